@@ -2,8 +2,13 @@ package vmcommon
 
 import (
 	"bytes"
+	"encoding/binary"
+	"fmt"
 
+	"github.com/btcsuite/btcd/btcutil/bech32"
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
 )
 
 // SystemAccountAddress is the hard-coded address in which we save global settings on all shards
@@ -24,6 +29,55 @@ const numInitCharactersForOnMetachainSC = 15
 
 const numInitCharactersForSystemAccountAddress = 30
 
+// DefaultAddressHRP is the human-readable part used by the MultiversX bech32 addresses
+const DefaultAddressHRP = "erd"
+
+const bech32FromBits = 8
+const bech32ToBits = 5
+
+// AddressKind defines the kind of an address
+type AddressKind uint8
+
+const (
+	// EmptyAddressKind is the kind of an empty or all-zero address
+	EmptyAddressKind AddressKind = iota
+	// UserAddressKind is the kind of a user (wallet) address
+	UserAddressKind
+	// SmartContractAddressKind is the kind of a smart contract deployed in a regular shard
+	SmartContractAddressKind
+	// MetachainSmartContractAddressKind is the kind of a system smart contract residing on the metachain
+	MetachainSmartContractAddressKind
+	// SystemAccountAddressKind is the kind of the system account holding global settings
+	SystemAccountAddressKind
+)
+
+// String returns the human-readable name of the address kind
+func (kind AddressKind) String() string {
+	switch kind {
+	case EmptyAddressKind:
+		return "empty"
+	case UserAddressKind:
+		return "user"
+	case SmartContractAddressKind:
+		return "smart contract"
+	case MetachainSmartContractAddressKind:
+		return "metachain smart contract"
+	case SystemAccountAddressKind:
+		return "system account"
+	default:
+		return fmt.Sprintf("unknown address kind: %d", kind)
+	}
+}
+
+// AddressInfo holds the classification of an address
+type AddressInfo struct {
+	Kind AddressKind
+	// VMType is only set for smart contract addresses
+	VMType []byte
+	// ShardIdentifier holds the last ShardIdentiferLen bytes of the address
+	ShardIdentifier []byte
+}
+
 // IsSystemAccountAddress returns true if given address is system account address
 func IsSystemAccountAddress(address []byte) bool {
 	if len(address) < numInitCharactersForSystemAccountAddress {
@@ -110,3 +164,111 @@ func ParseVMTypeFromContractAddress(contractAddress []byte) ([]byte, error) {
 	endIndex := NumInitCharactersForScAddress
 	return contractAddress[startIndex:endIndex], nil
 }
+
+// ComputeContractAddress is the reference implementation of BlockchainHook.NewAddress. It builds the standard smart
+// contract address layout: NumInitCharactersForScAddress-VMTypeLen zero bytes, followed by the VM type, followed by
+// the keccak hash of the creator address and its little endian encoded nonce, ending with the last ShardIdentiferLen
+// bytes of the creator address, so that the contract is placed in the same shard as its creator.
+func ComputeContractAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	if len(creatorAddress) < ShardIdentiferLen {
+		return nil, ErrInvalidCreatorAddress
+	}
+	if len(vmType) != VMTypeLen {
+		return nil, ErrInvalidVMType
+	}
+
+	addressAndNonce := make([]byte, len(creatorAddress), len(creatorAddress)+8)
+	copy(addressAndNonce, creatorAddress)
+	addressAndNonce = binary.LittleEndian.AppendUint64(addressAndNonce, creatorNonce)
+
+	address := keccak.NewKeccak().Compute(string(addressAndNonce))
+	copy(address[:NumInitCharactersForScAddress-VMTypeLen], make([]byte, NumInitCharactersForScAddress-VMTypeLen))
+	copy(address[NumInitCharactersForScAddress-VMTypeLen:NumInitCharactersForScAddress], vmType)
+	copy(address[len(address)-ShardIdentiferLen:], creatorAddress[len(creatorAddress)-ShardIdentiferLen:])
+
+	return address, nil
+}
+
+// ClassifyAddress returns the kind of the provided address, together with its VM type and shard identifier bytes
+func ClassifyAddress(address []byte) AddressInfo {
+	info := AddressInfo{
+		Kind: UserAddressKind,
+	}
+	if len(address) >= ShardIdentiferLen {
+		info.ShardIdentifier = address[len(address)-ShardIdentiferLen:]
+	}
+
+	switch {
+	case IsEmptyAddress(address):
+		info.Kind = EmptyAddressKind
+	case IsSystemAccountAddress(address):
+		info.Kind = SystemAccountAddressKind
+	case IsSmartContractAddress(address):
+		info.Kind = SmartContractAddressKind
+		if IsSmartContractOnMetachain(info.ShardIdentifier, address) {
+			info.Kind = MetachainSmartContractAddressKind
+		}
+		info.VMType, _ = ParseVMTypeFromContractAddress(address)
+	}
+
+	return info
+}
+
+type bech32AddressCodec struct {
+	hrp string
+}
+
+// NewBech32AddressCodec creates a codec that converts addresses to and from the bech32 format using the provided
+// human-readable part (e.g. DefaultAddressHRP)
+func NewBech32AddressCodec(hrp string) (*bech32AddressCodec, error) {
+	if !check.IfHrp(hrp) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHRP, hrp)
+	}
+
+	return &bech32AddressCodec{
+		hrp: hrp,
+	}, nil
+}
+
+// Encode returns the bech32 representation of the address
+func (codec *bech32AddressCodec) Encode(address []byte) (string, error) {
+	converted, err := bech32.ConvertBits(address, bech32FromBits, bech32ToBits, true)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidBech32Address, err.Error())
+	}
+
+	encoded, err := bech32.Encode(codec.hrp, converted)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidBech32Address, err.Error())
+	}
+
+	return encoded, nil
+}
+
+// Decode returns the address from its bech32 representation. The human-readable part must match the configured one.
+func (codec *bech32AddressCodec) Decode(encoded string) ([]byte, error) {
+	hrp, data, err := bech32.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBech32Address, err.Error())
+	}
+	if hrp != codec.hrp {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrInvalidHRP, codec.hrp, hrp)
+	}
+
+	address, err := bech32.ConvertBits(data, bech32ToBits, bech32FromBits, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBech32Address, err.Error())
+	}
+
+	return address, nil
+}
+
+// HRP returns the configured human-readable part
+func (codec *bech32AddressCodec) HRP() string {
+	return codec.hrp
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (codec *bech32AddressCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
@@ -1,10 +1,16 @@
 package vmcommon
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAddress_isSmartContractAddress(t *testing.T) {
@@ -93,3 +99,132 @@ func Test_ParseVMTypeFromContractAddress_Invalid(t *testing.T) {
 	assert.Nil(t, vmType)
 	assert.Equal(t, ErrInvalidVMType, err)
 }
+
+func TestClassifyAddress(t *testing.T) {
+	t.Parallel()
+
+	info := ClassifyAddress(nil)
+	assert.Equal(t, EmptyAddressKind, info.Kind)
+	assert.Nil(t, info.ShardIdentifier)
+
+	info = ClassifyAddress(make([]byte, 32))
+	assert.Equal(t, EmptyAddressKind, info.Kind)
+
+	userAddress, _ := hex.DecodeString("000000000001000000005fed9c659422cd8429ce92f8973bba2a9fb51e0eb3a1")
+	info = ClassifyAddress(userAddress)
+	assert.Equal(t, UserAddressKind, info.Kind)
+	assert.Nil(t, info.VMType)
+	assert.Equal(t, []byte{0xb3, 0xa1}, info.ShardIdentifier)
+
+	scAddress, _ := hex.DecodeString("000000000000000005005fed9c659422cd8429ce92f8973bba2a9fb51e0eb3a1")
+	info = ClassifyAddress(scAddress)
+	assert.Equal(t, SmartContractAddressKind, info.Kind)
+	assert.Equal(t, []byte{5, 0}, info.VMType)
+	assert.Equal(t, []byte{0xb3, 0xa1}, info.ShardIdentifier)
+
+	metaSCAddress, _ := hex.DecodeString("000000000000000000010000000000000000000000000000000000000002ffff")
+	info = ClassifyAddress(metaSCAddress)
+	assert.Equal(t, MetachainSmartContractAddressKind, info.Kind)
+	assert.Equal(t, []byte{0, 1}, info.VMType)
+	assert.Equal(t, []byte{0xff, 0xff}, info.ShardIdentifier)
+
+	info = ClassifyAddress(SystemAccountAddress)
+	assert.Equal(t, SystemAccountAddressKind, info.Kind)
+	assert.Equal(t, "system account", info.Kind.String())
+}
+
+func TestNewBech32AddressCodec_InvalidHRPShouldErr(t *testing.T) {
+	t.Parallel()
+
+	codec, err := NewBech32AddressCodec("")
+	assert.Nil(t, codec)
+	assert.True(t, errors.Is(err, ErrInvalidHRP))
+
+	codec, err = NewBech32AddressCodec("erd1")
+	assert.Nil(t, codec)
+	assert.True(t, errors.Is(err, ErrInvalidHRP))
+}
+
+func TestBech32AddressCodec_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	codec, err := NewBech32AddressCodec(DefaultAddressHRP)
+	require.Nil(t, err)
+	assert.Equal(t, DefaultAddressHRP, codec.HRP())
+
+	esdtSCAddress, _ := hex.DecodeString("000000000000000000010000000000000000000000000000000000000002ffff")
+	encoded, err := codec.Encode(esdtSCAddress)
+	require.Nil(t, err)
+	assert.Equal(t, "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqzllls8a5w6u", encoded)
+
+	coreConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, DefaultAddressHRP)
+	userAddress, _ := hex.DecodeString("000000000001000000005fed9c659422cd8429ce92f8973bba2a9fb51e0eb3a1")
+	encoded, err = codec.Encode(userAddress)
+	require.Nil(t, err)
+	expectedEncoded, _ := coreConverter.Encode(userAddress)
+	assert.Equal(t, expectedEncoded, encoded)
+
+	decoded, err := codec.Decode(encoded)
+	require.Nil(t, err)
+	assert.Equal(t, userAddress, decoded)
+}
+
+func TestBech32AddressCodec_DecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewBech32AddressCodec("test")
+	erdCodec, _ := NewBech32AddressCodec(DefaultAddressHRP)
+	encoded, _ := erdCodec.Encode([]byte("address"))
+
+	_, err := codec.Decode(encoded)
+	assert.True(t, errors.Is(err, ErrInvalidHRP))
+
+	_, err = codec.Decode("not a bech32 address")
+	assert.True(t, errors.Is(err, ErrInvalidBech32Address))
+}
+
+func TestComputeContractAddress_InvalidArgsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	address, err := ComputeContractAddress([]byte{1}, 0, []byte{5, 0})
+	assert.Nil(t, address)
+	assert.Equal(t, ErrInvalidCreatorAddress, err)
+
+	address, err = ComputeContractAddress(bytes.Repeat([]byte{1}, 32), 0, []byte{5})
+	assert.Nil(t, address)
+	assert.Equal(t, ErrInvalidVMType, err)
+}
+
+func TestComputeContractAddress(t *testing.T) {
+	t.Parallel()
+
+	creator, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	creatorCopy := append([]byte{}, creator...)
+	vmType := []byte{5, 0}
+
+	address, err := ComputeContractAddress(creator, 37, vmType)
+	require.Nil(t, err)
+	require.Len(t, address, 32)
+	assert.Equal(t, creatorCopy, creator)
+
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, 37)
+	expected := keccak.NewKeccak().Compute(string(append(creatorCopy, nonceBytes...)))
+	copy(expected[:NumInitCharactersForScAddress], append(make([]byte, NumInitCharactersForScAddress-VMTypeLen), vmType...))
+	copy(expected[30:], creator[30:])
+	assert.Equal(t, expected, address)
+
+	assert.True(t, IsSmartContractAddress(address))
+	parsedVMType, err := ParseVMTypeFromContractAddress(address)
+	require.Nil(t, err)
+	assert.Equal(t, vmType, parsedVMType)
+	assert.Equal(t, creator[30:], address[30:])
+
+	info := ClassifyAddress(address)
+	assert.Equal(t, SmartContractAddressKind, info.Kind)
+	assert.Equal(t, vmType, info.VMType)
+
+	otherAddress, _ := ComputeContractAddress(creator, 38, vmType)
+	assert.NotEqual(t, address, otherAddress)
+	assert.True(t, IsSmartContractAddress(otherAddress))
+}
@@ -0,0 +1,228 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ArgumentType is the type of a positional argument of a built-in function
+type ArgumentType string
+
+const (
+	// ArgumentTokenIdentifier is a non empty token identifier
+	ArgumentTokenIdentifier ArgumentType = "tokenIdentifier"
+	// ArgumentNonce is a big endian unsigned integer of at most 8 bytes
+	ArgumentNonce ArgumentType = "nonce"
+	// ArgumentBigUint is a big endian unsigned integer of any length
+	ArgumentBigUint ArgumentType = "bigUint"
+	// ArgumentAddress is an address of the same length as the caller address
+	ArgumentAddress ArgumentType = "address"
+	// ArgumentBytes is any byte slice
+	ArgumentBytes ArgumentType = "bytes"
+	// ArgumentRepeated is a group of arguments repeated a number of times
+	ArgumentRepeated ArgumentType = "repeated"
+)
+
+const maxNonceLength = 8
+
+// ArgumentSchema describes a positional argument, or a group of repeated arguments for the ArgumentRepeated type
+type ArgumentSchema struct {
+	Name string       `json:"name"`
+	Type ArgumentType `json:"type"`
+	// Group holds the repeated arguments of an ArgumentRepeated entry
+	Group []*ArgumentSchema `json:"group,omitempty"`
+	// CountArgument is the name of a previous argument holding the number of repetitions. When empty, the group is
+	// repeated up to the arguments that follow it, which must not be repeated groups.
+	CountArgument string `json:"countArgument,omitempty"`
+	// MinCount is the minimum number of repetitions of the group
+	MinCount int `json:"minCount,omitempty"`
+}
+
+// FunctionSchema describes the arguments of a built-in function as they are sent in the transaction data. For the
+// transfers, these are the arguments of the call on the sender, before they are rewritten for the destination.
+type FunctionSchema struct {
+	FunctionName string            `json:"functionName"`
+	Arguments    []*ArgumentSchema `json:"arguments"`
+	// ExecOnDestByCallerArguments replaces Arguments for the calls of type vm.ExecOnDestByCaller, when set
+	ExecOnDestByCallerArguments []*ArgumentSchema `json:"execOnDestByCallerArguments,omitempty"`
+}
+
+// ArgumentsFor returns the arguments expected for the provided call type
+func (fs *FunctionSchema) ArgumentsFor(callType vm.CallType) []*ArgumentSchema {
+	if callType == vm.ExecOnDestByCaller && fs.ExecOnDestByCallerArguments != nil {
+		return fs.ExecOnDestByCallerArguments
+	}
+
+	return fs.Arguments
+}
+
+// ArgumentIndex returns the position of the named argument, or of the first argument of the named repeated group. Only
+// the entries up to the first repeated group have a fixed position.
+func (fs *FunctionSchema) ArgumentIndex(name string) (int, bool) {
+	for i, argument := range fs.Arguments {
+		if argument.Name == name {
+			return i, true
+		}
+		if argument.Type == ArgumentRepeated {
+			break
+		}
+	}
+
+	return 0, false
+}
+
+// Validate checks the arguments of the call against the schema. The addresses must have the length of the caller
+func (fs *FunctionSchema) Validate(input *vmcommon.ContractCallInput) error {
+	if input == nil {
+		return ErrNilVmInput
+	}
+
+	return ValidateArguments(fs.ArgumentsFor(input.CallType), input.Arguments, len(input.CallerAddr))
+}
+
+// ValidateArguments checks that the arguments match the schema, with no argument left over
+func ValidateArguments(schema []*ArgumentSchema, arguments [][]byte, addressLength int) error {
+	validator := &argumentsValidator{
+		arguments:     arguments,
+		addressLength: addressLength,
+		counts:        make(map[string]uint64),
+	}
+
+	err := validator.validateList(schema, len(arguments))
+	if err != nil {
+		return err
+	}
+	if validator.index != len(arguments) {
+		return fmt.Errorf("%w, expected %d, got %d", ErrInvalidNumberOfArguments, validator.index, len(arguments))
+	}
+
+	return nil
+}
+
+type argumentsValidator struct {
+	arguments     [][]byte
+	index         int
+	addressLength int
+	counts        map[string]uint64
+}
+
+// validateList consumes the arguments described by the schema, without going past the end index
+func (av *argumentsValidator) validateList(schema []*ArgumentSchema, end int) error {
+	for i, argument := range schema {
+		var err error
+		switch {
+		case argument.Type != ArgumentRepeated:
+			err = av.validateArgument(argument, end)
+		case len(argument.CountArgument) > 0:
+			err = av.validateCountedGroup(argument, end)
+		default:
+			err = av.validateGroupUpTo(argument, schema[i+1:], end)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (av *argumentsValidator) validateCountedGroup(argument *ArgumentSchema, end int) error {
+	if len(argument.Group) == 0 {
+		return fmt.Errorf("%w, empty repeated group %s", ErrInvalidArguments, argument.Name)
+	}
+	count, ok := av.counts[argument.CountArgument]
+	if !ok {
+		return fmt.Errorf("%w, invalid count argument %s of %s", ErrInvalidArguments, argument.CountArgument, argument.Name)
+	}
+	if count < uint64(argument.MinCount) {
+		return fmt.Errorf("%w, %s repeated %d times, expected at least %d", ErrInvalidArguments, argument.Name, count, argument.MinCount)
+	}
+
+	for i := uint64(0); i < count; i++ {
+		err := av.validateList(argument.Group, end)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateGroupUpTo repeats the group up to the arguments described by the following entries of the schema
+func (av *argumentsValidator) validateGroupUpTo(argument *ArgumentSchema, following []*ArgumentSchema, end int) error {
+	if len(argument.Group) == 0 {
+		return fmt.Errorf("%w, empty repeated group %s", ErrInvalidArguments, argument.Name)
+	}
+	for _, next := range following {
+		if next.Type == ArgumentRepeated {
+			return fmt.Errorf("%w, %s is followed by another repeated group", ErrInvalidArguments, argument.Name)
+		}
+	}
+
+	groupEnd := end - len(following)
+	count := 0
+	for ; av.index < groupEnd; count++ {
+		start := av.index
+		err := av.validateList(argument.Group, groupEnd)
+		if err != nil {
+			return err
+		}
+		if av.index == start {
+			return fmt.Errorf("%w, repeated group %s consumes no arguments", ErrInvalidArguments, argument.Name)
+		}
+	}
+	if count < argument.MinCount {
+		return fmt.Errorf("%w, %s repeated %d times, expected at least %d", ErrInvalidNumberOfArguments, argument.Name, count, argument.MinCount)
+	}
+
+	return nil
+}
+
+func (av *argumentsValidator) validateArgument(argument *ArgumentSchema, end int) error {
+	if av.index >= end {
+		return fmt.Errorf("%w, missing argument %s", ErrInvalidNumberOfArguments, argument.Name)
+	}
+
+	value := av.arguments[av.index]
+	err := validateArgumentValue(argument.Type, value, av.addressLength)
+	if err != nil {
+		return fmt.Errorf("%w, argument %d (%s)", err, av.index, argument.Name)
+	}
+
+	if argument.Type == ArgumentBigUint || argument.Type == ArgumentNonce {
+		count := big.NewInt(0).SetBytes(value)
+		if count.IsUint64() {
+			av.counts[argument.Name] = count.Uint64()
+		} else {
+			delete(av.counts, argument.Name)
+		}
+	}
+	av.index++
+
+	return nil
+}
+
+func validateArgumentValue(argumentType ArgumentType, value []byte, addressLength int) error {
+	switch argumentType {
+	case ArgumentTokenIdentifier:
+		if len(value) == 0 {
+			return fmt.Errorf("%w, empty token identifier", ErrInvalidArguments)
+		}
+	case ArgumentNonce:
+		if len(value) > maxNonceLength {
+			return fmt.Errorf("%w, nonce longer than %d bytes", ErrInvalidArguments, maxNonceLength)
+		}
+	case ArgumentAddress:
+		if len(value) != addressLength {
+			return ErrInvalidAddressLength
+		}
+	case ArgumentBigUint, ArgumentBytes:
+	default:
+		return fmt.Errorf("%w, unknown argument type %s", ErrInvalidArguments, argumentType)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateArguments_Types(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{1}, 32)
+	schema := []*ArgumentSchema{
+		argument("token", ArgumentTokenIdentifier),
+		argument("nonce", ArgumentNonce),
+		argument("value", ArgumentBigUint),
+		argument("destination", ArgumentAddress),
+		argument("data", ArgumentBytes),
+	}
+
+	assert.Nil(t, ValidateArguments(schema, [][]byte{[]byte("TKN-abcdef"), {1}, {}, address, {}}, 32))
+
+	err := ValidateArguments(schema, [][]byte{{}, {1}, {}, address, {}}, 32)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	err = ValidateArguments(schema, [][]byte{[]byte("TKN"), make([]byte, 9), {}, address, {}}, 32)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	err = ValidateArguments(schema, [][]byte{[]byte("TKN"), {1}, {}, address[1:], {}}, 32)
+	assert.True(t, errors.Is(err, ErrInvalidAddressLength))
+	err = ValidateArguments(schema, [][]byte{[]byte("TKN"), {1}, {}, address}, 32)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	err = ValidateArguments(schema, [][]byte{[]byte("TKN"), {1}, {}, address, {}, {}}, 32)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	err = ValidateArguments([]*ArgumentSchema{argument("unknown", "unknown")}, [][]byte{{}}, 32)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+}
+
+func TestValidateArguments_RepeatedGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("group up to the end", func(t *testing.T) {
+		t.Parallel()
+
+		schema := []*ArgumentSchema{repeatedUpTo("pairs", 1, argument("key", ArgumentBytes), argument("value", ArgumentBytes))}
+		assert.Nil(t, ValidateArguments(schema, [][]byte{{1}, {2}, {3}, {4}}, 32))
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{{1}, {2}, {3}}, 32), ErrInvalidNumberOfArguments))
+		assert.True(t, errors.Is(ValidateArguments(schema, nil, 32), ErrInvalidNumberOfArguments))
+	})
+	t.Run("group followed by fixed arguments", func(t *testing.T) {
+		t.Parallel()
+
+		schema := []*ArgumentSchema{
+			repeatedUpTo("uris", 1, argument("uri", ArgumentBytes)),
+			argument("address", ArgumentAddress),
+		}
+		assert.Nil(t, ValidateArguments(schema, [][]byte{{1}, {2}, make([]byte, 32)}, 32))
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{make([]byte, 32)}, 32), ErrInvalidNumberOfArguments))
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{{1}, {2}}, 32), ErrInvalidAddressLength))
+	})
+	t.Run("group followed by another repeated group", func(t *testing.T) {
+		t.Parallel()
+
+		schema := []*ArgumentSchema{
+			repeatedUpTo("first", 0, argument("a", ArgumentBytes)),
+			repeatedUpTo("second", 0, argument("b", ArgumentBytes)),
+		}
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{{1}}, 32), ErrInvalidArguments))
+	})
+	t.Run("counted group", func(t *testing.T) {
+		t.Parallel()
+
+		schema := []*ArgumentSchema{
+			argument("count", ArgumentBigUint),
+			repeatedCounted("transfers", "count", 1, argument("token", ArgumentTokenIdentifier), argument("value", ArgumentBigUint)),
+			repeatedUpTo("rest", 0, argument("data", ArgumentBytes)),
+		}
+		assert.Nil(t, ValidateArguments(schema, [][]byte{{2}, []byte("A"), {1}, []byte("B"), {2}}, 32))
+		assert.Nil(t, ValidateArguments(schema, [][]byte{{1}, []byte("A"), {1}, []byte("f"), {3}}, 32))
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{{0}}, 32), ErrInvalidArguments))
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{{3}, []byte("A"), {1}}, 32), ErrInvalidNumberOfArguments))
+		err := ValidateArguments(schema, [][]byte{bytes.Repeat([]byte{0xFF}, 9), []byte("A"), {1}}, 32)
+		assert.True(t, errors.Is(err, ErrInvalidArguments))
+	})
+	t.Run("nested groups", func(t *testing.T) {
+		t.Parallel()
+
+		schema, _ := GetFunctionSchema(vmcommon.ESDTDeleteMetadata)
+		args := [][]byte{[]byte("A"), {2}, {1}, {2}, {5}, {6}, []byte("B"), {1}, {1}, {1}}
+		assert.Nil(t, ValidateArguments(schema.Arguments, args, 32))
+		assert.True(t, errors.Is(ValidateArguments(schema.Arguments, args[:9], 32), ErrInvalidNumberOfArguments))
+	})
+	t.Run("group consuming no arguments", func(t *testing.T) {
+		t.Parallel()
+
+		schema := []*ArgumentSchema{
+			argument("count", ArgumentBigUint),
+			repeatedUpTo("outer", 0, repeatedCounted("inner", "count", 0, argument("a", ArgumentBytes))),
+			argument("last", ArgumentBytes),
+		}
+		assert.True(t, errors.Is(ValidateArguments(schema, [][]byte{{0}, {1}, {2}}, 32), ErrInvalidArguments))
+		assert.True(t, errors.Is(ValidateArguments([]*ArgumentSchema{repeatedUpTo("empty", 0)}, [][]byte{{1}}, 32), ErrInvalidArguments))
+	})
+}
+
+func TestFunctionSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	schema, ok := GetFunctionSchema("ESDTNFTCreate")
+	assert.True(t, ok)
+	assert.Equal(t, ErrNilVmInput, schema.Validate(nil))
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: make([]byte, 32),
+			Arguments:  [][]byte{[]byte("NFT-abcdef"), {1}, []byte("name"), {10}, {}, {}, []byte("uri")},
+			CallType:   vm.DirectCall,
+		},
+	}
+	assert.Nil(t, schema.Validate(input))
+
+	input.CallType = vm.ExecOnDestByCaller
+	assert.True(t, errors.Is(schema.Validate(input), ErrInvalidNumberOfArguments))
+	input.Arguments = append(input.Arguments, make([]byte, 32))
+	assert.Nil(t, schema.Validate(input))
+}
+
+func TestFunctionSchema_ArgumentIndex(t *testing.T) {
+	t.Parallel()
+
+	schema := &FunctionSchema{
+		Arguments: []*ArgumentSchema{
+			argument("token", ArgumentTokenIdentifier),
+			repeatedUpTo("addresses", 1, argument("address", ArgumentAddress)),
+			argument("last", ArgumentBytes),
+		},
+	}
+
+	index, ok := schema.ArgumentIndex("token")
+	assert.True(t, ok)
+	assert.Equal(t, 0, index)
+	index, ok = schema.ArgumentIndex("addresses")
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+	_, ok = schema.ArgumentIndex("last")
+	assert.False(t, ok)
+	_, ok = schema.ArgumentIndex("address")
+	assert.False(t, ok)
+}
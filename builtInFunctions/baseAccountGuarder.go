@@ -47,9 +47,7 @@ func newBaseAccountGuarder(args BaseAccountGuarderArgs) (*baseAccountGuarder, er
 		guardedAccountHandler: args.GuardedAccountHandler,
 	}
 
-	accGuarder.activeHandler = func() bool {
-		return args.EnableEpochsHandler.IsFlagEnabled(SetGuardianFlag)
-	}
+	accGuarder.baseActiveHandler = newFlagActiveHandler(args.EnableEpochsHandler, SetGuardianFlag)
 
 	return accGuarder, nil
 }
@@ -85,3 +83,11 @@ func (baf *baseAccountGuarder) checkBaseAccountGuarderArgs(
 func isZero(n *big.Int) bool {
 	return len(n.Bits()) == 0
 }
+
+// FuncGasCost returns the gas cost charged by the function
+func (baf *baseAccountGuarder) FuncGasCost() uint64 {
+	baf.mutExecution.RLock()
+	defer baf.mutExecution.RUnlock()
+
+	return baf.funcGasCost
+}
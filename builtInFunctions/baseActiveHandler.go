@@ -1,5 +1,10 @@
 package builtInFunctions
 
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
 type baseAlwaysActiveHandler struct {
 }
 
@@ -8,13 +13,44 @@ func (b baseAlwaysActiveHandler) IsActive() bool {
 	return trueHandler()
 }
 
+// ActivationFlag returns an empty flag as this built-in function is always active
+func (b baseAlwaysActiveHandler) ActivationFlag() core.EnableEpochFlag {
+	return ""
+}
+
+// IsDeactivationFlag returns false as this built-in function is always active
+func (b baseAlwaysActiveHandler) IsDeactivationFlag() bool {
+	return false
+}
+
 // IsInterfaceNil always returns false
 func (b baseAlwaysActiveHandler) IsInterfaceNil() bool {
 	return false
 }
 
 type baseActiveHandler struct {
-	activeHandler func() bool
+	activeHandler      func() bool
+	activationFlag     core.EnableEpochFlag
+	isDeactivationFlag bool
+}
+
+// newFlagActiveHandler creates the active handler of a function that is active while the flag is enabled
+func newFlagActiveHandler(enableEpochsHandler vmcommon.EnableEpochsHandler, flag core.EnableEpochFlag) baseActiveHandler {
+	return baseActiveHandler{
+		activeHandler: func() bool {
+			return enableEpochsHandler.IsFlagEnabled(flag)
+		},
+		activationFlag: flag,
+	}
+}
+
+// newDeactivationFlagActiveHandler creates the active handler of a function that is active while the flag is
+// enabled, the flag being enabled until its epoch instead of from it
+func newDeactivationFlagActiveHandler(enableEpochsHandler vmcommon.EnableEpochsHandler, flag core.EnableEpochFlag) baseActiveHandler {
+	activeHandler := newFlagActiveHandler(enableEpochsHandler, flag)
+	activeHandler.isDeactivationFlag = true
+
+	return activeHandler
 }
 
 // IsActive returns true if function is active
@@ -22,6 +58,16 @@ func (b *baseActiveHandler) IsActive() bool {
 	return b.activeHandler()
 }
 
+// ActivationFlag returns the flag that gates the function, or an empty flag if the function is not gated
+func (b *baseActiveHandler) ActivationFlag() core.EnableEpochFlag {
+	return b.activationFlag
+}
+
+// IsDeactivationFlag returns true if the epoch of the flag is the one from which the function is no longer active
+func (b *baseActiveHandler) IsDeactivationFlag() bool {
+	return b.isDeactivationFlag
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (b *baseActiveHandler) IsInterfaceNil() bool {
 	return b == nil
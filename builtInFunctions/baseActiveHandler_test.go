@@ -3,7 +3,9 @@ package builtInFunctions
 import (
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,3 +31,26 @@ func TestBaseAlwaysActiveHandler_IsActive(t *testing.T) {
 	assert.False(t, check.IfNil(handler))
 	assert.True(t, handler.IsActive())
 }
+
+func TestNewFlagActiveHandler(t *testing.T) {
+	t.Parallel()
+
+	enabled := false
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == DynamicEsdtFlag && enabled
+		},
+	}
+
+	handler := newFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
+	assert.Equal(t, DynamicEsdtFlag, handler.ActivationFlag())
+	assert.False(t, handler.IsDeactivationFlag())
+	assert.False(t, handler.IsActive())
+	enabled = true
+	assert.True(t, handler.IsActive())
+
+	handler = newDeactivationFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
+	assert.Equal(t, DynamicEsdtFlag, handler.ActivationFlag())
+	assert.True(t, handler.IsDeactivationFlag())
+	assert.True(t, handler.IsActive())
+}
@@ -41,6 +41,14 @@ func (c *changeOwnerAddress) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	c.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (c *changeOwnerAddress) FuncGasCost() uint64 {
+	c.mutExecution.RLock()
+	defer c.mutExecution.RUnlock()
+
+	return c.gasCost
+}
+
 // ProcessBuiltinFunction processes simple protocol built-in function
 func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
@@ -32,6 +32,14 @@ func (c *claimDeveloperRewards) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	c.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (c *claimDeveloperRewards) FuncGasCost() uint64 {
+	c.mutExecution.RLock()
+	defer c.mutExecution.RUnlock()
+
+	return c.gasCost
+}
+
 // ProcessBuiltinFunction processes the protocol built-in smart contract function
 func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
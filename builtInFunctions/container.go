@@ -2,7 +2,11 @@ package builtInFunctions
 
 import (
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/container"
@@ -12,7 +16,26 @@ var _ vmcommon.BuiltInFunctionContainer = (*functionContainer)(nil)
 
 // functionContainer is an interceptors holder organized by type
 type functionContainer struct {
-	objects *container.MutexMap
+	objects                *container.MutexMap
+	mutInterceptors        sync.Mutex
+	interceptors           atomic.Pointer[[]ExecutionInterceptor]
+	mutEnableEpochsHandler sync.RWMutex
+	enableEpochsHandler    vmcommon.EnableEpochsHandler
+}
+
+// FunctionActivation describes when a built-in function is active
+type FunctionActivation struct {
+	Name string
+	// Flag is the enable epoch flag that gates the function, empty if the function is always active
+	Flag core.EnableEpochFlag
+	// IsDeactivationFlag is true if the function is active only before the epoch of the flag
+	IsDeactivationFlag bool
+	// ActivationEpoch is the epoch from which the function is active, 0 if the function is always active or if it
+	// is gated by a deactivation flag
+	ActivationEpoch uint32
+	// DeactivationEpoch is the epoch from which the function is no longer active, 0 if the function is not gated by
+	// a deactivation flag
+	DeactivationEpoch uint32
 }
 
 // NewBuiltInFunctionContainer will create a new instance of a container
@@ -23,7 +46,9 @@ func NewBuiltInFunctionContainer() *functionContainer {
 }
 
 // Get returns the object stored at a certain key.
-// Returns an error if the element does not exist
+// Returns an error if the element does not exist.
+// The returned function runs through the execution interceptors of the container and must be unwrapped with
+// UnwrapBuiltinFunction before being type asserted.
 func (f *functionContainer) Get(key string) (vmcommon.BuiltinFunction, error) {
 	value, ok := f.objects.Get(key)
 	if !ok {
@@ -38,6 +63,27 @@ func (f *functionContainer) Get(key string) (vmcommon.BuiltinFunction, error) {
 	return function, nil
 }
 
+// AddExecutionInterceptor adds an interceptor around the execution of all the functions of the container, including
+// the ones already returned by Get. The first added interceptor is the outermost one.
+func (f *functionContainer) AddExecutionInterceptor(interceptor ExecutionInterceptor) error {
+	if check.IfNil(interceptor) {
+		return ErrNilExecutionInterceptor
+	}
+
+	f.mutInterceptors.Lock()
+	defer f.mutInterceptors.Unlock()
+
+	interceptors := make([]ExecutionInterceptor, 0)
+	current := f.interceptors.Load()
+	if current != nil {
+		interceptors = append(interceptors, *current...)
+	}
+	interceptors = append(interceptors, interceptor)
+	f.interceptors.Store(&interceptors)
+
+	return nil
+}
+
 // Add will add an object at a given key. Returns
 // an error if the element already exists
 func (f *functionContainer) Add(key string, function vmcommon.BuiltinFunction) error {
@@ -48,7 +94,7 @@ func (f *functionContainer) Add(key string, function vmcommon.BuiltinFunction) e
 		return ErrEmptyFunctionName
 	}
 
-	ok := f.objects.Insert(key, function)
+	ok := f.objects.Insert(key, newInterceptedFunction(key, function, f))
 	if !ok {
 		return ErrContainerKeyAlreadyExists
 	}
@@ -65,7 +111,7 @@ func (f *functionContainer) Replace(key string, function vmcommon.BuiltinFunctio
 		return ErrEmptyFunctionName
 	}
 
-	f.objects.Set(key, function)
+	f.objects.Set(key, newInterceptedFunction(key, function, f))
 	return nil
 }
 
@@ -95,6 +141,200 @@ func (f *functionContainer) Keys() map[string]struct{} {
 	return keys
 }
 
+// SetEnableEpochsHandler sets the handler used to resolve the activation of the functions in a given epoch
+func (f *functionContainer) SetEnableEpochsHandler(enableEpochsHandler vmcommon.EnableEpochsHandler) error {
+	if check.IfNil(enableEpochsHandler) {
+		return ErrNilEnableEpochsHandler
+	}
+
+	f.mutEnableEpochsHandler.Lock()
+	f.enableEpochsHandler = enableEpochsHandler
+	f.mutEnableEpochsHandler.Unlock()
+
+	return nil
+}
+
+// ActiveKeys returns the keys of the functions active in the current epoch
+func (f *functionContainer) ActiveKeys() map[string]struct{} {
+	keys := make(map[string]struct{})
+	for key, function := range f.functions() {
+		if function.IsActive() {
+			keys[key] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+// ActiveKeysInEpoch returns the keys of the functions active in the provided epoch. The functions that do not
+// implement ActivationFlagHandler are considered always active.
+func (f *functionContainer) ActiveKeysInEpoch(epoch uint32) (map[string]struct{}, error) {
+	enableEpochsHandler, err := f.getEnableEpochsHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for key, function := range f.functions() {
+		flag, _ := getActivationFlag(function)
+		if len(flag) == 0 || enableEpochsHandler.IsFlagEnabledInEpoch(flag, epoch) {
+			keys[key] = struct{}{}
+		}
+	}
+
+	return keys, nil
+}
+
+// ActivationInfo returns the flag gating each function and the epoch from which the function is active, or no longer
+// active for the deactivation flags, sorted by name. The functions that do not implement ActivationFlagHandler are
+// considered always active.
+func (f *functionContainer) ActivationInfo() ([]FunctionActivation, error) {
+	enableEpochsHandler, err := f.getEnableEpochsHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	activations := make([]FunctionActivation, 0, f.Len())
+	for key, function := range f.functions() {
+		activation := FunctionActivation{
+			Name: key,
+		}
+		activation.Flag, activation.IsDeactivationFlag = getActivationFlag(function)
+		switch {
+		case len(activation.Flag) == 0:
+		case activation.IsDeactivationFlag:
+			activation.DeactivationEpoch = enableEpochsHandler.GetActivationEpoch(activation.Flag)
+		default:
+			activation.ActivationEpoch = enableEpochsHandler.GetActivationEpoch(activation.Flag)
+		}
+
+		activations = append(activations, activation)
+	}
+
+	sort.Slice(activations, func(i, j int) bool {
+		return activations[i].Name < activations[j].Name
+	})
+
+	return activations, nil
+}
+
+// FunctionSchema returns the arguments schema of the function stored at the provided key
+func (f *functionContainer) FunctionSchema(key string) (*FunctionSchema, error) {
+	value, ok := f.objects.Get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w in function container for key %v", ErrInvalidContainerKey, key)
+	}
+	function, ok := value.(vmcommon.BuiltinFunction)
+	if !ok {
+		return nil, ErrWrongTypeInContainer
+	}
+
+	schema, ok := getFunctionSchema(key, UnwrapBuiltinFunction(function))
+	if !ok {
+		return nil, fmt.Errorf("%w for key %v", ErrFunctionSchemaNotFound, key)
+	}
+
+	return schema, nil
+}
+
+// FunctionSchemas returns the arguments schemas of all the functions that publish one, by key
+func (f *functionContainer) FunctionSchemas() map[string]*FunctionSchema {
+	schemas := make(map[string]*FunctionSchema)
+	for key, function := range f.functions() {
+		schema, ok := getFunctionSchema(key, function)
+		if ok {
+			schemas[key] = schema
+		}
+	}
+
+	return schemas
+}
+
+// ValidateArguments checks the arguments of the call against the schema of the called function
+func (f *functionContainer) ValidateArguments(input *vmcommon.ContractCallInput) error {
+	if input == nil {
+		return ErrNilVmInput
+	}
+
+	schema, err := f.FunctionSchema(input.Function)
+	if err != nil {
+		return err
+	}
+
+	return schema.Validate(input)
+}
+
+func (f *functionContainer) getEnableEpochsHandler() (vmcommon.EnableEpochsHandler, error) {
+	f.mutEnableEpochsHandler.RLock()
+	defer f.mutEnableEpochsHandler.RUnlock()
+
+	if check.IfNil(f.enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return f.enableEpochsHandler, nil
+}
+
+func (f *functionContainer) functions() map[string]vmcommon.BuiltinFunction {
+	functions := make(map[string]vmcommon.BuiltinFunction, f.Len())
+	for _, key := range f.objects.Keys() {
+		stringKey, ok := key.(string)
+		if !ok {
+			continue
+		}
+		value, ok := f.objects.Get(key)
+		if !ok {
+			continue
+		}
+		function, ok := value.(vmcommon.BuiltinFunction)
+		if !ok {
+			continue
+		}
+
+		functions[stringKey] = UnwrapBuiltinFunction(function)
+	}
+
+	return functions
+}
+
+// getTokenIdentifierArgument returns the first argument of the call if the function schema declares it as a token
+// identifier
+func getTokenIdentifierArgument(key string, function vmcommon.BuiltinFunction, vmInput *vmcommon.ContractCallInput) []byte {
+	if vmInput == nil || len(vmInput.Arguments) == 0 {
+		return nil
+	}
+
+	schema, ok := getFunctionSchema(key, function)
+	if !ok {
+		return nil
+	}
+	arguments := schema.ArgumentsFor(vmInput.CallType)
+	if len(arguments) == 0 || arguments[0].Type != ArgumentTokenIdentifier {
+		return nil
+	}
+
+	return vmInput.Arguments[0]
+}
+
+func getActivationFlag(function vmcommon.BuiltinFunction) (core.EnableEpochFlag, bool) {
+	activationFlagHandler, ok := function.(ActivationFlagHandler)
+	if !ok {
+		return "", false
+	}
+
+	return activationFlagHandler.ActivationFlag(), activationFlagHandler.IsDeactivationFlag()
+}
+
+func getFunctionSchema(key string, function vmcommon.BuiltinFunction) (*FunctionSchema, bool) {
+	schemaHandler, ok := function.(ArgumentsSchemaHandler)
+	if ok {
+		schema := schemaHandler.ArgumentsSchema()
+		return schema, schema != nil
+	}
+
+	return GetFunctionSchema(key)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (f *functionContainer) IsInterfaceNil() bool {
 	return f == nil
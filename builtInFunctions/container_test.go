@@ -4,7 +4,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -80,7 +82,7 @@ func TestBuiltInFunctionContainer_GetShouldWork(t *testing.T) {
 	_ = c.Add(key, val)
 	valRecovered, err := c.Get(key)
 
-	assert.True(t, val == valRecovered)
+	assert.True(t, val == UnwrapBuiltinFunction(valRecovered))
 	assert.Nil(t, err)
 }
 
@@ -100,7 +102,7 @@ func TestBuiltInFunctionContainer_ReplaceNilValueShouldErrAndNotModify(t *testin
 	valRecovered, _ := c.Get(key)
 
 	assert.Equal(t, ErrNilContainerElement, err)
-	assert.Equal(t, val, valRecovered)
+	assert.Equal(t, val, UnwrapBuiltinFunction(valRecovered))
 }
 
 func TestBuiltInFunctionContainer_ReplaceShouldWork(t *testing.T) {
@@ -117,7 +119,7 @@ func TestBuiltInFunctionContainer_ReplaceShouldWork(t *testing.T) {
 
 	valRecovered, _ := c.Get(key)
 
-	assert.True(t, val2 == valRecovered)
+	assert.True(t, val2 == UnwrapBuiltinFunction(valRecovered))
 	assert.Nil(t, err)
 }
 
@@ -156,3 +158,157 @@ func TestBuiltInFunctionContainer_LenShouldWork(t *testing.T) {
 	c.Remove("key1")
 	assert.Equal(t, 1, c.Len())
 }
+
+//------- Activation
+
+type gatedBuiltInFunctionStub struct {
+	mock.BuiltInFunctionStub
+	flag               core.EnableEpochFlag
+	isDeactivationFlag bool
+}
+
+func (stub *gatedBuiltInFunctionStub) ActivationFlag() core.EnableEpochFlag {
+	return stub.flag
+}
+
+func (stub *gatedBuiltInFunctionStub) IsDeactivationFlag() bool {
+	return stub.isDeactivationFlag
+}
+
+func createContainerWithGatedFunctions() *functionContainer {
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("always", &gatedBuiltInFunctionStub{})
+	_ = c.Add("unknown", &mock.BuiltInFunctionStub{})
+	_ = c.Add("gated", &gatedBuiltInFunctionStub{
+		BuiltInFunctionStub: mock.BuiltInFunctionStub{IsActiveCalled: func() bool { return false }},
+		flag:                DynamicEsdtFlag,
+	})
+	_ = c.Add("deactivated", &gatedBuiltInFunctionStub{
+		flag:               GlobalMintBurnFlag,
+		isDeactivationFlag: true,
+	})
+
+	return c
+}
+
+func TestBuiltInFunctionContainer_SetEnableEpochsHandler(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	assert.Equal(t, ErrNilEnableEpochsHandler, c.SetEnableEpochsHandler(nil))
+	assert.Nil(t, c.SetEnableEpochsHandler(&mock.EnableEpochsHandlerStub{}))
+}
+
+func TestBuiltInFunctionContainer_ActiveKeys(t *testing.T) {
+	t.Parallel()
+
+	c := createContainerWithGatedFunctions()
+	assert.Equal(t, map[string]struct{}{"always": {}, "unknown": {}, "deactivated": {}}, c.ActiveKeys())
+}
+
+func TestBuiltInFunctionContainer_ActiveKeysInEpoch(t *testing.T) {
+	t.Parallel()
+
+	c := createContainerWithGatedFunctions()
+	keys, err := c.ActiveKeysInEpoch(0)
+	assert.Nil(t, keys)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	_ = c.SetEnableEpochsHandler(&mock.EnableEpochsHandlerStub{
+		IsFlagEnabledInEpochCalled: func(flag core.EnableEpochFlag, epoch uint32) bool {
+			if flag == GlobalMintBurnFlag {
+				return epoch < 5
+			}
+			assert.Equal(t, DynamicEsdtFlag, flag)
+			return epoch >= 5
+		},
+	})
+	keys, err = c.ActiveKeysInEpoch(4)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{"always": {}, "unknown": {}, "deactivated": {}}, keys)
+	keys, err = c.ActiveKeysInEpoch(5)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{"always": {}, "unknown": {}, "gated": {}}, keys)
+}
+
+func TestBuiltInFunctionContainer_ActivationInfo(t *testing.T) {
+	t.Parallel()
+
+	c := createContainerWithGatedFunctions()
+	activations, err := c.ActivationInfo()
+	assert.Nil(t, activations)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	_ = c.SetEnableEpochsHandler(&mock.EnableEpochsHandlerStub{
+		GetActivationEpochCalled: func(flag core.EnableEpochFlag) uint32 {
+			return 5
+		},
+	})
+	activations, err = c.ActivationInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, []FunctionActivation{
+		{Name: "always"},
+		{Name: "deactivated", Flag: GlobalMintBurnFlag, IsDeactivationFlag: true, DeactivationEpoch: 5},
+		{Name: "gated", Flag: DynamicEsdtFlag, ActivationEpoch: 5},
+		{Name: "unknown"},
+	}, activations)
+}
+
+type describedBuiltInFunctionStub struct {
+	mock.BuiltInFunctionStub
+	schema *FunctionSchema
+}
+
+func (stub *describedBuiltInFunctionStub) ArgumentsSchema() *FunctionSchema {
+	return stub.schema
+}
+
+func TestBuiltInFunctionContainer_FunctionSchema(t *testing.T) {
+	t.Parallel()
+
+	described := &FunctionSchema{
+		FunctionName: "described",
+		Arguments:    []*ArgumentSchema{argument("token", ArgumentTokenIdentifier)},
+	}
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("described", &describedBuiltInFunctionStub{schema: described})
+	_ = c.Add("undescribed", &mock.BuiltInFunctionStub{})
+	_ = c.Add(core.BuiltInFunctionESDTPause, &mock.BuiltInFunctionStub{})
+
+	schema, err := c.FunctionSchema("described")
+	assert.Nil(t, err)
+	assert.True(t, schema == described)
+
+	schema, err = c.FunctionSchema(core.BuiltInFunctionESDTPause)
+	assert.Nil(t, err)
+	assert.Equal(t, core.BuiltInFunctionESDTPause, schema.FunctionName)
+
+	_, err = c.FunctionSchema("undescribed")
+	assert.True(t, errors.Is(err, ErrFunctionSchemaNotFound))
+	_, err = c.FunctionSchema("missing")
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+	schemas := c.FunctionSchemas()
+	assert.Equal(t, 2, len(schemas))
+	assert.True(t, schemas["described"] == described)
+}
+
+func TestBuiltInFunctionContainer_ValidateArguments(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add(core.BuiltInFunctionESDTPause, &mock.BuiltInFunctionStub{})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("TKN-abcdef")}},
+		Function: core.BuiltInFunctionESDTPause,
+	}
+	assert.Equal(t, ErrNilVmInput, c.ValidateArguments(nil))
+	assert.Nil(t, c.ValidateArguments(input))
+
+	input.Arguments = append(input.Arguments, []byte("extra"))
+	assert.True(t, errors.Is(c.ValidateArguments(input), ErrInvalidNumberOfArguments))
+
+	input.Function = "missing"
+	assert.True(t, errors.Is(c.ValidateArguments(input), ErrInvalidContainerKey))
+}
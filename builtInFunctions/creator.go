@@ -1,6 +1,10 @@
 package builtInFunctions
 
 import (
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/mitchellh/mapstructure"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -27,6 +31,8 @@ type ArgsCreateBuiltInFunctionContainer struct {
 	GuardedAccountHandler            vmcommon.GuardedAccountHandler
 	MaxNumOfAddressesForTransferRole uint32
 	ConfigAddress                    []byte
+	// MetricsSink is optional: when set, it receives an ExecutionRecord for every execution of a built-in function
+	MetricsSink ExecutionMetricsSink
 }
 
 type builtInFuncCreator struct {
@@ -36,7 +42,9 @@ type builtInFuncCreator struct {
 	marshaller                       vmcommon.Marshalizer
 	accounts                         vmcommon.AccountsAdapter
 	builtInFunctions                 vmcommon.BuiltInFunctionContainer
+	mutGasConfig                     sync.RWMutex
 	gasConfig                        *vmcommon.GasCost
+	gasConfigVersion                 uint32
 	shardCoordinator                 vmcommon.Coordinator
 	esdtStorageHandler               vmcommon.ESDTNFTStorageHandler
 	esdtGlobalSettingsHandler        vmcommon.ESDTGlobalSettingsHandler
@@ -44,6 +52,8 @@ type builtInFuncCreator struct {
 	guardedAccountHandler            vmcommon.GuardedAccountHandler
 	maxNumOfAddressesForTransferRole uint32
 	configAddress                    []byte
+	mutInterceptors                  sync.RWMutex
+	interceptors                     []ExecutionInterceptor
 }
 
 // NewBuiltInFunctionsCreator creates a component which will instantiate the built in functions contracts
@@ -91,27 +101,110 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 	if err != nil {
 		return nil, err
 	}
-	b.builtInFunctions = NewBuiltInFunctionContainer()
+	logUnknownGasCostFields("NewBuiltInFunctionsCreator", vmcommon.UnknownGasCostFields(args.GasMap))
+	if !check.IfNil(args.MetricsSink) {
+		metricsInterceptor, errMetrics := NewExecutionMetricsInterceptor(args.MetricsSink)
+		if errMetrics != nil {
+			return nil, errMetrics
+		}
+		b.interceptors = append(b.interceptors, metricsInterceptor)
+	}
+	b.builtInFunctions = b.createContainer()
 
 	return b, nil
 }
 
+// GasScheduleChangeResult holds the outcome of a successful gas schedule change
+type GasScheduleChangeResult struct {
+	// Version is the gas config version that became active
+	Version uint32
+	// UpdatedFunctions contains the names of all the built-in functions that received the new gas config, sorted
+	UpdatedFunctions []string
+	// Changes contains the gas cost fields that differ from the previously active gas config
+	Changes []*vmcommon.GasCostChange
+	// UnknownFields contains the fields of the gas cost sections that do not match any gas cost and were ignored
+	UnknownFields []string
+}
+
 // GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated
 func (b *builtInFuncCreator) GasScheduleChange(gasSchedule map[string]map[string]uint64) {
+	_, err := b.ApplyGasScheduleChange(gasSchedule)
+	if err != nil {
+		log.Error("builtInFuncCreator.GasScheduleChange: gas schedule not applied", "error", err)
+	}
+}
+
+// ApplyGasScheduleChange updates the gas config of all the built-in functions from the container. The change is
+// all-or-nothing: if the gas schedule can not be decoded or any function can not be fetched from the container,
+// none of the functions is updated and the previous gas config remains active. The fields of the gas cost sections
+// that do not match any gas cost are ignored, logged and reported in the result.
+func (b *builtInFuncCreator) ApplyGasScheduleChange(gasSchedule map[string]map[string]uint64) (*GasScheduleChangeResult, error) {
 	newGasConfig, err := createGasConfig(gasSchedule)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	b.gasConfig = newGasConfig
+	b.mutGasConfig.Lock()
+	defer b.mutGasConfig.Unlock()
+
+	functionNames := make([]string, 0, b.builtInFunctions.Len())
 	for key := range b.builtInFunctions.Keys() {
-		builtInFunc, errGet := b.builtInFunctions.Get(key)
+		functionNames = append(functionNames, key)
+	}
+	sort.Strings(functionNames)
+
+	functions := make([]vmcommon.BuiltinFunction, 0, len(functionNames))
+	for _, name := range functionNames {
+		builtInFunc, errGet := b.builtInFunctions.Get(name)
 		if errGet != nil {
-			return
+			return nil, errGet
 		}
 
-		builtInFunc.SetNewGasConfig(b.gasConfig)
+		functions = append(functions, builtInFunc)
+	}
+
+	for _, builtInFunc := range functions {
+		builtInFunc.SetNewGasConfig(newGasConfig)
+	}
+
+	changes := vmcommon.DiffGasCosts(b.gasConfig, newGasConfig)
+	b.gasConfig = newGasConfig
+	b.gasConfigVersion++
+
+	unknownFields := vmcommon.UnknownGasCostFields(gasSchedule)
+	logUnknownGasCostFields("builtInFuncCreator.ApplyGasScheduleChange", unknownFields)
+
+	return &GasScheduleChangeResult{
+		Version:          b.gasConfigVersion,
+		UpdatedFunctions: functionNames,
+		Changes:          changes,
+		UnknownFields:    unknownFields,
+	}, nil
+}
+
+func logUnknownGasCostFields(caller string, unknownFields []string) {
+	if len(unknownFields) == 0 {
+		return
 	}
+
+	log.Warn(caller+": unknown gas cost fields are ignored", "fields", strings.Join(unknownFields, ", "))
+}
+
+// GasConfig returns a copy of the active gas config
+func (b *builtInFuncCreator) GasConfig() vmcommon.GasCost {
+	b.mutGasConfig.RLock()
+	defer b.mutGasConfig.RUnlock()
+
+	return *b.gasConfig
+}
+
+// GasConfigVersion returns the version of the active gas config. The gas config provided at construction time has
+// version 0 and every applied gas schedule change increments it.
+func (b *builtInFuncCreator) GasConfigVersion() uint32 {
+	b.mutGasConfig.RLock()
+	defer b.mutGasConfig.RUnlock()
+
+	return b.gasConfigVersion
 }
 
 // NFTStorageHandler will return the esdt storage handler from the built in functions factory
@@ -131,16 +224,17 @@ func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunction
 
 // CreateBuiltInFunctionContainer will create the list of built-in functions
 func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
+	gasConfig := b.GasConfig()
 
-	b.builtInFunctions = NewBuiltInFunctionContainer()
+	b.builtInFunctions = b.createContainer()
 	var newFunc vmcommon.BuiltinFunction
-	newFunc = NewClaimDeveloperRewardsFunc(b.gasConfig.BuiltInCost.ClaimDeveloperRewards)
+	newFunc = NewClaimDeveloperRewardsFunc(gasConfig.BuiltInCost.ClaimDeveloperRewards)
 	err := b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewChangeOwnerAddressFunc(b.gasConfig.BuiltInCost.ChangeOwnerAddress, b.enableEpochsHandler)
+	newFunc, err = NewChangeOwnerAddressFunc(gasConfig.BuiltInCost.ChangeOwnerAddress, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -150,7 +244,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewSaveUserNameFunc(b.gasConfig.BuiltInCost.SaveUserName, b.mapDNSAddresses, b.mapDNSV2Addresses, b.enableEpochsHandler)
+	newFunc, err = NewSaveUserNameFunc(gasConfig.BuiltInCost.SaveUserName, b.mapDNSAddresses, b.mapDNSV2Addresses, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -159,7 +253,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDeleteUserNameFunc(b.gasConfig.BuiltInCost.SaveUserName, b.mapDNSV2Addresses, b.enableEpochsHandler)
+	newFunc, err = NewDeleteUserNameFunc(gasConfig.BuiltInCost.SaveUserName, b.mapDNSV2Addresses, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -168,7 +262,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewSaveKeyValueStorageFunc(b.gasConfig.BaseOperationCost, b.gasConfig.BuiltInCost.SaveKeyValue, b.enableEpochsHandler)
+	newFunc, err = NewSaveKeyValueStorageFunc(gasConfig.BaseOperationCost, gasConfig.BuiltInCost.SaveKeyValue, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -203,7 +297,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 	}
 
 	newFunc, err = NewESDTTransferFunc(
-		b.gasConfig.BuiltInCost.ESDTTransfer,
+		gasConfig.BuiltInCost.ESDTTransfer,
 		b.marshaller,
 		globalSettingsFunc,
 		b.shardCoordinator,
@@ -217,7 +311,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTBurnFunc(b.gasConfig.BuiltInCost.ESDTBurn, b.marshaller, globalSettingsFunc, b.enableEpochsHandler)
+	newFunc, err = NewESDTBurnFunc(gasConfig.BuiltInCost.ESDTBurn, b.marshaller, globalSettingsFunc, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -250,7 +344,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTLocalBurnFunc(b.gasConfig.BuiltInCost.ESDTLocalBurn, b.marshaller, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
+	newFunc, err = NewESDTLocalBurnFunc(gasConfig.BuiltInCost.ESDTLocalBurn, b.marshaller, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -259,7 +353,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTLocalMintFunc(b.gasConfig.BuiltInCost.ESDTLocalMint, b.marshaller, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
+	newFunc, err = NewESDTLocalMintFunc(gasConfig.BuiltInCost.ESDTLocalMint, b.marshaller, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -280,7 +374,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTAddQuantityFunc(b.gasConfig.BuiltInCost.ESDTNFTAddQuantity, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
+	newFunc, err = NewESDTNFTAddQuantityFunc(gasConfig.BuiltInCost.ESDTNFTAddQuantity, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -289,7 +383,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTBurnFunc(b.gasConfig.BuiltInCost.ESDTNFTBurn, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc)
+	newFunc, err = NewESDTNFTBurnFunc(gasConfig.BuiltInCost.ESDTNFTBurn, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc)
 	if err != nil {
 		return err
 	}
@@ -298,7 +392,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTCreateFunc(b.gasConfig.BuiltInCost.ESDTNFTCreate, b.gasConfig.BaseOperationCost, b.marshaller, globalSettingsFunc, setRoleFunc, b.esdtStorageHandler, b.accounts, b.enableEpochsHandler)
+	newFunc, err = NewESDTNFTCreateFunc(gasConfig.BuiltInCost.ESDTNFTCreate, gasConfig.BaseOperationCost, b.marshaller, globalSettingsFunc, setRoleFunc, b.esdtStorageHandler, b.accounts, b.enableEpochsHandler)
 	if err != nil {
 		return err
 	}
@@ -334,12 +428,12 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTTransferFunc(b.gasConfig.BuiltInCost.ESDTNFTTransfer,
+	newFunc, err = NewESDTNFTTransferFunc(gasConfig.BuiltInCost.ESDTNFTTransfer,
 		b.marshaller,
 		globalSettingsFunc,
 		b.accounts,
 		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
+		gasConfig.BaseOperationCost,
 		setRoleFunc,
 		b.esdtStorageHandler,
 		b.enableEpochsHandler)
@@ -360,7 +454,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTUpdateAttributesFunc(b.gasConfig.BuiltInCost.ESDTNFTUpdateAttributes, b.gasConfig.BaseOperationCost, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTNFTUpdateAttributesFunc(gasConfig.BuiltInCost.ESDTNFTUpdateAttributes, gasConfig.BaseOperationCost, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -369,7 +463,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTAddUriFunc(b.gasConfig.BuiltInCost.ESDTNFTAddURI, b.gasConfig.BaseOperationCost, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTNFTAddUriFunc(gasConfig.BuiltInCost.ESDTNFTAddURI, gasConfig.BaseOperationCost, b.esdtStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -378,12 +472,12 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTNFTMultiTransferFunc(b.gasConfig.BuiltInCost.ESDTNFTMultiTransfer,
+	newFunc, err = NewESDTNFTMultiTransferFunc(gasConfig.BuiltInCost.ESDTNFTMultiTransfer,
 		b.marshaller,
 		globalSettingsFunc,
 		b.accounts,
 		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
+		gasConfig.BaseOperationCost,
 		b.enableEpochsHandler,
 		setRoleFunc,
 		b.esdtStorageHandler)
@@ -395,15 +489,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTGlobalSettingsFunc(
-		b.accounts,
-		b.marshaller,
-		true,
-		core.BuiltInFunctionESDTSetLimitedTransfer,
-		func() bool {
-			return b.enableEpochsHandler.IsFlagEnabled(ESDTTransferRoleFlag)
-		},
-	)
+	newFunc, err = b.newGatedGlobalSettingsFunc(true, core.BuiltInFunctionESDTSetLimitedTransfer, ESDTTransferRoleFlag)
 	if err != nil {
 		return err
 	}
@@ -412,15 +498,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTGlobalSettingsFunc(
-		b.accounts,
-		b.marshaller,
-		false,
-		core.BuiltInFunctionESDTUnSetLimitedTransfer,
-		func() bool {
-			return b.enableEpochsHandler.IsFlagEnabled(ESDTTransferRoleFlag)
-		},
-	)
+	newFunc, err = b.newGatedGlobalSettingsFunc(false, core.BuiltInFunctionESDTUnSetLimitedTransfer, ESDTTransferRoleFlag)
 	if err != nil {
 		return err
 	}
@@ -430,7 +508,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 	}
 
 	argsNewDeleteFunc := ArgsNewESDTDeleteMetadata{
-		FuncGasCost:         b.gasConfig.BuiltInCost.ESDTNFTBurn,
+		FuncGasCost:         gasConfig.BuiltInCost.ESDTNFTBurn,
 		Marshalizer:         b.marshaller,
 		Accounts:            b.accounts,
 		AllowedAddress:      b.configAddress,
@@ -456,15 +534,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTGlobalSettingsFunc(
-		b.accounts,
-		b.marshaller,
-		true,
-		vmcommon.BuiltInFunctionESDTSetBurnRoleForAll,
-		func() bool {
-			return b.enableEpochsHandler.IsFlagEnabled(SendAlwaysFlag)
-		},
-	)
+	newFunc, err = b.newGatedGlobalSettingsFunc(true, vmcommon.BuiltInFunctionESDTSetBurnRoleForAll, SendAlwaysFlag)
 	if err != nil {
 		return err
 	}
@@ -473,15 +543,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTGlobalSettingsFunc(
-		b.accounts,
-		b.marshaller,
-		false,
-		vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll,
-		func() bool {
-			return b.enableEpochsHandler.IsFlagEnabled(SendAlwaysFlag)
-		},
-	)
+	newFunc, err = b.newGatedGlobalSettingsFunc(false, vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll, SendAlwaysFlag)
 	if err != nil {
 		return err
 	}
@@ -509,7 +571,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 	}
 
 	argsSetGuardian := SetGuardianArgs{
-		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.SetGuardian),
+		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(gasConfig.BuiltInCost.SetGuardian),
 	}
 	newFunc, err = NewSetGuardianFunc(argsSetGuardian)
 	if err != nil {
@@ -520,7 +582,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	argsGuardAccount := b.createGuardAccountArgs()
+	argsGuardAccount := b.createGuardAccountArgs(gasConfig.BuiltInCost.GuardAccount)
 	newFunc, err = NewGuardAccountFunc(argsGuardAccount)
 	if err != nil {
 		return err
@@ -539,7 +601,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewMigrateDataTrieFunc(b.gasConfig.BuiltInCost, b.enableEpochsHandler, b.accounts)
+	newFunc, err = NewMigrateDataTrieFunc(gasConfig.BuiltInCost, b.enableEpochsHandler, b.accounts)
 	if err != nil {
 		return err
 	}
@@ -548,19 +610,18 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	activeHandler := func() bool {
-		return b.enableEpochsHandler.IsFlagEnabled(DynamicEsdtFlag)
-	}
-	newFunc, err = NewESDTSetTokenTypeFunc(b.accounts, globalSettingsFunc, b.marshaller, activeHandler)
+	activeHandler := newFlagActiveHandler(b.enableEpochsHandler, DynamicEsdtFlag)
+	setTokenTypeFunc, err := NewESDTSetTokenTypeFunc(b.accounts, globalSettingsFunc, b.marshaller, activeHandler.IsActive)
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.ESDTSetTokenType, newFunc)
+	setTokenTypeFunc.baseActiveHandler = activeHandler
+	err = b.builtInFunctions.Add(core.ESDTSetTokenType, setTokenTypeFunc)
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewESDTMetaDataRecreateFunc(b.gasConfig.BuiltInCost.ESDTNFTRecreate, b.gasConfig.BaseOperationCost, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTMetaDataRecreateFunc(gasConfig.BuiltInCost.ESDTNFTRecreate, gasConfig.BaseOperationCost, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -569,7 +630,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTMetaDataUpdateFunc(b.gasConfig.BuiltInCost.ESDTNFTUpdate, b.gasConfig.BaseOperationCost, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTMetaDataUpdateFunc(gasConfig.BuiltInCost.ESDTNFTUpdate, gasConfig.BaseOperationCost, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -578,7 +639,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTSetNewURIsFunc(b.gasConfig.BuiltInCost.ESDTNFTRecreate, b.gasConfig.BaseOperationCost, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTSetNewURIsFunc(gasConfig.BuiltInCost.ESDTNFTSetNewURIs, gasConfig.BaseOperationCost, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -587,7 +648,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTModifyRoyaltiesFunc(b.gasConfig.BuiltInCost.ESDTModifyRoyalties, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTModifyRoyaltiesFunc(gasConfig.BuiltInCost.ESDTModifyRoyalties, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -596,7 +657,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTModifyCreatorFunc(b.gasConfig.BuiltInCost.ESDTModifyRoyalties, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
+	newFunc, err = NewESDTModifyCreatorFunc(gasConfig.BuiltInCost.ESDTModifyCreator, b.accounts, globalSettingsFunc, b.esdtStorageHandler, setRoleFunc, b.enableEpochsHandler, b.marshaller)
 	if err != nil {
 		return err
 	}
@@ -617,9 +678,9 @@ func (b *builtInFuncCreator) createBaseAccountGuarderArgs(funcGasCost uint64) Ba
 	}
 }
 
-func (b *builtInFuncCreator) createGuardAccountArgs() GuardAccountArgs {
+func (b *builtInFuncCreator) createGuardAccountArgs(funcGasCost uint64) GuardAccountArgs {
 	return GuardAccountArgs{
-		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.GuardAccount),
+		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(funcGasCost),
 	}
 }
 
@@ -654,6 +715,17 @@ func createGasConfig(gasMap map[string]map[string]uint64) (*vmcommon.GasCost, er
 	return &gasCost, nil
 }
 
+func (b *builtInFuncCreator) newGatedGlobalSettingsFunc(set bool, function string, flag core.EnableEpochFlag) (*esdtGlobalSettings, error) {
+	activeHandler := newFlagActiveHandler(b.enableEpochsHandler, flag)
+	globalSettingsFunc, err := NewESDTGlobalSettingsFunc(b.accounts, b.marshaller, set, function, activeHandler.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	globalSettingsFunc.baseActiveHandler = activeHandler
+
+	return globalSettingsFunc, nil
+}
+
 // SetBlockchainHook sets the blockchain hook to the needed functions
 func (b *builtInFuncCreator) SetBlockchainHook(blockchainHook vmcommon.BlockchainDataHook) error {
 	if check.IfNil(blockchainHook) {
@@ -667,7 +739,7 @@ func (b *builtInFuncCreator) SetBlockchainHook(blockchainHook vmcommon.Blockchai
 			return err
 		}
 
-		esdtBlockchainDataProvider, ok := builtInFunc.(vmcommon.BlockchainDataProvider)
+		esdtBlockchainDataProvider, ok := UnwrapBuiltinFunction(builtInFunc).(vmcommon.BlockchainDataProvider)
 		if !ok {
 			continue
 		}
@@ -703,7 +775,7 @@ func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHa
 			return err
 		}
 
-		esdtTransferFunc, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
+		esdtTransferFunc, ok := UnwrapBuiltinFunction(builtInFunc).(vmcommon.AcceptPayableChecker)
 		if !ok {
 			return ErrWrongTypeAssertion
 		}
@@ -717,6 +789,43 @@ func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHa
 	return nil
 }
 
+// AddExecutionInterceptor adds an interceptor around the execution of all the built-in functions. The interceptor
+// is added to the current container and to the ones created afterwards by CreateBuiltInFunctionContainer.
+func (b *builtInFuncCreator) AddExecutionInterceptor(interceptor ExecutionInterceptor) error {
+	if check.IfNil(interceptor) {
+		return ErrNilExecutionInterceptor
+	}
+
+	b.mutInterceptors.Lock()
+	defer b.mutInterceptors.Unlock()
+
+	holder, ok := b.builtInFunctions.(executionInterceptorsHolder)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+	err := holder.AddExecutionInterceptor(interceptor)
+	if err != nil {
+		return err
+	}
+
+	b.interceptors = append(b.interceptors, interceptor)
+
+	return nil
+}
+
+func (b *builtInFuncCreator) createContainer() *functionContainer {
+	b.mutInterceptors.RLock()
+	defer b.mutInterceptors.RUnlock()
+
+	newContainer := NewBuiltInFunctionContainer()
+	newContainer.enableEpochsHandler = b.enableEpochsHandler
+	for _, interceptor := range b.interceptors {
+		_ = newContainer.AddExecutionInterceptor(interceptor)
+	}
+
+	return newContainer
+}
+
 // IsInterfaceNil returns true if underlying object is nil
 func (b *builtInFuncCreator) IsInterfaceNil() bool {
 	return b == nil
@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"errors"
+	"sort"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -161,6 +162,77 @@ func TestCreateBuiltInContainer_GasScheduleChange(t *testing.T) {
 	assert.Equal(t, f.gasConfig.BuiltInCost.ClaimDeveloperRewards, uint64(5))
 }
 
+func TestCreateBuiltInContainer_ApplyGasScheduleChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid gas schedule should not change anything", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArguments()
+		f, _ := NewBuiltInFunctionsCreator(args)
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		newGasMap := fillGasMapInternal(make(map[string]map[string]uint64), 5)
+		newGasMap[core.BuiltInCostString]["ESDTTransfer"] = 0
+		result, err := f.ApplyGasScheduleChange(newGasMap)
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, uint32(0), f.GasConfigVersion())
+		assert.Equal(t, uint64(1), f.GasConfig().BuiltInCost.ESDTTransfer)
+	})
+	t.Run("container error should not update any function", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArguments()
+		f, _ := NewBuiltInFunctionsCreator(args)
+
+		numSetNewGasConfigCalls := 0
+		_ = f.builtInFunctions.Add("a", &mock.BuiltInFunctionStub{
+			SetNewGasConfigCalled: func(gasCost *vmcommon.GasCost) {
+				numSetNewGasConfigCalls++
+			},
+		})
+		f.builtInFunctions.(*functionContainer).objects.Set("b", "not a built-in function")
+
+		result, err := f.ApplyGasScheduleChange(fillGasMapInternal(make(map[string]map[string]uint64), 5))
+		assert.Nil(t, result)
+		assert.Equal(t, ErrWrongTypeInContainer, err)
+		assert.Equal(t, 0, numSetNewGasConfigCalls)
+		assert.Equal(t, uint32(0), f.GasConfigVersion())
+		assert.Equal(t, uint64(1), f.GasConfig().BuiltInCost.ESDTTransfer)
+	})
+	t.Run("should update all functions and report the change", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArguments()
+		f, _ := NewBuiltInFunctionsCreator(args)
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		newGasMap := fillGasMapInternal(make(map[string]map[string]uint64), 1)
+		newGasMap[core.BuiltInCostString]["ESDTTransfer"] = 7
+		result, err := f.ApplyGasScheduleChange(newGasMap)
+		require.Nil(t, err)
+		assert.Equal(t, uint32(1), result.Version)
+		assert.Equal(t, 42, len(result.UpdatedFunctions))
+		assert.True(t, sort.StringsAreSorted(result.UpdatedFunctions))
+		require.Len(t, result.Changes, 1)
+		assert.Equal(t, "BuiltInCost.ESDTTransfer: 1 -> 7", result.Changes[0].String())
+		assert.Equal(t, []string{"BaseOperationCost.GetCode", "BuiltInCost.UnGuardAccount"}, result.UnknownFields)
+
+		assert.Equal(t, uint32(1), f.GasConfigVersion())
+		assert.Equal(t, uint64(7), f.GasConfig().BuiltInCost.ESDTTransfer)
+
+		transferFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionESDTTransfer)
+		assert.Equal(t, uint64(7), UnwrapBuiltinFunction(transferFunc).(*esdtTransfer).funcGasCost)
+
+		f.GasScheduleChange(fillGasMapInternal(make(map[string]map[string]uint64), 2))
+		assert.Equal(t, uint32(2), f.GasConfigVersion())
+		assert.Equal(t, uint64(2), UnwrapBuiltinFunction(transferFunc).(*esdtTransfer).funcGasCost)
+	})
+}
+
 func TestCreateBuiltInContainer_Create(t *testing.T) {
 	args := createMockArguments()
 	f, _ := NewBuiltInFunctionsCreator(args)
@@ -181,7 +253,7 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 	numSetBlockDataHandlerCalls := 0
 	for funcName := range f.builtInFunctions.Keys() {
 		builtInFunc, _ := f.builtInFunctions.Get(funcName)
-		_, ok := builtInFunc.(vmcommon.BlockchainDataProvider)
+		_, ok := UnwrapBuiltinFunction(builtInFunc).(vmcommon.BlockchainDataProvider)
 		if !ok {
 			continue
 		}
@@ -207,3 +279,67 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 	nftStorageHandler := f.NFTStorageHandler()
 	assert.False(t, check.IfNil(nftStorageHandler))
 }
+
+func TestCreateBuiltInContainer_ActivationFlags(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArguments()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		GetActivationEpochCalled: func(flag core.EnableEpochFlag) uint32 {
+			if flag == DynamicEsdtFlag {
+				return 10
+			}
+			return 1
+		},
+		IsFlagEnabledInEpochCalled: func(flag core.EnableEpochFlag, epoch uint32) bool {
+			switch flag {
+			case DynamicEsdtFlag:
+				return epoch >= 10
+			case GlobalMintBurnFlag:
+				// GlobalMintBurnFlag is enabled until its epoch
+				return epoch < 1
+			default:
+				return epoch >= 1
+			}
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, f.CreateBuiltInFunctionContainer())
+	c := f.BuiltInFunctionContainer().(*functionContainer)
+
+	activations, err := c.ActivationInfo()
+	require.Nil(t, err)
+	flags := make(map[string]core.EnableEpochFlag)
+	for _, activation := range activations {
+		flags[activation.Name] = activation.Flag
+		if activation.Name == core.ESDTSetTokenType {
+			assert.Equal(t, uint32(10), activation.ActivationEpoch)
+			assert.False(t, activation.IsDeactivationFlag)
+		}
+		if activation.Name == core.BuiltInFunctionESDTBurn {
+			assert.True(t, activation.IsDeactivationFlag)
+			assert.Equal(t, uint32(0), activation.ActivationEpoch)
+			assert.Equal(t, uint32(1), activation.DeactivationEpoch)
+		}
+	}
+	assert.Equal(t, core.EnableEpochFlag(""), flags[core.BuiltInFunctionESDTTransfer])
+	assert.Equal(t, core.EnableEpochFlag(""), flags[core.BuiltInFunctionESDTPause])
+	assert.Equal(t, ESDTTransferRoleFlag, flags[core.BuiltInFunctionESDTSetLimitedTransfer])
+	assert.Equal(t, SendAlwaysFlag, flags[vmcommon.BuiltInFunctionESDTSetBurnRoleForAll])
+	assert.Equal(t, DynamicEsdtFlag, flags[core.ESDTSetTokenType])
+	assert.Equal(t, SetGuardianFlag, flags[core.BuiltInFunctionGuardAccount])
+	assert.Equal(t, GlobalMintBurnFlag, flags[core.BuiltInFunctionESDTBurn])
+
+	keysInEpoch0, _ := c.ActiveKeysInEpoch(0)
+	keysInEpoch10, _ := c.ActiveKeysInEpoch(10)
+	assert.Contains(t, keysInEpoch0, core.BuiltInFunctionESDTTransfer)
+	assert.Contains(t, keysInEpoch0, core.BuiltInFunctionESDTBurn)
+	assert.NotContains(t, keysInEpoch0, core.ESDTSetTokenType)
+	assert.NotContains(t, keysInEpoch10, core.BuiltInFunctionESDTBurn)
+	assert.Len(t, keysInEpoch10, c.Len()-1)
+
+	activeKeys := c.ActiveKeys()
+	assert.Contains(t, activeKeys, core.BuiltInFunctionESDTTransfer)
+	assert.NotContains(t, activeKeys, core.BuiltInFunctionESDTBurn, "no flag is enabled in the current epoch")
+	assert.NotContains(t, activeKeys, core.ESDTSetTokenType)
+}
@@ -34,9 +34,7 @@ func NewDeleteUserNameFunc(
 	for key := range mapDnsAddresses {
 		d.mapDnsAddresses[key] = struct{}{}
 	}
-	d.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(ChangeUsernameFlag)
-	}
+	d.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, ChangeUsernameFlag)
 
 	return d, nil
 }
@@ -52,6 +50,14 @@ func (d *deleteUserName) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	d.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (d *deleteUserName) FuncGasCost() uint64 {
+	d.mutExecution.RLock()
+	defer d.mutExecution.RUnlock()
+
+	return d.gasCost
+}
+
 // ProcessBuiltinFunction sets the username to the account if it is allowed
 func (d *deleteUserName) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
@@ -2,224 +2,265 @@ package builtInFunctions
 
 import (
 	"errors"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
+// newBuiltInError creates an error classified with the return code to be set in the VMOutput and a stable code,
+// so that callers can retrieve them with errors.As on a *vmcommon.VMError
+func newBuiltInError(returnCode vmcommon.ReturnCode, code string, message string) error {
+	return vmcommon.NewVMError(errors.New(message), returnCode, code)
+}
+
 // ErrNilAccountsAdapter defines the error when trying to use a nil AccountsAddapter
-var ErrNilAccountsAdapter = errors.New("nil AccountsAdapter")
+var ErrNilAccountsAdapter = newBuiltInError(vmcommon.ExecutionFailed, "NIL_ACCOUNTS_ADAPTER", "nil AccountsAdapter")
 
 // ErrInsufficientFunds signals the funds are insufficient for the move balance operation but the
 // transaction fee is covered by the current balance
-var ErrInsufficientFunds = errors.New("insufficient funds")
+var ErrInsufficientFunds = newBuiltInError(vmcommon.OutOfFunds, "INSUFFICIENT_FUNDS", "insufficient funds")
 
 // ErrNilValue signals the value is nil
-var ErrNilValue = errors.New("nil value")
+var ErrNilValue = newBuiltInError(vmcommon.ExecutionFailed, "NIL_VALUE", "nil value")
 
 // ErrNilMarshalizer signals that an operation has been attempted to or with a nil Marshalizer implementation
-var ErrNilMarshalizer = errors.New("nil Marshalizer")
+var ErrNilMarshalizer = newBuiltInError(vmcommon.ExecutionFailed, "NIL_MARSHALIZER", "nil Marshalizer")
 
 // ErrInvalidRcvAddr signals that an invalid receiver address was provided
-var ErrInvalidRcvAddr = errors.New("invalid receiver address")
+var ErrInvalidRcvAddr = newBuiltInError(vmcommon.UserError, "INVALID_RCV_ADDR", "invalid receiver address")
 
 // ErrNegativeValue signals that a negative value has been detected and it is not allowed
-var ErrNegativeValue = errors.New("negative value")
+var ErrNegativeValue = newBuiltInError(vmcommon.UserError, "NEGATIVE_VALUE", "negative value")
 
 // ErrNilShardCoordinator signals that an operation has been attempted to or with a nil shard coordinator
-var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+var ErrNilShardCoordinator = newBuiltInError(vmcommon.ExecutionFailed, "NIL_SHARD_COORDINATOR", "nil shard coordinator")
 
 // ErrWrongTypeAssertion signals that an type assertion failed
-var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+var ErrWrongTypeAssertion = newBuiltInError(vmcommon.ExecutionFailed, "WRONG_TYPE_ASSERTION", "wrong type assertion")
 
 // ErrNilSCDestAccount signals that destination account is nil
-var ErrNilSCDestAccount = errors.New("nil destination SC account")
+var ErrNilSCDestAccount = newBuiltInError(vmcommon.UserError, "NIL_SC_DEST_ACCOUNT", "nil destination SC account")
 
 // ErrNotEnoughGas signals that not enough gas has been provided
-var ErrNotEnoughGas = errors.New("not enough gas was sent in the transaction")
+var ErrNotEnoughGas = newBuiltInError(vmcommon.OutOfGas, "NOT_ENOUGH_GAS", "not enough gas was sent in the transaction")
+
+// ErrNotEnoughGasForDataTrieMigration signals that the provided gas does not cover the migration of one trie node
+var ErrNotEnoughGasForDataTrieMigration = newBuiltInError(vmcommon.OutOfGas, "NOT_ENOUGH_GAS_FOR_DATA_TRIE_MIGRATION", "not enough gas")
 
 // ErrInvalidArguments signals that invalid arguments were given to process built-in function
-var ErrInvalidArguments = errors.New("invalid arguments to process built-in function")
+var ErrInvalidArguments = newBuiltInError(vmcommon.FunctionWrongSignature, "INVALID_ARGUMENTS", "invalid arguments to process built-in function")
 
 // ErrOperationNotPermitted signals that operation is not permitted
-var ErrOperationNotPermitted = errors.New("operation in account not permitted")
+var ErrOperationNotPermitted = newBuiltInError(vmcommon.UserError, "OPERATION_NOT_PERMITTED", "operation in account not permitted")
 
 // ErrInvalidAddressLength signals that address length is invalid
-var ErrInvalidAddressLength = errors.New("invalid address length")
+var ErrInvalidAddressLength = newBuiltInError(vmcommon.UserError, "INVALID_ADDRESS_LENGTH", "invalid address length")
 
 // ErrNilVmInput signals that provided vm input is nil
-var ErrNilVmInput = errors.New("nil vm input")
+var ErrNilVmInput = newBuiltInError(vmcommon.ExecutionFailed, "NIL_VM_INPUT", "nil vm input")
 
 // ErrNilDnsAddresses signals that nil dns addresses map was provided
-var ErrNilDnsAddresses = errors.New("nil dns addresses map")
+var ErrNilDnsAddresses = newBuiltInError(vmcommon.ExecutionFailed, "NIL_DNS_ADDRESSES", "nil dns addresses map")
 
 // ErrCallerIsNotTheDNSAddress signals that called address is not the DNS address
-var ErrCallerIsNotTheDNSAddress = errors.New("not a dns address")
+var ErrCallerIsNotTheDNSAddress = newBuiltInError(vmcommon.UserError, "CALLER_IS_NOT_THE_DNS_ADDRESS", "not a dns address")
 
 // ErrUserNameChangeIsDisabled signals the user name change is not allowed
-var ErrUserNameChangeIsDisabled = errors.New("user name change is disabled")
+var ErrUserNameChangeIsDisabled = newBuiltInError(vmcommon.UserError, "USER_NAME_CHANGE_IS_DISABLED", "user name change is disabled")
 
 // ErrBuiltInFunctionCalledWithValue signals that builtin function was called with value that is not allowed
-var ErrBuiltInFunctionCalledWithValue = errors.New("built in function called with tx value is not allowed")
+var ErrBuiltInFunctionCalledWithValue = newBuiltInError(vmcommon.UserError, "BUILT_IN_FUNCTION_CALLED_WITH_VALUE", "built in function called with tx value is not allowed")
 
 // ErrAccountNotPayable will be sent when trying to send tokens to a non-payableCheck account
-var ErrAccountNotPayable = errors.New("sending value to non payable contract")
+var ErrAccountNotPayable = newBuiltInError(vmcommon.UserError, "ACCOUNT_NOT_PAYABLE", "sending value to non payable contract")
 
 // ErrNilUserAccount signals that nil user account was provided
-var ErrNilUserAccount = errors.New("nil user account")
+var ErrNilUserAccount = newBuiltInError(vmcommon.ExecutionFailed, "NIL_USER_ACCOUNT", "nil user account")
 
 // ErrAddressIsNotESDTSystemSC signals that destination is not a system sc address
-var ErrAddressIsNotESDTSystemSC = errors.New("destination is not system sc address")
+var ErrAddressIsNotESDTSystemSC = newBuiltInError(vmcommon.UserError, "ADDRESS_IS_NOT_ESDT_SYSTEM_SC", "destination is not system sc address")
 
 // ErrOnlySystemAccountAccepted signals that only system account is accepted
-var ErrOnlySystemAccountAccepted = errors.New("only system account is accepted")
+var ErrOnlySystemAccountAccepted = newBuiltInError(vmcommon.UserError, "ONLY_SYSTEM_ACCOUNT_ACCEPTED", "only system account is accepted")
 
 // ErrNilGlobalSettingsHandler signals that nil pause handler has been provided
-var ErrNilGlobalSettingsHandler = errors.New("nil pause handler")
+var ErrNilGlobalSettingsHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_GLOBAL_SETTINGS_HANDLER", "nil pause handler")
 
 // ErrNilRolesHandler signals that nil roles handler has been provided
-var ErrNilRolesHandler = errors.New("nil roles handler")
+var ErrNilRolesHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_ROLES_HANDLER", "nil roles handler")
 
 // ErrESDTTokenIsPaused signals that esdt token is paused
-var ErrESDTTokenIsPaused = errors.New("esdt token is paused")
+var ErrESDTTokenIsPaused = newBuiltInError(vmcommon.UserError, "ESDT_TOKEN_IS_PAUSED", "esdt token is paused")
 
 // ErrESDTIsFrozenForAccount signals that account is frozen for given esdt token
-var ErrESDTIsFrozenForAccount = errors.New("account is frozen for this esdt token")
+var ErrESDTIsFrozenForAccount = newBuiltInError(vmcommon.UserError, "ESDT_IS_FROZEN_FOR_ACCOUNT", "account is frozen for this esdt token")
 
 // ErrCannotWipeAccountNotFrozen signals that account isn't frozen so the wipe is not possible
-var ErrCannotWipeAccountNotFrozen = errors.New("cannot wipe because the account is not frozen for this esdt token")
+var ErrCannotWipeAccountNotFrozen = newBuiltInError(vmcommon.UserError, "CANNOT_WIPE_ACCOUNT_NOT_FROZEN", "cannot wipe because the account is not frozen for this esdt token")
 
 // ErrNilPayableHandler signals that nil payableHandler was provided
-var ErrNilPayableHandler = errors.New("nil payableHandler was provided")
+var ErrNilPayableHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_PAYABLE_HANDLER", "nil payableHandler was provided")
 
 // ErrActionNotAllowed signals that action is not allowed
-var ErrActionNotAllowed = errors.New("action is not allowed")
+var ErrActionNotAllowed = newBuiltInError(vmcommon.UserError, "ACTION_NOT_ALLOWED", "action is not allowed")
 
 // ErrOnlyFungibleTokensHaveBalanceTransfer signals that only fungible tokens have balance transfer
-var ErrOnlyFungibleTokensHaveBalanceTransfer = errors.New("only fungible tokens have balance transfer")
+var ErrOnlyFungibleTokensHaveBalanceTransfer = newBuiltInError(vmcommon.UserError, "ONLY_FUNGIBLE_TOKENS_HAVE_BALANCE_TRANSFER", "only fungible tokens have balance transfer")
 
 // ErrNFTTokenDoesNotExist signals that NFT token does not exist
-var ErrNFTTokenDoesNotExist = errors.New("NFT token does not exist")
+var ErrNFTTokenDoesNotExist = newBuiltInError(vmcommon.UserError, "NFT_TOKEN_DOES_NOT_EXIST", "NFT token does not exist")
 
 // ErrNFTDoesNotHaveMetadata signals that NFT does not have metadata
-var ErrNFTDoesNotHaveMetadata = errors.New("NFT does not have metadata")
+var ErrNFTDoesNotHaveMetadata = newBuiltInError(vmcommon.UserError, "NFT_DOES_NOT_HAVE_METADATA", "NFT does not have metadata")
 
 // ErrInvalidNFTQuantity signals that invalid NFT quantity was provided
-var ErrInvalidNFTQuantity = errors.New("invalid NFT quantity")
+var ErrInvalidNFTQuantity = newBuiltInError(vmcommon.UserError, "INVALID_NFT_QUANTITY", "invalid NFT quantity")
 
 // ErrNewNFTDataOnSenderAddress signals that a new NFT data was found on the sender address
-var ErrNewNFTDataOnSenderAddress = errors.New("new NFT data on sender")
+var ErrNewNFTDataOnSenderAddress = newBuiltInError(vmcommon.UserError, "NEW_NFT_DATA_ON_SENDER_ADDRESS", "new NFT data on sender")
 
 // ErrNilContainerElement signals when trying to add a nil element in the container
-var ErrNilContainerElement = errors.New("element cannot be nil")
+var ErrNilContainerElement = newBuiltInError(vmcommon.ExecutionFailed, "NIL_CONTAINER_ELEMENT", "element cannot be nil")
 
 // ErrInvalidContainerKey signals that an element does not exist in the container's map
-var ErrInvalidContainerKey = errors.New("element does not exist in container")
+var ErrInvalidContainerKey = newBuiltInError(vmcommon.ExecutionFailed, "INVALID_CONTAINER_KEY", "element does not exist in container")
 
 // ErrContainerKeyAlreadyExists signals that an element was already set in the container's map
-var ErrContainerKeyAlreadyExists = errors.New("provided key already exists in container")
+var ErrContainerKeyAlreadyExists = newBuiltInError(vmcommon.ExecutionFailed, "CONTAINER_KEY_ALREADY_EXISTS", "provided key already exists in container")
 
 // ErrWrongTypeInContainer signals that a wrong type of object was found in container
-var ErrWrongTypeInContainer = errors.New("wrong type of object inside container")
+var ErrWrongTypeInContainer = newBuiltInError(vmcommon.ExecutionFailed, "WRONG_TYPE_IN_CONTAINER", "wrong type of object inside container")
 
 // ErrEmptyFunctionName signals that an empty function name has been provided
-var ErrEmptyFunctionName = errors.New("empty function name")
+var ErrEmptyFunctionName = newBuiltInError(vmcommon.ExecutionFailed, "EMPTY_FUNCTION_NAME", "empty function name")
 
 // ErrInsufficientQuantityESDT signals the funds are insufficient for the ESDT transfer
-var ErrInsufficientQuantityESDT = errors.New("insufficient quantity")
+var ErrInsufficientQuantityESDT = newBuiltInError(vmcommon.OutOfFunds, "INSUFFICIENT_QUANTITY_ESDT", "insufficient quantity")
 
 // ErrNilESDTNFTStorageHandler signals that a nil nft storage handler has been provided
-var ErrNilESDTNFTStorageHandler = errors.New("nil esdt nft storage handler")
+var ErrNilESDTNFTStorageHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_ESDTNFT_STORAGE_HANDLER", "nil esdt nft storage handler")
 
 // ErrNilTransactionHandler signals that a nil transaction handler has been provided
-var ErrNilTransactionHandler = errors.New("nil transaction handler")
+var ErrNilTransactionHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_TRANSACTION_HANDLER", "nil transaction handler")
 
 // ErrAddressIsNotAllowed signals that sender is not allowed to do the action
-var ErrAddressIsNotAllowed = errors.New("address is not allowed to do the action")
+var ErrAddressIsNotAllowed = newBuiltInError(vmcommon.UserError, "ADDRESS_IS_NOT_ALLOWED", "address is not allowed to do the action")
 
 // ErrInvalidNumOfArgs signals that the number of arguments is invalid
-var ErrInvalidNumOfArgs = errors.New("invalid number of arguments")
+var ErrInvalidNumOfArgs = newBuiltInError(vmcommon.FunctionWrongSignature, "INVALID_NUM_OF_ARGS", "invalid number of arguments")
 
 // ErrInvalidNonce signals that invalid nonce for esdt
-var ErrInvalidNonce = errors.New("invalid nonce for esdt")
+var ErrInvalidNonce = newBuiltInError(vmcommon.UserError, "INVALID_NONCE", "invalid nonce for esdt")
 
 // ErrTokenHasValidMetadata signals that token has a valid metadata
-var ErrTokenHasValidMetadata = errors.New("token has valid metadata")
+var ErrTokenHasValidMetadata = newBuiltInError(vmcommon.UserError, "TOKEN_HAS_VALID_METADATA", "token has valid metadata")
 
 // ErrInvalidTokenID signals that invalid tokenID was provided
-var ErrInvalidTokenID = errors.New("invalid tokenID")
+var ErrInvalidTokenID = newBuiltInError(vmcommon.UserError, "INVALID_TOKEN_ID", "invalid tokenID")
 
 // ErrNilESDTData signals that ESDT data does not exist
-var ErrNilESDTData = errors.New("nil esdt data")
+var ErrNilESDTData = newBuiltInError(vmcommon.UserError, "NIL_ESDT_DATA", "nil esdt data")
 
 // ErrInvalidMetadata signals that invalid metadata was provided
-var ErrInvalidMetadata = errors.New("invalid metadata")
+var ErrInvalidMetadata = newBuiltInError(vmcommon.UserError, "INVALID_METADATA", "invalid metadata")
 
 // ErrInvalidLiquidityForESDT signals that liquidity is invalid for ESDT
-var ErrInvalidLiquidityForESDT = errors.New("invalid liquidity for ESDT")
+var ErrInvalidLiquidityForESDT = newBuiltInError(vmcommon.UserError, "INVALID_LIQUIDITY_FOR_ESDT", "invalid liquidity for ESDT")
 
 // ErrTooManyTransferAddresses signals that too many transfer address roles has been added
-var ErrTooManyTransferAddresses = errors.New("too many transfer addresses")
+var ErrTooManyTransferAddresses = newBuiltInError(vmcommon.UserError, "TOO_MANY_TRANSFER_ADDRESSES", "too many transfer addresses")
 
 // ErrInvalidMaxNumAddresses signals that there is an invalid max number of addresses
-var ErrInvalidMaxNumAddresses = errors.New("invalid max number of addresses")
+var ErrInvalidMaxNumAddresses = newBuiltInError(vmcommon.ExecutionFailed, "INVALID_MAX_NUM_ADDRESSES", "invalid max number of addresses")
 
 // ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
-var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+var ErrNilEnableEpochsHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_ENABLE_EPOCHS_HANDLER", "nil enable epochs handler")
 
 // ErrNilActiveHandler signals that a nil active handler has been provided
-var ErrNilActiveHandler = errors.New("nil active handler")
+var ErrNilActiveHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_ACTIVE_HANDLER", "nil active handler")
 
 // ErrInvalidNumberOfArguments signals that an invalid number of arguments has been provided
-var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
+var ErrInvalidNumberOfArguments = newBuiltInError(vmcommon.FunctionWrongSignature, "INVALID_NUMBER_OF_ARGUMENTS", "invalid number of arguments")
 
 // ErrInvalidAddress signals that an invalid address has been provided
-var ErrInvalidAddress = errors.New("invalid address")
+var ErrInvalidAddress = newBuiltInError(vmcommon.UserError, "INVALID_ADDRESS", "invalid address")
 
 // ErrCannotSetOwnAddressAsGuardian signals that an owner cannot set its own address as guardian
-var ErrCannotSetOwnAddressAsGuardian = errors.New("cannot set own address as guardian")
+var ErrCannotSetOwnAddressAsGuardian = newBuiltInError(vmcommon.UserError, "CANNOT_SET_OWN_ADDRESS_AS_GUARDIAN", "cannot set own address as guardian")
 
 // ErrOwnerAlreadyHasOneGuardianPending signals that an owner already has one guardian pending
-var ErrOwnerAlreadyHasOneGuardianPending = errors.New("owner already has one guardian pending")
+var ErrOwnerAlreadyHasOneGuardianPending = newBuiltInError(vmcommon.UserError, "OWNER_ALREADY_HAS_ONE_GUARDIAN_PENDING", "owner already has one guardian pending")
 
 // ErrGuardianAlreadyExists signals that a guardian with the same address already exists
-var ErrGuardianAlreadyExists = errors.New("a guardian with the same address already exists")
+var ErrGuardianAlreadyExists = newBuiltInError(vmcommon.UserError, "GUARDIAN_ALREADY_EXISTS", "a guardian with the same address already exists")
 
 // ErrNoGuardianEnabled signals that account has no guardian enabled
-var ErrNoGuardianEnabled = errors.New("account has no guardian enabled")
+var ErrNoGuardianEnabled = newBuiltInError(vmcommon.UserError, "NO_GUARDIAN_ENABLED", "account has no guardian enabled")
 
 // ErrSetGuardAccountFlag signals that an account is already guarded when trying to guard it
-var ErrSetGuardAccountFlag = errors.New("cannot guard account, it is already guarded")
+var ErrSetGuardAccountFlag = newBuiltInError(vmcommon.UserError, "SET_GUARD_ACCOUNT_FLAG", "cannot guard account, it is already guarded")
 
 // ErrSetUnGuardAccount signals that an account is already unguarded when trying to un-guard it
-var ErrSetUnGuardAccount = errors.New("cannot un-guard account, it is not guarded")
+var ErrSetUnGuardAccount = newBuiltInError(vmcommon.UserError, "SET_UN_GUARD_ACCOUNT", "cannot un-guard account, it is not guarded")
 
 // ErrNilAccountHandler signals that a nil account handler has been provided
-var ErrNilAccountHandler = errors.New("nil account handler provided")
+var ErrNilAccountHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_ACCOUNT_HANDLER", "nil account handler provided")
 
 // ErrNilGuardedAccountHandler signals that a nil guarded account handler was provided
-var ErrNilGuardedAccountHandler = errors.New("nil guarded account handler")
+var ErrNilGuardedAccountHandler = newBuiltInError(vmcommon.ExecutionFailed, "NIL_GUARDED_ACCOUNT_HANDLER", "nil guarded account handler")
 
 // ErrInvalidServiceUID signals that an invalid service UID was provided
-var ErrInvalidServiceUID = errors.New("service UID is invalid")
+var ErrInvalidServiceUID = newBuiltInError(vmcommon.UserError, "INVALID_SERVICE_UID", "service UID is invalid")
 
 // ErrCannotMigrateNilUserName signals that a nil username is migrated
-var ErrCannotMigrateNilUserName = errors.New("cannot migrate nil username")
+var ErrCannotMigrateNilUserName = newBuiltInError(vmcommon.UserError, "CANNOT_MIGRATE_NIL_USER_NAME", "cannot migrate nil username")
 
 // ErrWrongUserNameSplit signals that user name split is wrong
-var ErrWrongUserNameSplit = errors.New("wrong user name split")
+var ErrWrongUserNameSplit = newBuiltInError(vmcommon.UserError, "WRONG_USER_NAME_SPLIT", "wrong user name split")
 
 // ErrUserNamePrefixNotEqual signals that user name prefix is not equal
-var ErrUserNamePrefixNotEqual = errors.New("user name prefix is not equal")
+var ErrUserNamePrefixNotEqual = newBuiltInError(vmcommon.UserError, "USER_NAME_PREFIX_NOT_EQUAL", "user name prefix is not equal")
 
 // ErrBuiltInFunctionIsNotActive signals that built-in function is not active
-var ErrBuiltInFunctionIsNotActive = errors.New("built-in function is not active")
+var ErrBuiltInFunctionIsNotActive = newBuiltInError(vmcommon.FunctionNotFound, "BUILT_IN_FUNCTION_IS_NOT_ACTIVE", "built-in function is not active")
 
 // ErrInvalidEsdtValue signals that a nil value has been provided
-var ErrInvalidEsdtValue = errors.New("invalid esdt value provided")
+var ErrInvalidEsdtValue = newBuiltInError(vmcommon.UserError, "INVALID_ESDT_VALUE", "invalid esdt value provided")
 
 // ErrInvalidVersion signals that an invalid version has been provided
-var ErrInvalidVersion = errors.New("invalid version")
+var ErrInvalidVersion = newBuiltInError(vmcommon.UserError, "INVALID_VERSION", "invalid version")
 
 // ErrNilBlockchainHook signals that a nil blockchain hook has been provided
-var ErrNilBlockchainHook = errors.New("nil blockchain hook")
+var ErrNilBlockchainHook = newBuiltInError(vmcommon.ExecutionFailed, "NIL_BLOCKCHAIN_HOOK", "nil blockchain hook")
 
 // ErrTypeNotSetInsideGlobalSettingsHandler signals that type is not set inside global settings handler
-var ErrTypeNotSetInsideGlobalSettingsHandler = errors.New("type not set inside global settings handler")
+var ErrTypeNotSetInsideGlobalSettingsHandler = newBuiltInError(vmcommon.ExecutionFailed, "TYPE_NOT_SET_INSIDE_GLOBAL_SETTINGS_HANDLER", "type not set inside global settings handler")
+
+// ErrInvalidESDTType signals that an invalid esdt type was provided
+var ErrInvalidESDTType = newBuiltInError(vmcommon.UserError, "INVALID_ESDT_TYPE", "invalid esdt type")
+
+// ErrNilLogEntry signals that a nil log entry was provided
+var ErrNilLogEntry = newBuiltInError(vmcommon.ExecutionFailed, "NIL_LOG_ENTRY", "nil log entry")
+
+// ErrUnknownLogEvent signals that the log entry was not emitted by a known built-in function
+var ErrUnknownLogEvent = newBuiltInError(vmcommon.UserError, "UNKNOWN_LOG_EVENT", "unknown log event")
+
+// ErrInvalidLogEventTopics signals that the topics of the log entry do not match the layout of its event
+var ErrInvalidLogEventTopics = newBuiltInError(vmcommon.UserError, "INVALID_LOG_EVENT_TOPICS", "invalid log event topics")
+
+// ErrNilExecutionInterceptor signals that a nil execution interceptor has been provided
+var ErrNilExecutionInterceptor = newBuiltInError(vmcommon.ExecutionFailed, "NIL_EXECUTION_INTERCEPTOR", "nil execution interceptor")
+
+// ErrBuiltInFunctionPanicked signals that the execution of a built-in function panicked
+var ErrBuiltInFunctionPanicked = newBuiltInError(vmcommon.ExecutionFailed, "BUILT_IN_FUNCTION_PANICKED", "built-in function panicked")
+
+// ErrNilExecutionMetricsSink signals that a nil execution metrics sink has been provided
+var ErrNilExecutionMetricsSink = newBuiltInError(vmcommon.ExecutionFailed, "NIL_EXECUTION_METRICS_SINK", "nil execution metrics sink")
+
+// ErrInvalidHistogramBuckets signals that the provided histogram buckets are not strictly increasing
+var ErrInvalidHistogramBuckets = newBuiltInError(vmcommon.ExecutionFailed, "INVALID_HISTOGRAM_BUCKETS", "invalid histogram buckets")
+
+// ErrGasEstimationNotSupported signals that the gas of the built-in function can not be estimated
+var ErrGasEstimationNotSupported = newBuiltInError(vmcommon.FunctionNotFound, "GAS_ESTIMATION_NOT_SUPPORTED", "gas estimation is not supported for the built-in function")
+
+// ErrFunctionSchemaNotFound signals that the built-in function does not publish the schema of its arguments
+var ErrFunctionSchemaNotFound = newBuiltInError(vmcommon.FunctionNotFound, "FUNCTION_SCHEMA_NOT_FOUND", "function schema not found")
@@ -0,0 +1,47 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltInErrors_ShouldBeClassified(t *testing.T) {
+	t.Parallel()
+
+	testData := []struct {
+		err        error
+		returnCode vmcommon.ReturnCode
+		code       string
+	}{
+		{err: ErrNotEnoughGas, returnCode: vmcommon.OutOfGas, code: "NOT_ENOUGH_GAS"},
+		{err: ErrInsufficientFunds, returnCode: vmcommon.OutOfFunds, code: "INSUFFICIENT_FUNDS"},
+		{err: ErrInvalidArguments, returnCode: vmcommon.FunctionWrongSignature, code: "INVALID_ARGUMENTS"},
+		{err: ErrBuiltInFunctionIsNotActive, returnCode: vmcommon.FunctionNotFound, code: "BUILT_IN_FUNCTION_IS_NOT_ACTIVE"},
+		{err: ErrESDTTokenIsPaused, returnCode: vmcommon.UserError, code: "ESDT_TOKEN_IS_PAUSED"},
+		{err: ErrNilMarshalizer, returnCode: vmcommon.ExecutionFailed, code: "NIL_MARSHALIZER"},
+	}
+
+	for _, td := range testData {
+		wrapped := fmt.Errorf("%w, additional details", td.err)
+		assert.True(t, errors.Is(wrapped, td.err))
+
+		vmErr := &vmcommon.VMError{}
+		require.True(t, errors.As(wrapped, &vmErr), td.code)
+		assert.Equal(t, td.returnCode, vmErr.ReturnCode(), td.code)
+		assert.Equal(t, td.code, vmErr.Code())
+		assert.Equal(t, td.returnCode, vmcommon.GetReturnCodeFromError(wrapped))
+	}
+}
+
+func TestBuiltInErrors_MessagesShouldNotChange(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "esdt token is paused", ErrESDTTokenIsPaused.Error())
+	assert.Equal(t, "not enough gas", ErrNotEnoughGasForDataTrieMigration.Error())
+	assert.Equal(t, "invalid esdt type: 37", fmt.Errorf("%w: %d", ErrInvalidESDTType, 37).Error())
+}
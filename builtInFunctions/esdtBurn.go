@@ -43,9 +43,7 @@ func NewESDTBurnFunc(
 		globalSettingsHandler: globalSettingsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(GlobalMintBurnFlag)
-	}
+	e.baseActiveHandler = newDeactivationFlagActiveHandler(enableEpochsHandler, GlobalMintBurnFlag)
 
 	return e, nil
 }
@@ -61,6 +59,14 @@ func (e *esdtBurn) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtBurn) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT burn function call
 func (e *esdtBurn) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
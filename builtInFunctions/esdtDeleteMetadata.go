@@ -8,6 +8,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/tokenIdentifier"
 )
 
 const numArgsPerAdd = 3
@@ -57,9 +58,7 @@ func NewESDTDeleteMetadataFunc(
 		function:       core.BuiltInFunctionMultiESDTNFTTransfer,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return args.EnableEpochsHandler.IsFlagEnabled(SendAlwaysFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(args.EnableEpochsHandler, SendAlwaysFlag)
 
 	return e, nil
 }
@@ -120,7 +119,7 @@ func (e *esdtDeleteMetaData) deleteMetadata(args [][]byte) error {
 		numIntervals := big.NewInt(0).SetBytes(args[i+1]).Uint64()
 		i += 2
 
-		if !vmcommon.ValidateToken(tokenID) {
+		if tokenIdentifier.ValidateTokenID(tokenID) != nil {
 			return ErrInvalidTokenID
 		}
 
@@ -211,7 +210,7 @@ func (e *esdtDeleteMetaData) addMetadata(args [][]byte) error {
 			return ErrInvalidNonce
 		}
 
-		if !vmcommon.ValidateToken(tokenID) {
+		if tokenIdentifier.ValidateTokenID(tokenID) != nil {
 			return ErrInvalidTokenID
 		}
 
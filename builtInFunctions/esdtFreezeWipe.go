@@ -7,6 +7,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/tokenIdentifier"
 )
 
 type esdtFreezeWipe struct {
@@ -75,7 +76,7 @@ func (e *esdtFreezeWipe) ProcessBuiltinFunction(
 	}
 
 	esdtTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	identifier, nonce := extractTokenIdentifierAndNonceESDTWipe(vmInput.Arguments[0])
+	identifier, nonce := tokenIdentifier.SplitTokenIDAndNonce(vmInput.Arguments[0])
 
 	var amount *big.Int
 	var err error
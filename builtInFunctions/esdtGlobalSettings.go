@@ -295,7 +295,7 @@ func convertToGlobalSettingsHandlerTokenType(esdtType uint32) (uint32, error) {
 	case uint32(core.DynamicMeta):
 		return uint32(dynamicMeta), nil
 	default:
-		return math.MaxUint32, fmt.Errorf("invalid esdt type: %d", esdtType)
+		return math.MaxUint32, fmt.Errorf("%w: %d", ErrInvalidESDTType, esdtType)
 	}
 }
 
@@ -320,7 +320,7 @@ func convertToESDTTokenType(esdtType uint32) (uint32, error) {
 	case dynamicMeta:
 		return uint32(core.DynamicMeta), nil
 	default:
-		return math.MaxUint32, fmt.Errorf("invalid esdt type: %d", esdtType)
+		return math.MaxUint32, fmt.Errorf("%w: %d", ErrInvalidESDTType, esdtType)
 	}
 }
 
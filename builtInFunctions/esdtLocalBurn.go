@@ -67,6 +67,14 @@ func (e *esdtLocalBurn) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtLocalBurn) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT local burn function call
 func (e *esdtLocalBurn) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
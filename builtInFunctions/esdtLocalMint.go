@@ -66,6 +66,14 @@ func (e *esdtLocalMint) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtLocalMint) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT local mint function call
 func (e *esdtLocalMint) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
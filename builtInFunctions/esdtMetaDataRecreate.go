@@ -78,9 +78,7 @@ func NewESDTMetaDataRecreateFunc(
 		marshaller:             marshaller,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(DynamicEsdtFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
 
 	return e, nil
 }
@@ -360,6 +358,14 @@ func (e *esdtMetaDataRecreate) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtMetaDataRecreate) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (e *esdtMetaDataRecreate) IsInterfaceNil() bool {
 	return e == nil
@@ -68,9 +68,7 @@ func NewESDTMetaDataUpdateFunc(
 		marshaller:             marshaller,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(DynamicEsdtFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
 
 	return e, nil
 }
@@ -183,6 +181,14 @@ func (e *esdtMetaDataUpdate) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtMetaDataUpdate) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (e *esdtMetaDataUpdate) IsInterfaceNil() bool {
 	return e == nil
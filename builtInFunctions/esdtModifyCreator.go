@@ -64,9 +64,7 @@ func NewESDTModifyCreatorFunc(
 		marshaller:             marshaller,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(DynamicEsdtFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
 
 	return e, nil
 }
@@ -130,6 +128,14 @@ func (e *esdtModifyCreator) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtModifyCreator) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (e *esdtModifyCreator) IsInterfaceNil() bool {
 	return e == nil
@@ -71,9 +71,7 @@ func NewESDTModifyRoyaltiesFunc(
 		marshaller:             marshaller,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(DynamicEsdtFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
 
 	return e, nil
 }
@@ -142,6 +140,14 @@ func (e *esdtModifyRoyalties) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtModifyRoyalties) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (e *esdtModifyRoyalties) IsInterfaceNil() bool {
 	return e == nil
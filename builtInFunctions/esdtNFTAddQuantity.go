@@ -68,6 +68,14 @@ func (e *esdtNFTAddQuantity) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtNFTAddQuantity) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT NFT add quantity function call
 // Requires 3 arguments:
 // arg0 - token identifier
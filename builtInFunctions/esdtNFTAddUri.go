@@ -63,9 +63,7 @@ func NewESDTNFTAddUriFunc(
 		marshaller:             marshaller,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(ESDTNFTImprovementV1Flag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, ESDTNFTImprovementV1Flag)
 
 	return e, nil
 }
@@ -82,6 +80,14 @@ func (e *esdtNFTAddUri) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtNFTAddUri) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT NFT add uris function call
 // Requires 3 arguments:
 // arg0 - token identifier
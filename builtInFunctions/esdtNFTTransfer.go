@@ -106,6 +106,14 @@ func (e *esdtNFTTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtNFTTransfer) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT NFT transfer roles function call
 // Requires 4 arguments:
 // arg0 - token identifier
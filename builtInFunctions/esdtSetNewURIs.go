@@ -69,9 +69,7 @@ func NewESDTSetNewURIsFunc(
 		marshaller:             marshaller,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(DynamicEsdtFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, DynamicEsdtFlag)
 
 	return e, nil
 }
@@ -144,6 +142,14 @@ func (e *esdtSetNewURIs) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtSetNewURIs) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (e *esdtSetNewURIs) IsInterfaceNil() bool {
 	return e == nil
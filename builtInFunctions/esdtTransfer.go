@@ -80,6 +80,14 @@ func (e *esdtTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtTransfer) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT transfer function calls
 func (e *esdtTransfer) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
@@ -51,9 +51,7 @@ func NewESDTTransferRoleAddressFunc(
 		set:             set,
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(SendAlwaysFlag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, SendAlwaysFlag)
 
 	return e, nil
 }
@@ -0,0 +1,148 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// BuiltInFunctionCall holds the arguments of one built-in function execution
+type BuiltInFunctionCall struct {
+	FunctionName       string
+	SenderAccount      vmcommon.UserAccountHandler
+	DestinationAccount vmcommon.UserAccountHandler
+	Input              *vmcommon.ContractCallInput
+}
+
+// ProcessBuiltInFunctionHandler executes a built-in function call: the next interceptor or the function itself
+type ProcessBuiltInFunctionHandler func(call *BuiltInFunctionCall) (*vmcommon.VMOutput, error)
+
+// ExecutionInterceptorFunc adapts a function to the ExecutionInterceptor interface
+type ExecutionInterceptorFunc func(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error)
+
+// Intercept calls the function
+func (f ExecutionInterceptorFunc) Intercept(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+	return f(call, next)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (f ExecutionInterceptorFunc) IsInterfaceNil() bool {
+	return f == nil
+}
+
+// NewPanicRecoveryInterceptor creates an interceptor that turns a panic of the inner interceptors or of the
+// built-in function into an ErrBuiltInFunctionPanicked error
+func NewPanicRecoveryInterceptor() ExecutionInterceptor {
+	return ExecutionInterceptorFunc(func(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (vmOutput *vmcommon.VMOutput, err error) {
+		defer func() {
+			r := recover()
+			if r != nil {
+				log.Error("built-in function panicked", "function", call.FunctionName, "panic", r)
+				vmOutput, err = nil, fmt.Errorf("%w: %s: %v", ErrBuiltInFunctionPanicked, call.FunctionName, r)
+			}
+		}()
+
+		return next(call)
+	})
+}
+
+// interceptedFunction is stored by the container in place of each function. It runs the function through the
+// execution interceptors of the container, the first added one being the outermost, and returns the classified
+// errors of the function with the function name and the token identifier argument as context, see
+// vmcommon.AddErrorContext.
+type interceptedFunction struct {
+	vmcommon.BuiltinFunction
+	name      string
+	container *functionContainer
+	chain     atomic.Pointer[interceptorChain]
+}
+
+// interceptorChain is the handler that runs a function through a given list of interceptors
+type interceptorChain struct {
+	interceptors *[]ExecutionInterceptor
+	handler      ProcessBuiltInFunctionHandler
+}
+
+func newInterceptedFunction(name string, function vmcommon.BuiltinFunction, container *functionContainer) *interceptedFunction {
+	return &interceptedFunction{
+		BuiltinFunction: UnwrapBuiltinFunction(function),
+		name:            name,
+		container:       container,
+	}
+}
+
+// ProcessBuiltinFunction runs the wrapped function through the interceptors of the container
+func (f *interceptedFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	interceptors := f.container.interceptors.Load()
+	if interceptors == nil {
+		return f.processWithErrorContext(acntSnd, acntDst, vmInput)
+	}
+
+	return f.getChain(interceptors).handler(&BuiltInFunctionCall{
+		FunctionName:       f.name,
+		SenderAccount:      acntSnd,
+		DestinationAccount: acntDst,
+		Input:              vmInput,
+	})
+}
+
+// getChain returns the handler built for the provided interceptors, building it only when the interceptors changed
+func (f *interceptedFunction) getChain(interceptors *[]ExecutionInterceptor) *interceptorChain {
+	chain := f.chain.Load()
+	if chain != nil && chain.interceptors == interceptors {
+		return chain
+	}
+
+	handler := func(call *BuiltInFunctionCall) (*vmcommon.VMOutput, error) {
+		return f.processWithErrorContext(call.SenderAccount, call.DestinationAccount, call.Input)
+	}
+	for i := len(*interceptors) - 1; i >= 0; i-- {
+		handler = chainInterceptor((*interceptors)[i], handler)
+	}
+
+	chain = &interceptorChain{
+		interceptors: interceptors,
+		handler:      handler,
+	}
+	f.chain.Store(chain)
+
+	return chain
+}
+
+func (f *interceptedFunction) processWithErrorContext(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := f.BuiltinFunction.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+	if err != nil {
+		return vmOutput, vmcommon.AddErrorContext(err, f.name, getTokenIdentifierArgument(f.name, f.BuiltinFunction, vmInput))
+	}
+
+	return vmOutput, nil
+}
+
+func chainInterceptor(interceptor ExecutionInterceptor, next ProcessBuiltInFunctionHandler) ProcessBuiltInFunctionHandler {
+	return func(call *BuiltInFunctionCall) (*vmcommon.VMOutput, error) {
+		return interceptor.Intercept(call, next)
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (f *interceptedFunction) IsInterfaceNil() bool {
+	return f == nil
+}
+
+// UnwrapBuiltinFunction returns the function wrapped by a container, or the provided function if it is not wrapped.
+// It must be used before type asserting a function obtained from a container.
+func UnwrapBuiltinFunction(function vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	wrapped, ok := function.(*interceptedFunction)
+	if !ok {
+		return function
+	}
+
+	return wrapped.BuiltinFunction
+}
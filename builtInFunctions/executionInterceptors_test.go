@@ -0,0 +1,220 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRecordingInterceptor(name string, calls *[]string) ExecutionInterceptor {
+	return ExecutionInterceptorFunc(func(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+		*calls = append(*calls, "before "+name+" "+call.FunctionName)
+		vmOutput, err := next(call)
+		*calls = append(*calls, "after "+name)
+		return vmOutput, err
+	})
+}
+
+func TestFunctionContainer_AddExecutionInterceptor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil interceptor should error", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewBuiltInFunctionContainer()
+		assert.Equal(t, ErrNilExecutionInterceptor, c.AddExecutionInterceptor(nil))
+		var nilFunc ExecutionInterceptorFunc
+		assert.Equal(t, ErrNilExecutionInterceptor, c.AddExecutionInterceptor(nilFunc))
+	})
+	t.Run("get should return the wrapped function", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewBuiltInFunctionContainer()
+		function := &mock.BuiltInFunctionStub{}
+		_ = c.Add("key", function)
+
+		wrapped, err := c.Get("key")
+		assert.Nil(t, err)
+		assert.False(t, wrapped == function)
+		assert.True(t, UnwrapBuiltinFunction(wrapped) == function)
+		assert.True(t, UnwrapBuiltinFunction(function) == function)
+
+		other := NewBuiltInFunctionContainer()
+		_ = other.Replace("key", wrapped)
+		rewrapped, _ := other.Get("key")
+		assert.True(t, UnwrapBuiltinFunction(rewrapped) == function)
+	})
+	t.Run("without interceptors the function should be called directly", func(t *testing.T) {
+		t.Parallel()
+
+		expectedOutput := &vmcommon.VMOutput{}
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return expectedOutput, nil
+			},
+		})
+
+		function, _ := c.Get("key")
+		vmOutput, err := function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.True(t, vmOutput == expectedOutput)
+	})
+	t.Run("classified errors should carry the function and token context", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add(core.BuiltInFunctionESDTTransfer, &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, ErrInsufficientFunds
+			},
+		})
+
+		var seenErr error
+		_ = c.AddExecutionInterceptor(ExecutionInterceptorFunc(func(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+			vmOutput, err := next(call)
+			seenErr = err
+			return vmOutput, err
+		}))
+		function, _ := c.Get(core.BuiltInFunctionESDTTransfer)
+		_, err := function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{
+			VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("TKN-abcdef"), {1}}},
+			Function: core.BuiltInFunctionESDTTransfer,
+		})
+		assert.Equal(t, seenErr, err)
+		assert.True(t, errors.Is(err, ErrInsufficientFunds))
+		assert.Equal(t, ErrInsufficientFunds.Error(), err.Error())
+
+		vmErr := &vmcommon.VMError{}
+		require.True(t, errors.As(err, &vmErr))
+		assert.Equal(t, vmcommon.OutOfFunds, vmErr.ReturnCode())
+		assert.Equal(t, core.BuiltInFunctionESDTTransfer, vmErr.Function())
+		assert.Equal(t, []byte("TKN-abcdef"), vmErr.TokenID())
+
+		sentinel := &vmcommon.VMError{}
+		require.True(t, errors.As(ErrInsufficientFunds, &sentinel))
+		assert.Empty(t, sentinel.Function())
+		assert.Nil(t, sentinel.TokenID())
+	})
+	t.Run("interceptors should run in order around the function", func(t *testing.T) {
+		t.Parallel()
+
+		calls := make([]string, 0)
+		sender, destination := &mock.UserAccountStub{}, &mock.UserAccountStub{}
+		input := &vmcommon.ContractCallInput{Function: "key"}
+		expectedOutput := &vmcommon.VMOutput{ReturnData: [][]byte{[]byte("data")}}
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.True(t, acntSnd == sender)
+				assert.True(t, acntDst == destination)
+				assert.True(t, vmInput == input)
+				calls = append(calls, "function")
+				return expectedOutput, nil
+			},
+		}
+
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", function)
+		wrapped, _ := c.Get("key")
+		require.Nil(t, c.AddExecutionInterceptor(createRecordingInterceptor("first", &calls)))
+
+		_, _ = wrapped.ProcessBuiltinFunction(sender, destination, input)
+		assert.Equal(t, []string{"before first key", "function", "after first"}, calls)
+		calls = calls[:0]
+
+		require.Nil(t, c.AddExecutionInterceptor(createRecordingInterceptor("second", &calls)))
+		vmOutput, err := wrapped.ProcessBuiltinFunction(sender, destination, input)
+		assert.Nil(t, err)
+		assert.True(t, vmOutput == expectedOutput)
+		assert.Equal(t, []string{"before first key", "before second key", "function", "after second", "after first"}, calls, "the function got before adding an interceptor must run through it")
+	})
+	t.Run("interceptors should see the result and may short-circuit", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		functionCalled := false
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				functionCalled = true
+				return nil, expectedErr
+			},
+		})
+
+		var seenErr error
+		_ = c.AddExecutionInterceptor(ExecutionInterceptorFunc(func(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+			vmOutput, err := next(call)
+			seenErr = err
+			return vmOutput, err
+		}))
+		function, _ := c.Get("key")
+		input := &vmcommon.ContractCallInput{Function: "key"}
+		_, err := function.ProcessBuiltinFunction(nil, nil, input)
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, expectedErr, seenErr)
+		assert.True(t, functionCalled)
+
+		functionCalled = false
+		_ = c.AddExecutionInterceptor(ExecutionInterceptorFunc(func(_ *BuiltInFunctionCall, _ ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+			return nil, ErrOperationNotPermitted
+		}))
+		_, err = function.ProcessBuiltinFunction(nil, nil, input)
+		assert.Equal(t, ErrOperationNotPermitted, err)
+		assert.Equal(t, ErrOperationNotPermitted, seenErr)
+		assert.False(t, functionCalled)
+	})
+}
+
+func TestNewPanicRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := NewPanicRecoveryInterceptor()
+	assert.False(t, check.IfNil(interceptor))
+
+	call := &BuiltInFunctionCall{FunctionName: "key"}
+	vmOutput, err := interceptor.Intercept(call, func(_ *BuiltInFunctionCall) (*vmcommon.VMOutput, error) {
+		panic("boom")
+	})
+	assert.Nil(t, vmOutput)
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionPanicked))
+	assert.Equal(t, "built-in function panicked: key: boom", err.Error())
+	assert.Equal(t, vmcommon.ExecutionFailed, vmcommon.GetReturnCodeFromError(err))
+
+	expectedOutput := &vmcommon.VMOutput{}
+	vmOutput, err = interceptor.Intercept(call, func(_ *BuiltInFunctionCall) (*vmcommon.VMOutput, error) {
+		return expectedOutput, nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, vmOutput == expectedOutput)
+}
+
+func TestBuiltInFuncCreator_AddExecutionInterceptor(t *testing.T) {
+	t.Parallel()
+
+	f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	assert.Equal(t, ErrNilExecutionInterceptor, f.AddExecutionInterceptor(nil))
+
+	calls := make([]string, 0)
+	require.Nil(t, f.AddExecutionInterceptor(createRecordingInterceptor("first", &calls)))
+	require.Nil(t, f.CreateBuiltInFunctionContainer())
+	require.Nil(t, f.AddExecutionInterceptor(createRecordingInterceptor("second", &calls)))
+	require.Nil(t, f.SetPayableHandler(&mock.PayableHandlerStub{}))
+	require.Nil(t, f.SetBlockchainHook(&disabledBlockchainHook{}))
+	_, err := f.ApplyGasScheduleChange(fillGasMapInternal(make(map[string]map[string]uint64), 2))
+	require.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionESDTTransfer)
+	_, err = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{CallValue: big.NewInt(0)},
+		Function: core.BuiltInFunctionESDTTransfer,
+	})
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	assert.Equal(t, []string{"before first ESDTTransfer", "before second ESDTTransfer", "after second", "after first"}, calls)
+}
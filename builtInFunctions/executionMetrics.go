@@ -0,0 +1,108 @@
+package builtInFunctions
+
+import (
+	"errors"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ ExecutionInterceptor = (*executionMetricsInterceptor)(nil)
+
+// UnclassifiedErrorKind is the error kind of the errors that do not carry a vmcommon.VMError
+const UnclassifiedErrorKind = "UNCLASSIFIED"
+
+// ExecutionRecord describes one execution of a built-in function
+type ExecutionRecord struct {
+	FunctionName string
+	// ErrorKind is empty for a successful execution. Otherwise, it is the code of the vmcommon.VMError carried by the
+	// error, UnclassifiedErrorKind for the other errors, or the return code for a failed VMOutput without an error.
+	ErrorKind string
+	// GasUsed is the gas provided minus the gas remaining in the VMOutput and minus the gas forwarded with the output
+	// transfers. A failed execution uses all the provided gas.
+	GasUsed uint64
+	// StorageBytesTouched is the size of the keys and values of the storage updates of the VMOutput
+	StorageBytesTouched uint64
+}
+
+type executionMetricsInterceptor struct {
+	sink ExecutionMetricsSink
+}
+
+// NewExecutionMetricsInterceptor creates an interceptor that sends an ExecutionRecord to the sink for every
+// execution of a built-in function
+func NewExecutionMetricsInterceptor(sink ExecutionMetricsSink) (*executionMetricsInterceptor, error) {
+	if check.IfNil(sink) {
+		return nil, ErrNilExecutionMetricsSink
+	}
+
+	return &executionMetricsInterceptor{
+		sink: sink,
+	}, nil
+}
+
+// Intercept executes the call and records its outcome
+func (emi *executionMetricsInterceptor) Intercept(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+	vmOutput, err := next(call)
+	emi.sink.RecordExecution(createExecutionRecord(call, vmOutput, err))
+
+	return vmOutput, err
+}
+
+func createExecutionRecord(call *BuiltInFunctionCall, vmOutput *vmcommon.VMOutput, err error) *ExecutionRecord {
+	record := &ExecutionRecord{
+		FunctionName: call.FunctionName,
+		ErrorKind:    getErrorKind(vmOutput, err),
+	}
+
+	gasProvided := uint64(0)
+	if call.Input != nil {
+		gasProvided = call.Input.GasProvided
+	}
+	record.GasUsed = gasProvided
+	if len(record.ErrorKind) > 0 {
+		return record
+	}
+
+	gasNotUsed := vmOutput.GasRemaining
+	for _, outAcc := range vmOutput.OutputAccounts {
+		if outAcc == nil {
+			continue
+		}
+		for _, storageUpdate := range outAcc.StorageUpdates {
+			record.StorageBytesTouched += uint64(len(storageUpdate.Offset) + len(storageUpdate.Data))
+		}
+		for _, outTransfer := range outAcc.OutputTransfers {
+			gasNotUsed += outTransfer.GasLimit
+		}
+	}
+	if gasNotUsed <= gasProvided {
+		record.GasUsed = gasProvided - gasNotUsed
+	}
+
+	return record
+}
+
+func getErrorKind(vmOutput *vmcommon.VMOutput, err error) string {
+	if err == nil {
+		if vmOutput == nil {
+			return UnclassifiedErrorKind
+		}
+		if vmOutput.ReturnCode != vmcommon.Ok {
+			return vmOutput.ReturnCode.String()
+		}
+		return ""
+	}
+
+	vmErr := &vmcommon.VMError{}
+	if errors.As(err, &vmErr) && len(vmErr.Code()) > 0 {
+		return vmErr.Code()
+	}
+
+	return UnclassifiedErrorKind
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (emi *executionMetricsInterceptor) IsInterfaceNil() bool {
+	return emi == nil
+}
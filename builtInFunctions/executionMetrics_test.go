@@ -0,0 +1,131 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type executionMetricsSinkStub struct {
+	records []*ExecutionRecord
+}
+
+func (stub *executionMetricsSinkStub) RecordExecution(record *ExecutionRecord) {
+	stub.records = append(stub.records, record)
+}
+
+func (stub *executionMetricsSinkStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func TestNewExecutionMetricsInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor, err := NewExecutionMetricsInterceptor(nil)
+	assert.Nil(t, interceptor)
+	assert.Equal(t, ErrNilExecutionMetricsSink, err)
+
+	interceptor, err = NewExecutionMetricsInterceptor(&executionMetricsSinkStub{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(interceptor))
+}
+
+func TestExecutionMetricsInterceptor_Intercept(t *testing.T) {
+	t.Parallel()
+
+	call := &BuiltInFunctionCall{
+		FunctionName: "key",
+		Input:        &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 100}},
+	}
+
+	testData := []struct {
+		name     string
+		vmOutput *vmcommon.VMOutput
+		err      error
+		expected ExecutionRecord
+	}{
+		{
+			name: "success",
+			vmOutput: &vmcommon.VMOutput{
+				GasRemaining: 40,
+				OutputAccounts: map[string]*vmcommon.OutputAccount{
+					"a": {StorageUpdates: map[string]*vmcommon.StorageUpdate{"k": {Offset: []byte("key"), Data: []byte("value")}}},
+					"b": nil,
+				},
+			},
+			expected: ExecutionRecord{FunctionName: "key", GasUsed: 60, StorageBytesTouched: 8},
+		},
+		{
+			name: "forwarded gas should not be used",
+			vmOutput: &vmcommon.VMOutput{
+				GasRemaining: 10,
+				OutputAccounts: map[string]*vmcommon.OutputAccount{
+					"a": {OutputTransfers: []vmcommon.OutputTransfer{{GasLimit: 30, GasLocked: 5}, {GasLimit: 20}}},
+				},
+			},
+			expected: ExecutionRecord{FunctionName: "key", GasUsed: 40},
+		},
+		{
+			name:     "classified error",
+			err:      errors.Join(errors.New("context"), ErrNotEnoughGas),
+			expected: ExecutionRecord{FunctionName: "key", ErrorKind: "NOT_ENOUGH_GAS", GasUsed: 100},
+		},
+		{
+			name:     "unclassified error",
+			err:      errors.New("error"),
+			expected: ExecutionRecord{FunctionName: "key", ErrorKind: UnclassifiedErrorKind, GasUsed: 100},
+		},
+		{
+			name:     "failed output",
+			vmOutput: &vmcommon.VMOutput{ReturnCode: vmcommon.OutOfFunds, GasRemaining: 40},
+			expected: ExecutionRecord{FunctionName: "key", ErrorKind: vmcommon.OutOfFunds.String(), GasUsed: 100},
+		},
+	}
+
+	for _, td := range testData {
+		sink := &executionMetricsSinkStub{}
+		interceptor, _ := NewExecutionMetricsInterceptor(sink)
+		vmOutput, err := interceptor.Intercept(call, func(_ *BuiltInFunctionCall) (*vmcommon.VMOutput, error) {
+			return td.vmOutput, td.err
+		})
+		assert.True(t, vmOutput == td.vmOutput, td.name)
+		assert.Equal(t, td.err, err, td.name)
+		require.Len(t, sink.records, 1, td.name)
+		assert.Equal(t, td.expected, *sink.records[0], td.name)
+	}
+}
+
+func TestBuiltInFuncCreator_MetricsSink(t *testing.T) {
+	t.Parallel()
+
+	sink, _ := NewInMemoryMetricsSink(nil)
+	args := createMockArguments()
+	args.MetricsSink = sink
+	f, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	require.Nil(t, f.CreateBuiltInFunctionContainer())
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionClaimDeveloperRewards)
+	_, _ = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{CallValue: big.NewInt(0), GasProvided: 10},
+		Function: core.BuiltInFunctionClaimDeveloperRewards,
+	})
+	_, _ = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{CallValue: big.NewInt(1), GasProvided: 10},
+		Function: core.BuiltInFunctionClaimDeveloperRewards,
+	})
+
+	metrics := sink.Snapshot()
+	require.Contains(t, metrics, core.BuiltInFunctionClaimDeveloperRewards)
+	claimMetrics := metrics[core.BuiltInFunctionClaimDeveloperRewards]
+	assert.Equal(t, uint64(2), claimMetrics.Calls)
+	assert.Equal(t, uint64(1), claimMetrics.Errors)
+	assert.Equal(t, map[string]uint64{"BUILT_IN_FUNCTION_CALLED_WITH_VALUE": 1}, claimMetrics.ErrorsByKind)
+	assert.Equal(t, uint64(20), claimMetrics.GasUsed.Sum)
+}
@@ -57,3 +57,8 @@ var allFlags = []core.EnableEpochFlag{
 	DynamicEsdtFlag,
 	EGLDInESDTMultiTransferFlag,
 }
+
+// AllFlags returns all the flags used by mx-chain-vm-common-go in the current version
+func AllFlags() []core.EnableEpochFlag {
+	return append(make([]core.EnableEpochFlag, 0, len(allFlags)), allFlags...)
+}
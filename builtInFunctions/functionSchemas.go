@@ -0,0 +1,241 @@
+package builtInFunctions
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var functionSchemas = createFunctionSchemas()
+
+// GetFunctionSchema returns the arguments schema of a built-in function of this package. The returned schema is
+// shared and must not be modified.
+func GetFunctionSchema(functionName string) (*FunctionSchema, bool) {
+	schema, ok := functionSchemas[functionName]
+	return schema, ok
+}
+
+func createFunctionSchemas() map[string]*FunctionSchema {
+	nftCreateArguments := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		argument("initialQuantity", ArgumentBigUint),
+		argument("name", ArgumentBytes),
+		argument("royalties", ArgumentBigUint),
+		argument("hash", ArgumentBytes),
+		argument("attributes", ArgumentBytes),
+		repeatedUpTo("uris", 1, argument("uri", ArgumentBytes)),
+	}
+	metaDataArguments := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		argument("nonce", ArgumentNonce),
+		argument("name", ArgumentBytes),
+		argument("royalties", ArgumentBigUint),
+		argument("hash", ArgumentBytes),
+		argument("attributes", ArgumentBytes),
+		repeatedUpTo("uris", 1, argument("uri", ArgumentBytes)),
+	}
+	scCall := repeatedUpTo("scCall", 0, argument("functionOrArgument", ArgumentBytes))
+	tokenOnly := []*ArgumentSchema{argument("tokenIdentifier", ArgumentTokenIdentifier)}
+	tokenAndNonce := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		argument("nonce", ArgumentNonce),
+	}
+	tokenAndAddresses := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		repeatedUpTo("addresses", 1, argument("address", ArgumentAddress)),
+	}
+	tokenAndRoles := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		repeatedUpTo("roles", 1, argument("role", ArgumentBytes)),
+	}
+	tokenAndValue := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		argument("value", ArgumentBigUint),
+	}
+	tokenNonceAndQuantity := []*ArgumentSchema{
+		argument("tokenIdentifier", ArgumentTokenIdentifier),
+		argument("nonce", ArgumentNonce),
+		argument("quantity", ArgumentBigUint),
+	}
+
+	schemas := []*FunctionSchema{
+		{FunctionName: core.BuiltInFunctionClaimDeveloperRewards},
+		{FunctionName: core.BuiltInFunctionChangeOwnerAddress, Arguments: []*ArgumentSchema{argument("newOwner", ArgumentAddress)}},
+		{FunctionName: core.BuiltInFunctionSetUserName, Arguments: []*ArgumentSchema{argument("userName", ArgumentBytes)}},
+		{FunctionName: deleteUserNameFuncName},
+		{
+			FunctionName: core.BuiltInFunctionSaveKeyValue,
+			Arguments: []*ArgumentSchema{
+				repeatedUpTo("keyValuePairs", 1, argument("key", ArgumentBytes), argument("value", ArgumentBytes)),
+			},
+		},
+		{
+			FunctionName: core.BuiltInFunctionESDTTransfer,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("value", ArgumentBigUint),
+				scCall,
+			},
+		},
+		{FunctionName: core.BuiltInFunctionESDTBurn, Arguments: tokenAndValue},
+		{FunctionName: core.BuiltInFunctionESDTPause, Arguments: tokenOnly},
+		{FunctionName: core.BuiltInFunctionESDTUnPause, Arguments: tokenOnly},
+		{FunctionName: core.BuiltInFunctionSetESDTRole, Arguments: tokenAndRoles},
+		{FunctionName: core.BuiltInFunctionUnSetESDTRole, Arguments: tokenAndRoles},
+		{FunctionName: core.BuiltInFunctionESDTLocalBurn, Arguments: tokenAndValue},
+		{FunctionName: core.BuiltInFunctionESDTLocalMint, Arguments: tokenAndValue},
+		{FunctionName: core.BuiltInFunctionESDTNFTAddQuantity, Arguments: tokenNonceAndQuantity},
+		{FunctionName: core.BuiltInFunctionESDTNFTBurn, Arguments: tokenNonceAndQuantity},
+		{
+			FunctionName:                core.BuiltInFunctionESDTNFTCreate,
+			Arguments:                   nftCreateArguments,
+			ExecOnDestByCallerArguments: append(nftCreateArguments[:len(nftCreateArguments):len(nftCreateArguments)], argument("scAddressWithRoles", ArgumentAddress)),
+		},
+		{FunctionName: core.BuiltInFunctionESDTFreeze, Arguments: tokenOnly},
+		{FunctionName: core.BuiltInFunctionESDTUnFreeze, Arguments: tokenOnly},
+		{FunctionName: core.BuiltInFunctionESDTWipe, Arguments: tokenOnly},
+		{
+			FunctionName: core.BuiltInFunctionESDTNFTTransfer,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("nonce", ArgumentNonce),
+				argument("quantity", ArgumentBigUint),
+				argument("destination", ArgumentAddress),
+				scCall,
+			},
+		},
+		{
+			FunctionName: core.BuiltInFunctionESDTNFTCreateRoleTransfer,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("destination", ArgumentAddress),
+			},
+		},
+		{
+			FunctionName: core.BuiltInFunctionESDTNFTUpdateAttributes,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("nonce", ArgumentNonce),
+				argument("attributes", ArgumentBytes),
+			},
+		},
+		{
+			FunctionName: core.BuiltInFunctionESDTNFTAddURI,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("nonce", ArgumentNonce),
+				repeatedUpTo("uris", 1, argument("uri", ArgumentBytes)),
+			},
+		},
+		{
+			FunctionName: core.BuiltInFunctionMultiESDTNFTTransfer,
+			Arguments: []*ArgumentSchema{
+				argument("destination", ArgumentAddress),
+				argument("numTransfers", ArgumentBigUint),
+				repeatedCounted("transfers", "numTransfers", 1,
+					argument("tokenIdentifier", ArgumentTokenIdentifier),
+					argument("nonce", ArgumentNonce),
+					argument("quantity", ArgumentBigUint),
+				),
+				scCall,
+			},
+		},
+		{FunctionName: core.BuiltInFunctionESDTSetLimitedTransfer, Arguments: tokenOnly},
+		{FunctionName: core.BuiltInFunctionESDTUnSetLimitedTransfer, Arguments: tokenOnly},
+		{
+			FunctionName: vmcommon.ESDTDeleteMetadata,
+			Arguments: []*ArgumentSchema{
+				repeatedUpTo("tokens", 1,
+					argument("tokenIdentifier", ArgumentTokenIdentifier),
+					argument("numIntervals", ArgumentBigUint),
+					repeatedCounted("intervals", "numIntervals", 1,
+						argument("startNonce", ArgumentNonce),
+						argument("endNonce", ArgumentNonce),
+					),
+				),
+			},
+		},
+		{
+			FunctionName: vmcommon.ESDTAddMetadata,
+			Arguments: []*ArgumentSchema{
+				repeatedUpTo("tokens", 1,
+					argument("tokenIdentifier", ArgumentTokenIdentifier),
+					argument("nonce", ArgumentNonce),
+					argument("metaData", ArgumentBytes),
+				),
+			},
+		},
+		{FunctionName: vmcommon.BuiltInFunctionESDTSetBurnRoleForAll, Arguments: tokenOnly},
+		{FunctionName: vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll, Arguments: tokenOnly},
+		{FunctionName: vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress, Arguments: tokenAndAddresses},
+		{FunctionName: vmcommon.BuiltInFunctionESDTTransferRoleAddAddress, Arguments: tokenAndAddresses},
+		{
+			FunctionName: core.BuiltInFunctionSetGuardian,
+			Arguments: []*ArgumentSchema{
+				argument("guardian", ArgumentAddress),
+				argument("guardianServiceUID", ArgumentBytes),
+			},
+		},
+		{FunctionName: core.BuiltInFunctionGuardAccount},
+		{FunctionName: core.BuiltInFunctionUnGuardAccount},
+		{FunctionName: core.BuiltInFunctionMigrateDataTrie},
+		{
+			FunctionName: core.ESDTSetTokenType,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("tokenType", ArgumentBytes),
+			},
+		},
+		{FunctionName: core.ESDTMetaDataRecreate, Arguments: metaDataArguments},
+		{FunctionName: core.ESDTMetaDataUpdate, Arguments: metaDataArguments},
+		{
+			FunctionName: core.ESDTSetNewURIs,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("nonce", ArgumentNonce),
+				repeatedUpTo("uris", 1, argument("uri", ArgumentBytes)),
+			},
+		},
+		{
+			FunctionName: core.ESDTModifyRoyalties,
+			Arguments: []*ArgumentSchema{
+				argument("tokenIdentifier", ArgumentTokenIdentifier),
+				argument("nonce", ArgumentNonce),
+				argument("royalties", ArgumentBigUint),
+			},
+		},
+		{FunctionName: core.ESDTModifyCreator, Arguments: tokenAndNonce},
+	}
+
+	schemasByName := make(map[string]*FunctionSchema, len(schemas))
+	for _, schema := range schemas {
+		schemasByName[schema.FunctionName] = schema
+	}
+
+	return schemasByName
+}
+
+func argument(name string, argumentType ArgumentType) *ArgumentSchema {
+	return &ArgumentSchema{
+		Name: name,
+		Type: argumentType,
+	}
+}
+
+func repeatedUpTo(name string, minCount int, group ...*ArgumentSchema) *ArgumentSchema {
+	return &ArgumentSchema{
+		Name:     name,
+		Type:     ArgumentRepeated,
+		Group:    group,
+		MinCount: minCount,
+	}
+}
+
+func repeatedCounted(name string, countArgument string, minCount int, group ...*ArgumentSchema) *ArgumentSchema {
+	return &ArgumentSchema{
+		Name:          name,
+		Type:          ArgumentRepeated,
+		Group:         group,
+		CountArgument: countArgument,
+		MinCount:      minCount,
+	}
+}
@@ -0,0 +1,258 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/inMemory"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkSchemaConsistency(t *testing.T, functionName string, schema []*ArgumentSchema, counts map[string]struct{}) {
+	for i, argument := range schema {
+		if argument.Type != ArgumentRepeated {
+			assert.Contains(t, []ArgumentType{ArgumentTokenIdentifier, ArgumentNonce, ArgumentBigUint, ArgumentAddress, ArgumentBytes}, argument.Type, "%s: %s", functionName, argument.Name)
+			counts[argument.Name] = struct{}{}
+			continue
+		}
+
+		assert.NotEmpty(t, argument.Group, "%s: %s", functionName, argument.Name)
+		if len(argument.CountArgument) > 0 {
+			_, ok := counts[argument.CountArgument]
+			assert.True(t, ok, "%s: %s", functionName, argument.Name)
+		} else {
+			for _, next := range schema[i+1:] {
+				assert.NotEqual(t, ArgumentRepeated, next.Type, "%s: %s", functionName, argument.Name)
+			}
+		}
+		checkSchemaConsistency(t, functionName, argument.Group, counts)
+	}
+}
+
+func TestFunctionSchemas_AllCreatedFunctionsAreDescribed(t *testing.T) {
+	t.Parallel()
+
+	f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	require.Nil(t, f.CreateBuiltInFunctionContainer())
+	c := f.BuiltInFunctionContainer().(*functionContainer)
+
+	schemas := c.FunctionSchemas()
+	assert.Equal(t, c.Len(), len(schemas))
+	for key := range c.Keys() {
+		schema, err := c.FunctionSchema(key)
+		require.Nil(t, err, key)
+		assert.Equal(t, key, schema.FunctionName)
+	}
+}
+
+func TestFunctionSchemas_Consistency(t *testing.T) {
+	t.Parallel()
+
+	for name, schema := range functionSchemas {
+		assert.Equal(t, name, schema.FunctionName)
+		checkSchemaConsistency(t, name, schema.Arguments, make(map[string]struct{}))
+		checkSchemaConsistency(t, name, schema.ExecOnDestByCallerArguments, make(map[string]struct{}))
+	}
+}
+
+// generateArguments builds an argument list matching the schema, with every repeated group repeated count times,
+// but at least its minimum
+func generateArguments(schema []*ArgumentSchema, count int, address []byte, values map[string][]byte) [][]byte {
+	repetitions := make(map[string]int)
+	for _, argument := range schema {
+		if argument.Type == ArgumentRepeated {
+			repetitions[argument.Name] = count
+			if count < argument.MinCount {
+				repetitions[argument.Name] = argument.MinCount
+			}
+			if len(argument.CountArgument) > 0 {
+				repetitions[argument.CountArgument] = repetitions[argument.Name]
+			}
+		}
+	}
+
+	arguments := make([][]byte, 0)
+	for _, argument := range schema {
+		if argument.Type != ArgumentRepeated {
+			numRepetitions, isCount := repetitions[argument.Name]
+			if isCount {
+				arguments = append(arguments, big.NewInt(int64(numRepetitions)).Bytes())
+				continue
+			}
+
+			arguments = append(arguments, generateArgument(argument, address, values))
+			continue
+		}
+
+		for i := 0; i < repetitions[argument.Name]; i++ {
+			arguments = append(arguments, generateArguments(argument.Group, count, address, values)...)
+		}
+	}
+
+	return arguments
+}
+
+func generateArgument(argument *ArgumentSchema, address []byte, values map[string][]byte) []byte {
+	value, ok := values[argument.Name]
+	if ok {
+		return value
+	}
+
+	switch argument.Type {
+	case ArgumentTokenIdentifier:
+		return []byte("TOKEN-abcdef")
+	case ArgumentAddress:
+		return address
+	case ArgumentNonce, ArgumentBigUint:
+		return []byte{1}
+	default:
+		return []byte("value")
+	}
+}
+
+func isArgumentsError(err error) bool {
+	return errors.Is(err, ErrInvalidArguments) || errors.Is(err, ErrInvalidNumberOfArguments) || errors.Is(err, ErrInvalidNumOfArgs)
+}
+
+func TestFunctionSchemas_RealFunctionsAcceptTheSchemaArguments(t *testing.T) {
+	t.Parallel()
+
+	user := bytes.Repeat([]byte{1}, 32)
+	other := bytes.Repeat([]byte{2}, 32)
+	tokenID := []byte("TOKEN-abcdef")
+
+	// the functions called by the ESDT system SC on the accounts of its users
+	esdtSCFunctions := map[string]struct{}{
+		core.BuiltInFunctionESDTFreeze:                {},
+		core.BuiltInFunctionESDTUnFreeze:              {},
+		core.BuiltInFunctionESDTWipe:                  {},
+		core.BuiltInFunctionSetESDTRole:               {},
+		core.BuiltInFunctionUnSetESDTRole:             {},
+		core.BuiltInFunctionESDTNFTCreateRoleTransfer: {},
+	}
+	// the functions called by the ESDT system SC on the system account
+	systemAccountFunctions := map[string]struct{}{
+		core.BuiltInFunctionESDTPause:                         {},
+		core.BuiltInFunctionESDTUnPause:                       {},
+		core.BuiltInFunctionESDTSetLimitedTransfer:            {},
+		core.BuiltInFunctionESDTUnSetLimitedTransfer:          {},
+		vmcommon.BuiltInFunctionESDTSetBurnRoleForAll:         {},
+		vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll:       {},
+		vmcommon.BuiltInFunctionESDTTransferRoleAddAddress:    {},
+		vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress: {},
+		core.ESDTSetTokenType:                                 {},
+	}
+	// the functions that need more state than set up here, and fail after checking their arguments. The metadata
+	// of the same token can only be added once, so ESDTAddMetadata fails on the repetitions.
+	expectedErrors := map[string]error{
+		vmcommon.ESDTAddMetadata:           ErrTokenHasValidMetadata,
+		core.BuiltInFunctionESDTWipe:       ErrCannotWipeAccountNotFrozen,
+		core.BuiltInFunctionUnGuardAccount: ErrSetUnGuardAccount,
+	}
+
+	for name, schema := range functionSchemas {
+		for _, count := range []int{0, 3} {
+			accounts := inMemory.NewAccountsAdapter()
+			args := createMockArguments()
+			args.Accounts = accounts
+			args.MapDNSAddresses = map[string]struct{}{string(user): {}}
+			args.MapDNSV2Addresses = map[string]struct{}{string(user): {}}
+			args.ConfigAddress = user
+			args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+				IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+					return true
+				},
+			}
+			f, err := NewBuiltInFunctionsCreator(args)
+			require.Nil(t, err)
+			require.Nil(t, f.CreateBuiltInFunctionContainer())
+			require.Nil(t, f.SetPayableHandler(&mock.PayableHandlerStub{}))
+			require.Nil(t, f.SetBlockchainHook(&disabledBlockchainHook{}))
+
+			account := inMemory.NewUserAccount(user)
+			account.SetOwnerAddress(user)
+			roles, _ := f.marshaller.Marshal(&esdt.ESDTRoles{Roles: [][]byte{
+				[]byte(core.ESDTRoleLocalMint),
+				[]byte(core.ESDTRoleLocalBurn),
+				[]byte(core.ESDTRoleNFTCreate),
+				[]byte(core.ESDTRoleNFTAddQuantity),
+				[]byte(core.ESDTRoleNFTBurn),
+				[]byte(core.ESDTRoleNFTAddURI),
+				[]byte(core.ESDTRoleNFTUpdateAttributes),
+				[]byte(core.ESDTRoleNFTRecreate),
+				[]byte(core.ESDTRoleNFTUpdate),
+				[]byte(core.ESDTRoleSetNewURI),
+				[]byte(core.ESDTRoleModifyRoyalties),
+				[]byte(core.ESDTRoleModifyCreator),
+			}})
+			require.Nil(t, account.AccountDataHandler().SaveKeyValue(append(append([]byte{}, roleKeyPrefix...), tokenID...), roles))
+			esdtTokenKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+			for _, nonce := range []uint64{0, 1} {
+				esdtData := &esdt.ESDigitalToken{Type: uint32(core.Fungible), Value: big.NewInt(10)}
+				if nonce > 0 {
+					esdtData.Type = uint32(core.NonFungibleV2)
+					esdtData.TokenMetaData = &esdt.MetaData{Nonce: nonce, Creator: user, URIs: [][]byte{[]byte("uri")}}
+				}
+				_, err = f.esdtStorageHandler.SaveESDTNFTToken(user, account, esdtTokenKey, nonce, esdtData, vmcommon.NftSaveArgs{MustUpdateAllFields: true})
+				require.Nil(t, err)
+			}
+			require.Nil(t, accounts.SaveAccount(account))
+
+			metaData, _ := f.marshaller.Marshal(&esdt.MetaData{Nonce: 1})
+			schemaArgumentValues := map[string][]byte{
+				"role":               []byte(core.ESDTRoleLocalMint),
+				"tokenType":          []byte(core.NonFungibleESDTv2),
+				"guardianServiceUID": []byte("uid"),
+				"metaData":           metaData,
+			}
+			arguments := generateArguments(schema.Arguments, count, other, schemaArgumentValues)
+			require.Nil(t, schema.Validate(&vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallerAddr: user, Arguments: arguments}}), name)
+
+			input := &vmcommon.ContractCallInput{
+				VMInput: vmcommon.VMInput{
+					CallerAddr:  user,
+					Arguments:   arguments,
+					CallValue:   big.NewInt(0),
+					GasProvided: 1_000_000,
+				},
+				RecipientAddr: user,
+				Function:      name,
+			}
+			sender, _ := accounts.LoadAccount(user)
+			acntSnd, acntDst := sender.(vmcommon.UserAccountHandler), sender.(vmcommon.UserAccountHandler)
+			_, isESDTSCFunction := esdtSCFunctions[name]
+			_, isSystemAccountFunction := systemAccountFunctions[name]
+			switch {
+			case isESDTSCFunction:
+				input.CallerAddr = core.ESDTSCAddress
+				acntSnd = nil
+			case isSystemAccountFunction:
+				input.CallerAddr = core.ESDTSCAddress
+				input.RecipientAddr = vmcommon.SystemAccountAddress
+				acntSnd, acntDst = nil, nil
+			case name == core.BuiltInFunctionESDTBurn:
+				input.RecipientAddr = core.ESDTSCAddress
+				acntDst = nil
+			}
+
+			function, err := f.BuiltInFunctionContainer().Get(name)
+			require.Nil(t, err, name)
+			_, err = function.ProcessBuiltinFunction(acntSnd, acntDst, input)
+			assert.False(t, isArgumentsError(err), "%s with %d repetitions: %v", name, count, err)
+			expectedErr, ok := expectedErrors[name]
+			if ok && err != nil {
+				assert.True(t, errors.Is(err, expectedErr), "%s with %d repetitions: %v", name, count, err)
+				continue
+			}
+			assert.Nil(t, err, "%s with %d repetitions", name, count)
+		}
+	}
+}
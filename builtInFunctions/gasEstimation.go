@@ -0,0 +1,392 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// GasEstimate is the breakdown of the gas a built-in function call is expected to use
+type GasEstimate struct {
+	FunctionName string
+	// BaseCost is the fixed cost of the function, multiplied by the number of transfers for a multi transfer
+	BaseCost uint64
+	// StorageCost is the cost of the bytes persisted or stored by the call, computed with the per-byte costs
+	StorageCost uint64
+	// DataCopyCost is the cost of the NFT data sent along with a transfer. When the destination is in another shard
+	// that already received the token data, the data is still counted, so the estimate is an upper bound there.
+	DataCopyCost uint64
+	// DataTrieLoadCost is the cost of the data trie nodes loaded while reading the current storage values. The
+	// functions do not charge it, so it is not included in Total and can be added to the gas limit as a safety margin.
+	DataTrieLoadCost uint64
+	// Total is the gas the call uses: the base cost plus the storage and the data copy costs
+	Total uint64
+}
+
+type gasEstimationHandler func(b *builtInFuncCreator, gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error)
+
+var baseCostOnlyFunctions = map[string]struct{}{
+	core.BuiltInFunctionClaimDeveloperRewards: {},
+	core.BuiltInFunctionChangeOwnerAddress:    {},
+	core.BuiltInFunctionSetUserName:           {},
+	deleteUserNameFuncName:                    {},
+	core.BuiltInFunctionESDTTransfer:          {},
+	core.BuiltInFunctionESDTBurn:              {},
+	core.BuiltInFunctionESDTLocalMint:         {},
+	core.BuiltInFunctionESDTLocalBurn:         {},
+	core.BuiltInFunctionESDTNFTAddQuantity:    {},
+	core.BuiltInFunctionESDTNFTBurn:           {},
+	core.BuiltInFunctionSetGuardian:           {},
+	core.BuiltInFunctionGuardAccount:          {},
+	core.BuiltInFunctionUnGuardAccount:        {},
+	core.ESDTModifyRoyalties:                  {},
+	core.ESDTModifyCreator:                    {},
+}
+
+var gasEstimationHandlers = map[string]gasEstimationHandler{
+	core.BuiltInFunctionSaveKeyValue:            (*builtInFuncCreator).estimateSaveKeyValue,
+	core.BuiltInFunctionESDTNFTCreate:           (*builtInFuncCreator).estimateNFTCreate,
+	core.BuiltInFunctionESDTNFTTransfer:         (*builtInFuncCreator).estimateNFTTransfer,
+	core.BuiltInFunctionMultiESDTNFTTransfer:    (*builtInFuncCreator).estimateMultiTransfer,
+	core.BuiltInFunctionESDTNFTAddURI:           (*builtInFuncCreator).estimateAddURI,
+	core.BuiltInFunctionESDTNFTUpdateAttributes: (*builtInFuncCreator).estimateUpdateAttributes,
+	core.ESDTMetaDataRecreate:                   (*builtInFuncCreator).estimateMetaDataRecreate,
+	core.ESDTMetaDataUpdate:                     (*builtInFuncCreator).estimateMetaDataUpdate,
+	core.ESDTSetNewURIs:                         (*builtInFuncCreator).estimateSetNewURIs,
+}
+
+// EstimateGas computes the gas the built-in function call would use with the active gas config, without executing
+// it. The base cost is the one charged by the function in the container. The current storage values are read when
+// the cost depends on them: the values overwritten by SaveKeyValue and the metadata replaced by the metadata update
+// functions. The arguments are only checked as far as needed to compute the estimate, so a successful estimate does
+// not guarantee a successful execution.
+func (b *builtInFuncCreator) EstimateGas(input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+
+	_, isBaseCostOnly := baseCostOnlyFunctions[input.Function]
+	handler, found := gasEstimationHandlers[input.Function]
+	if !isBaseCostOnly && !found {
+		return nil, fmt.Errorf("%w: %s", ErrGasEstimationNotSupported, input.Function)
+	}
+
+	baseCost, err := b.getFuncGasCost(input.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &GasEstimate{BaseCost: baseCost}
+	if !isBaseCostOnly {
+		gasCost := b.GasConfig()
+		estimate, err = handler(b, &gasCost, baseCost, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	estimate.FunctionName = input.Function
+	estimate.Total = estimate.BaseCost + estimate.StorageCost + estimate.DataCopyCost
+
+	return estimate, nil
+}
+
+func (b *builtInFuncCreator) getFuncGasCost(name string) (uint64, error) {
+	function, err := b.builtInFunctions.Get(name)
+	if err != nil {
+		return 0, err
+	}
+
+	gasCostHandler, ok := UnwrapBuiltinFunction(function).(FuncGasCostHandler)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrGasEstimationNotSupported, name)
+	}
+
+	return gasCostHandler.FuncGasCost(), nil
+}
+
+func (b *builtInFuncCreator) estimateSaveKeyValue(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	if len(input.Arguments) == 0 || len(input.Arguments)%2 != 0 {
+		return nil, ErrInvalidArguments
+	}
+
+	account, err := b.loadUserAccount(input.RecipientAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &GasEstimate{BaseCost: baseCost}
+	for i := 0; i < len(input.Arguments); i += 2 {
+		key := input.Arguments[i]
+		value := input.Arguments[i+1]
+		estimate.StorageCost += uint64(len(key)+len(value)) * gasCost.BaseOperationCost.PersistPerByte
+
+		oldValue, trieDepth, errRetrieve := account.AccountDataHandler().RetrieveValue(key)
+		if core.IsGetNodeFromDBError(errRetrieve) {
+			return nil, errRetrieve
+		}
+		estimate.DataTrieLoadCost += uint64(trieDepth) * gasCost.BuiltInCost.TrieLoadPerNode
+		if bytes.Equal(oldValue, value) {
+			continue
+		}
+		if len(oldValue) < len(value) {
+			estimate.StorageCost += uint64(len(value)-len(oldValue)) * gasCost.BaseOperationCost.StorePerByte
+		}
+	}
+
+	return estimate, nil
+}
+
+func (b *builtInFuncCreator) estimateNFTCreate(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	return &GasEstimate{
+		BaseCost:    baseCost,
+		StorageCost: uint64(lenArgs(input.Arguments)) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (b *builtInFuncCreator) estimateNFTTransfer(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	estimate := &GasEstimate{BaseCost: baseCost}
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		return estimate, nil
+	}
+	if len(input.Arguments) < core.MinLenArgumentsESDTNFTTransfer {
+		return nil, ErrInvalidArguments
+	}
+
+	account, err := b.loadUserAccount(input.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	value := big.NewInt(0).SetBytes(input.Arguments[2])
+	mustSendData := !b.wasTokenDataSent(input.Arguments[3]) || value.Cmp(oneValue) == 0
+	nonce := big.NewInt(0).SetBytes(input.Arguments[1]).Uint64()
+	estimate.DataCopyCost, err = b.computeDataCopyCost(gasCost, account, input.Arguments[0], nonce, value, mustSendData)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimate, nil
+}
+
+func (b *builtInFuncCreator) estimateMultiTransfer(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	isOnSenderShard := bytes.Equal(input.CallerAddr, input.RecipientAddr)
+	numOfTransfersIndex := 0
+	if isOnSenderShard {
+		numOfTransfersIndex = 1
+	}
+	if len(input.Arguments) <= numOfTransfersIndex {
+		return nil, ErrInvalidArguments
+	}
+
+	numOfTransfers := big.NewInt(0).SetBytes(input.Arguments[numOfTransfersIndex]).Uint64()
+	if numOfTransfers == 0 {
+		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+	}
+
+	estimate := &GasEstimate{BaseCost: numOfTransfers * baseCost}
+	if !isOnSenderShard {
+		return estimate, nil
+	}
+
+	startIndex := uint64(numOfTransfersIndex + 1)
+	if uint64(len(input.Arguments)) < startIndex+numOfTransfers*argumentsPerTransfer {
+		return nil, fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+	}
+
+	account, err := b.loadUserAccount(input.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	wasTokenDataSent := b.wasTokenDataSent(input.Arguments[0])
+	for i := uint64(0); i < numOfTransfers; i++ {
+		tokenStartIndex := startIndex + i*argumentsPerTransfer
+		nonce := big.NewInt(0).SetBytes(input.Arguments[tokenStartIndex+1]).Uint64()
+		value := big.NewInt(0).SetBytes(input.Arguments[tokenStartIndex+2])
+		mustSendData := !wasTokenDataSent || value.Cmp(oneValue) == 0 ||
+			len(value.Bytes()) > vmcommon.MaxLengthForValueToOptTransfer
+
+		dataCopyCost, errCompute := b.computeDataCopyCost(gasCost, account, input.Arguments[tokenStartIndex], nonce, value, mustSendData)
+		if errCompute != nil {
+			return nil, errCompute
+		}
+		estimate.DataCopyCost += dataCopyCost
+	}
+
+	return estimate, nil
+}
+
+// wasTokenDataSent mirrors WasAlreadySentToDestinationShardAndUpdateState without reading or updating the system
+// account, so a destination in another shard is always considered as not having the token data
+func (b *builtInFuncCreator) wasTokenDataSent(dstAddress []byte) bool {
+	if !b.enableEpochsHandler.IsFlagEnabled(SaveToSystemAccountFlag) {
+		return false
+	}
+
+	dstShardID := b.shardCoordinator.ComputeId(dstAddress)
+	if dstShardID == b.shardCoordinator.SelfId() {
+		return true
+	}
+	if b.enableEpochsHandler.IsFlagEnabled(SendAlwaysFlag) {
+		return false
+	}
+
+	return dstShardID == core.MetachainShardId
+}
+
+func (b *builtInFuncCreator) computeDataCopyCost(
+	gasCost *vmcommon.GasCost,
+	account vmcommon.UserAccountHandler,
+	tokenID []byte,
+	nonce uint64,
+	value *big.Int,
+	mustSendData bool,
+) (uint64, error) {
+	if nonce == 0 || !mustSendData {
+		return 0, nil
+	}
+	if check.IfNil(b.esdtStorageHandler) {
+		return 0, ErrNilESDTNFTStorageHandler
+	}
+
+	esdtTokenKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	esdtData, err := b.esdtStorageHandler.GetESDTNFTTokenOnSender(account, esdtTokenKey, nonce)
+	if err != nil {
+		return 0, err
+	}
+	esdtData.Value = value
+
+	marshaledData, err := b.marshaller.Marshal(esdtData)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(marshaledData)) * gasCost.BaseOperationCost.DataCopyPerByte, nil
+}
+
+func (b *builtInFuncCreator) estimateAddURI(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	if len(input.Arguments) < 3 {
+		return nil, ErrInvalidArguments
+	}
+
+	return &GasEstimate{
+		BaseCost:    baseCost,
+		StorageCost: uint64(lenArgs(input.Arguments[2:])) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (b *builtInFuncCreator) estimateUpdateAttributes(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	if len(input.Arguments) != 3 {
+		return nil, ErrInvalidArguments
+	}
+
+	return &GasEstimate{
+		BaseCost:    baseCost,
+		StorageCost: uint64(len(input.Arguments[2])) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (b *builtInFuncCreator) estimateMetaDataRecreate(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	esdtInfo, err := b.getCurrentESDTInfo(input, urisStartIndex+1)
+	if err != nil {
+		return nil, err
+	}
+
+	lengthDifference := lenArgs(input.Arguments) - esdtInfo.esdtData.TokenMetaData.Size()
+
+	return &GasEstimate{
+		BaseCost:    baseCost,
+		StorageCost: positiveLength(lengthDifference) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (b *builtInFuncCreator) estimateMetaDataUpdate(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	esdtInfo, err := b.getCurrentESDTInfo(input, urisStartIndex+1)
+	if err != nil {
+		return nil, err
+	}
+
+	metaData := esdtInfo.esdtData.TokenMetaData
+	lengthDifference := lenArgs(input.Arguments) - len(metaData.Creator)
+	if len(input.Arguments[nameIndex]) != 0 {
+		lengthDifference -= len(metaData.Name)
+	}
+	if len(input.Arguments[royaltiesIndex]) != 0 {
+		lengthDifference -= len(input.Arguments[royaltiesIndex])
+	}
+	if len(input.Arguments[hashIndex]) != 0 {
+		lengthDifference -= len(metaData.Hash)
+	}
+	if len(input.Arguments[attributesIndex]) != 0 {
+		lengthDifference -= len(metaData.Attributes)
+	}
+	if len(input.Arguments[urisStartIndex]) != 0 {
+		lengthDifference -= lenArgs(metaData.URIs)
+	}
+
+	return &GasEstimate{
+		BaseCost:    baseCost,
+		StorageCost: positiveLength(lengthDifference) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (b *builtInFuncCreator) estimateSetNewURIs(gasCost *vmcommon.GasCost, baseCost uint64, input *vmcommon.ContractCallInput) (*GasEstimate, error) {
+	esdtInfo, err := b.getCurrentESDTInfo(input, uriStartIndex+1)
+	if err != nil {
+		return nil, err
+	}
+
+	lengthDifference := lenArgs(input.Arguments[uriStartIndex:]) - lenArgs(esdtInfo.esdtData.TokenMetaData.URIs)
+
+	return &GasEstimate{
+		BaseCost:    baseCost,
+		StorageCost: positiveLength(lengthDifference) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+func (b *builtInFuncCreator) getCurrentESDTInfo(input *vmcommon.ContractCallInput, minNumOfArgs int) (*esdtStorageInfo, error) {
+	if len(input.Arguments) < minNumOfArgs {
+		return nil, ErrInvalidArguments
+	}
+	if check.IfNil(b.esdtStorageHandler) {
+		return nil, ErrNilESDTNFTStorageHandler
+	}
+	globalSettingsHandler, ok := b.esdtGlobalSettingsHandler.(vmcommon.GlobalMetadataHandler)
+	if !ok {
+		return nil, ErrNilGlobalSettingsHandler
+	}
+
+	account, err := b.loadUserAccount(input.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return getEsdtInfo(input, account, b.esdtStorageHandler, globalSettingsHandler)
+}
+
+func (b *builtInFuncCreator) loadUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := b.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+func positiveLength(length int) uint64 {
+	if length < 0 {
+		return 0
+	}
+
+	return uint64(length)
+}
@@ -0,0 +1,287 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/inMemory"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCreatorForGasEstimation(t *testing.T) (*builtInFuncCreator, vmcommon.AccountsAdapter) {
+	accounts := inMemory.NewAccountsAdapter()
+	args := createMockArguments()
+	args.Accounts = accounts
+	args.GasMap = createDistinctGasMap()
+
+	f, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	require.Nil(t, f.CreateBuiltInFunctionContainer())
+
+	return f, accounts
+}
+
+func createDistinctGasMap() map[string]map[string]uint64 {
+	gasMap := fillGasMapInternal(make(map[string]map[string]uint64), 10)
+	gasMap[core.BaseOperationCostString]["StorePerByte"] = 2
+	gasMap[core.BaseOperationCostString]["PersistPerByte"] = 1
+	builtInCostNames := make([]string, 0, len(gasMap[core.BuiltInCostString]))
+	for name := range gasMap[core.BuiltInCostString] {
+		builtInCostNames = append(builtInCostNames, name)
+	}
+	sort.Strings(builtInCostNames)
+	for i, name := range builtInCostNames {
+		gasMap[core.BuiltInCostString][name] = uint64(100 * (i + 1))
+	}
+
+	return gasMap
+}
+
+func TestBuiltInFuncCreator_EstimateGas(t *testing.T) {
+	t.Parallel()
+
+	caller := []byte("caller__________________________")
+	destination := []byte("destination_____________________")
+
+	t.Run("invalid input should error", func(t *testing.T) {
+		t.Parallel()
+
+		f, _ := createCreatorForGasEstimation(t)
+		_, err := f.EstimateGas(nil)
+		assert.Equal(t, ErrNilVmInput, err)
+
+		_, err = f.EstimateGas(&vmcommon.ContractCallInput{Function: core.BuiltInFunctionESDTPause})
+		assert.True(t, errors.Is(err, ErrGasEstimationNotSupported))
+
+		_, err = f.EstimateGas(&vmcommon.ContractCallInput{Function: core.BuiltInFunctionSaveKeyValue, VMInput: vmcommon.VMInput{Arguments: [][]byte{[]byte("key")}}})
+		assert.Equal(t, ErrInvalidArguments, err)
+	})
+	t.Run("base cost only", func(t *testing.T) {
+		t.Parallel()
+
+		f, _ := createCreatorForGasEstimation(t)
+		builtInCost := f.GasConfig().BuiltInCost
+		estimate, err := f.EstimateGas(&vmcommon.ContractCallInput{Function: core.BuiltInFunctionESDTTransfer})
+		require.Nil(t, err)
+		assert.Equal(t, &GasEstimate{FunctionName: core.BuiltInFunctionESDTTransfer, BaseCost: builtInCost.ESDTTransfer, Total: builtInCost.ESDTTransfer}, estimate)
+
+		estimate, err = f.EstimateGas(&vmcommon.ContractCallInput{Function: core.ESDTModifyCreator})
+		require.Nil(t, err)
+		assert.Equal(t, builtInCost.ESDTModifyCreator, estimate.BaseCost)
+
+		estimate, err = f.EstimateGas(&vmcommon.ContractCallInput{Function: core.ESDTModifyRoyalties})
+		require.Nil(t, err)
+		assert.Equal(t, builtInCost.ESDTModifyRoyalties, estimate.BaseCost)
+	})
+	t.Run("save key value should use the current storage", func(t *testing.T) {
+		t.Parallel()
+
+		f, accounts := createCreatorForGasEstimation(t)
+		baseCost := f.GasConfig().BuiltInCost.SaveKeyValue
+		account := inMemory.NewUserAccount(destination)
+		_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("old"))
+		require.Nil(t, accounts.SaveAccount(account))
+
+		estimate, err := f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: destination,
+				Arguments:  [][]byte{[]byte("key"), []byte("value"), []byte("new"), []byte("data")},
+			},
+			RecipientAddr: destination,
+			Function:      core.BuiltInFunctionSaveKeyValue,
+		})
+		require.Nil(t, err)
+		// persist: (3 + 5 + 3 + 4) * 1, store: (5 - 3) * 2 + 4 * 2
+		assert.Equal(t, &GasEstimate{FunctionName: core.BuiltInFunctionSaveKeyValue, BaseCost: baseCost, StorageCost: 27, Total: baseCost + 27}, estimate)
+	})
+	t.Run("save key value should not include the data trie load cost in the total", func(t *testing.T) {
+		t.Parallel()
+
+		f, _ := createCreatorForGasEstimation(t)
+		builtInCost := f.GasConfig().BuiltInCost
+		f.accounts = &mock.AccountsStub{
+			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+				return &mock.UserAccountStub{
+					AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
+						return &mock.DataTrieTrackerStub{
+							RetrieveValueCalled: func(key []byte) ([]byte, uint32, error) {
+								return []byte("value"), 3, nil
+							},
+						}
+					},
+				}, nil
+			},
+		}
+
+		estimate, err := f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: destination,
+				Arguments:  [][]byte{[]byte("key"), []byte("value")},
+			},
+			RecipientAddr: destination,
+			Function:      core.BuiltInFunctionSaveKeyValue,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 3*builtInCost.TrieLoadPerNode, estimate.DataTrieLoadCost)
+		assert.Equal(t, builtInCost.SaveKeyValue+8, estimate.Total)
+	})
+	t.Run("NFT create should use the arguments length", func(t *testing.T) {
+		t.Parallel()
+
+		f, _ := createCreatorForGasEstimation(t)
+		estimate, err := f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("TOKEN-abcdef"), {1}, []byte("name"), {10}, []byte("hash"), []byte("attr"), []byte("uri")}},
+			Function: core.BuiltInFunctionESDTNFTCreate,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, f.GasConfig().BuiltInCost.ESDTNFTCreate, estimate.BaseCost)
+		assert.Equal(t, uint64(29*2), estimate.StorageCost)
+	})
+	t.Run("multi transfer should multiply the base cost", func(t *testing.T) {
+		t.Parallel()
+
+		f, _ := createCreatorForGasEstimation(t)
+		multiTransferCost := f.GasConfig().BuiltInCost.ESDTNFTMultiTransfer
+		estimate, err := f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: caller,
+				Arguments: [][]byte{
+					destination, {3},
+					[]byte("TKA-abcdef"), {}, {1},
+					[]byte("TKB-abcdef"), {}, {2},
+					[]byte("TKC-abcdef"), {}, {3},
+				},
+			},
+			RecipientAddr: caller,
+			Function:      core.BuiltInFunctionMultiESDTNFTTransfer,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 3*multiTransferCost, estimate.Total)
+
+		estimate, err = f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput:       vmcommon.VMInput{CallerAddr: caller, Arguments: [][]byte{{2}}},
+			RecipientAddr: destination,
+			Function:      core.BuiltInFunctionMultiESDTNFTTransfer,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 2*multiTransferCost, estimate.Total)
+
+		_, err = f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput:       vmcommon.VMInput{CallerAddr: caller, Arguments: [][]byte{{0}}},
+			RecipientAddr: destination,
+			Function:      core.BuiltInFunctionMultiESDTNFTTransfer,
+		})
+		assert.True(t, errors.Is(err, ErrInvalidArguments))
+	})
+	t.Run("set new URIs should use the current metadata", func(t *testing.T) {
+		t.Parallel()
+
+		f, accounts := createCreatorForGasEstimation(t)
+		tokenID := []byte("TOKEN-abcdef")
+		account := inMemory.NewUserAccount(caller)
+		_, err := f.esdtStorageHandler.SaveESDTNFTToken(caller, account, append([]byte(baseESDTKeyPrefix), tokenID...), 1, &esdt.ESDigitalToken{
+			Value:         big.NewInt(1),
+			TokenMetaData: &esdt.MetaData{Nonce: 1, URIs: [][]byte{[]byte("uri")}},
+		}, vmcommon.NftSaveArgs{MustUpdateAllFields: true, IsReturnWithError: true})
+		require.Nil(t, err)
+		require.Nil(t, accounts.SaveAccount(account))
+
+		estimate, err := f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput:  vmcommon.VMInput{CallerAddr: caller, Arguments: [][]byte{tokenID, {1}, []byte("longer uri")}},
+			Function: core.ESDTSetNewURIs,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, f.GasConfig().BuiltInCost.ESDTNFTSetNewURIs, estimate.BaseCost)
+		assert.Equal(t, uint64((10-3)*2), estimate.StorageCost)
+
+		_, err = f.EstimateGas(&vmcommon.ContractCallInput{
+			VMInput:  vmcommon.VMInput{CallerAddr: caller, Arguments: [][]byte{tokenID, {2}, []byte("uri")}},
+			Function: core.ESDTSetNewURIs,
+		})
+		assert.Equal(t, ErrNilESDTData, err)
+	})
+}
+
+func TestBuiltInFuncCreator_EstimateGasShouldMatchTheGasUsed(t *testing.T) {
+	t.Parallel()
+
+	accounts := inMemory.NewAccountsAdapter()
+	args := createMockArguments()
+	args.Accounts = accounts
+	args.GasMap = createDistinctGasMap()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return true
+		},
+	}
+	f, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	require.Nil(t, f.CreateBuiltInFunctionContainer())
+	require.Nil(t, f.SetPayableHandler(&mock.PayableHandlerStub{}))
+	require.Nil(t, f.SetBlockchainHook(&disabledBlockchainHook{}))
+
+	caller := bytes.Repeat([]byte{1}, 32)
+	destination := bytes.Repeat([]byte{2}, 32)
+	tokenID := []byte("TOKEN-abcdef")
+	roles, _ := f.marshaller.Marshal(&esdt.ESDTRoles{Roles: [][]byte{
+		[]byte(core.ESDTRoleNFTCreate),
+		[]byte(core.ESDTRoleNFTAddQuantity),
+		[]byte(core.ESDTRoleNFTAddURI),
+		[]byte(core.ESDTRoleNFTUpdateAttributes),
+		[]byte(core.ESDTRoleNFTRecreate),
+		[]byte(core.ESDTRoleNFTUpdate),
+		[]byte(core.ESDTRoleSetNewURI),
+	}})
+	account := inMemory.NewUserAccount(caller)
+	require.Nil(t, account.AccountDataHandler().SaveKeyValue(append(append([]byte{}, roleKeyPrefix...), tokenID...), roles))
+	require.Nil(t, accounts.SaveAccount(account))
+
+	nonce := []byte{1}
+	testData := []struct {
+		function  string
+		arguments [][]byte
+	}{
+		{function: core.BuiltInFunctionESDTNFTCreate, arguments: [][]byte{tokenID, {2}, []byte("name"), {10}, []byte("hash"), []byte("attributes"), []byte("uri")}},
+		{function: core.BuiltInFunctionESDTNFTAddURI, arguments: [][]byte{tokenID, nonce, []byte("second uri")}},
+		{function: core.BuiltInFunctionESDTNFTUpdateAttributes, arguments: [][]byte{tokenID, nonce, []byte("new attributes")}},
+		{function: core.ESDTMetaDataRecreate, arguments: [][]byte{tokenID, nonce, []byte("recreated name"), {20}, []byte("hash"), []byte("attributes"), []byte("uri")}},
+		{function: core.ESDTMetaDataUpdate, arguments: [][]byte{tokenID, nonce, []byte("updated name"), {}, []byte("longer hash"), {}, []byte("uri")}},
+		{function: core.ESDTSetNewURIs, arguments: [][]byte{tokenID, nonce, []byte("first uri"), []byte("second uri")}},
+		{function: core.BuiltInFunctionSaveKeyValue, arguments: [][]byte{[]byte("key"), []byte("value")}},
+		{function: core.BuiltInFunctionESDTNFTTransfer, arguments: [][]byte{tokenID, nonce, {1}, destination}},
+		{function: core.BuiltInFunctionMultiESDTNFTTransfer, arguments: [][]byte{destination, {1}, tokenID, nonce, {1}}},
+	}
+	require.Len(t, testData, len(gasEstimationHandlers))
+
+	for _, td := range testData {
+		input := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  caller,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1_000_000,
+				Arguments:   td.arguments,
+			},
+			RecipientAddr: caller,
+			Function:      td.function,
+		}
+
+		estimate, errEstimate := f.EstimateGas(input)
+		require.Nil(t, errEstimate, td.function)
+
+		function, _ := f.BuiltInFunctionContainer().Get(td.function)
+		sender, _ := accounts.LoadAccount(caller)
+		vmOutput, errProcess := function.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), sender.(vmcommon.UserAccountHandler), input)
+		require.Nil(t, errProcess, td.function)
+		require.Nil(t, accounts.SaveAccount(sender), td.function)
+
+		assert.Equal(t, input.GasProvided-vmOutput.GasRemaining, estimate.Total, td.function)
+	}
+}
@@ -0,0 +1,123 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var _ ExecutionMetricsSink = (*inMemoryMetricsSink)(nil)
+
+// DefaultGasUsedBuckets are the upper bounds of the gas used histogram buckets used when none are provided
+var DefaultGasUsedBuckets = []uint64{1_000, 10_000, 100_000, 1_000_000, 10_000_000, 100_000_000}
+
+// Histogram counts the values that fall in each bucket. Counts[i] holds the values lower than or equal to
+// Buckets[i] and greater than the previous bound, the last element of Counts holds the values above all the bounds.
+type Histogram struct {
+	Buckets []uint64
+	Counts  []uint64
+	Sum     uint64
+}
+
+// FunctionMetrics holds the metrics collected for one built-in function
+type FunctionMetrics struct {
+	Calls               uint64
+	Successes           uint64
+	Errors              uint64
+	ErrorsByKind        map[string]uint64
+	GasUsed             Histogram
+	StorageBytesTouched uint64
+}
+
+type inMemoryMetricsSink struct {
+	mut            sync.RWMutex
+	gasUsedBuckets []uint64
+	metrics        map[string]*FunctionMetrics
+}
+
+// NewInMemoryMetricsSink creates an execution metrics sink that aggregates the records by function name. The gas
+// used buckets must be strictly increasing, DefaultGasUsedBuckets are used if none are provided.
+func NewInMemoryMetricsSink(gasUsedBuckets []uint64) (*inMemoryMetricsSink, error) {
+	if len(gasUsedBuckets) == 0 {
+		gasUsedBuckets = DefaultGasUsedBuckets
+	}
+	for i := 1; i < len(gasUsedBuckets); i++ {
+		if gasUsedBuckets[i] <= gasUsedBuckets[i-1] {
+			return nil, fmt.Errorf("%w: bucket %d is not greater than the previous one", ErrInvalidHistogramBuckets, i)
+		}
+	}
+
+	return &inMemoryMetricsSink{
+		gasUsedBuckets: append([]uint64(nil), gasUsedBuckets...),
+		metrics:        make(map[string]*FunctionMetrics),
+	}, nil
+}
+
+// RecordExecution adds the record to the metrics of its function
+func (sink *inMemoryMetricsSink) RecordExecution(record *ExecutionRecord) {
+	if record == nil {
+		return
+	}
+
+	sink.mut.Lock()
+	defer sink.mut.Unlock()
+
+	functionMetrics, ok := sink.metrics[record.FunctionName]
+	if !ok {
+		functionMetrics = &FunctionMetrics{
+			ErrorsByKind: make(map[string]uint64),
+			GasUsed: Histogram{
+				Buckets: sink.gasUsedBuckets,
+				Counts:  make([]uint64, len(sink.gasUsedBuckets)+1),
+			},
+		}
+		sink.metrics[record.FunctionName] = functionMetrics
+	}
+
+	functionMetrics.Calls++
+	if len(record.ErrorKind) == 0 {
+		functionMetrics.Successes++
+	} else {
+		functionMetrics.Errors++
+		functionMetrics.ErrorsByKind[record.ErrorKind]++
+	}
+
+	bucket := sort.Search(len(sink.gasUsedBuckets), func(i int) bool {
+		return sink.gasUsedBuckets[i] >= record.GasUsed
+	})
+	functionMetrics.GasUsed.Counts[bucket]++
+	functionMetrics.GasUsed.Sum += record.GasUsed
+	functionMetrics.StorageBytesTouched += record.StorageBytesTouched
+}
+
+// Snapshot returns a copy of the metrics collected so far, keyed by function name
+func (sink *inMemoryMetricsSink) Snapshot() map[string]FunctionMetrics {
+	sink.mut.RLock()
+	defer sink.mut.RUnlock()
+
+	snapshot := make(map[string]FunctionMetrics, len(sink.metrics))
+	for name, functionMetrics := range sink.metrics {
+		copied := *functionMetrics
+		copied.ErrorsByKind = make(map[string]uint64, len(functionMetrics.ErrorsByKind))
+		for kind, count := range functionMetrics.ErrorsByKind {
+			copied.ErrorsByKind[kind] = count
+		}
+		copied.GasUsed.Buckets = append([]uint64(nil), functionMetrics.GasUsed.Buckets...)
+		copied.GasUsed.Counts = append([]uint64(nil), functionMetrics.GasUsed.Counts...)
+		snapshot[name] = copied
+	}
+
+	return snapshot
+}
+
+// Reset removes all the collected metrics
+func (sink *inMemoryMetricsSink) Reset() {
+	sink.mut.Lock()
+	sink.metrics = make(map[string]*FunctionMetrics)
+	sink.mut.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sink *inMemoryMetricsSink) IsInterfaceNil() bool {
+	return sink == nil
+}
@@ -0,0 +1,78 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInMemoryMetricsSink(t *testing.T) {
+	t.Parallel()
+
+	sink, err := NewInMemoryMetricsSink([]uint64{10, 10})
+	assert.Nil(t, sink)
+	assert.True(t, errors.Is(err, ErrInvalidHistogramBuckets))
+
+	sink, err = NewInMemoryMetricsSink(nil)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(sink))
+	assert.Equal(t, DefaultGasUsedBuckets, sink.gasUsedBuckets)
+}
+
+func TestInMemoryMetricsSink_RecordExecution(t *testing.T) {
+	t.Parallel()
+
+	sink, _ := NewInMemoryMetricsSink([]uint64{10, 100})
+	sink.RecordExecution(nil)
+	sink.RecordExecution(&ExecutionRecord{FunctionName: "a", GasUsed: 10, StorageBytesTouched: 3})
+	sink.RecordExecution(&ExecutionRecord{FunctionName: "a", GasUsed: 11, ErrorKind: "NOT_ENOUGH_GAS"})
+	sink.RecordExecution(&ExecutionRecord{FunctionName: "a", GasUsed: 1000, ErrorKind: "NOT_ENOUGH_GAS"})
+	sink.RecordExecution(&ExecutionRecord{FunctionName: "b", GasUsed: 5, StorageBytesTouched: 4})
+
+	snapshot := sink.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, FunctionMetrics{
+		Calls:               3,
+		Successes:           1,
+		Errors:              2,
+		ErrorsByKind:        map[string]uint64{"NOT_ENOUGH_GAS": 2},
+		GasUsed:             Histogram{Buckets: []uint64{10, 100}, Counts: []uint64{1, 1, 1}, Sum: 1021},
+		StorageBytesTouched: 3,
+	}, snapshot["a"])
+	assert.Equal(t, uint64(1), snapshot["b"].Successes)
+
+	snapshot["a"].ErrorsByKind["NOT_ENOUGH_GAS"] = 0
+	snapshot["a"].GasUsed.Counts[0] = 0
+	assert.Equal(t, uint64(2), sink.Snapshot()["a"].ErrorsByKind["NOT_ENOUGH_GAS"], "the snapshot should be a copy")
+	assert.Equal(t, uint64(1), sink.Snapshot()["a"].GasUsed.Counts[0], "the snapshot should be a copy")
+
+	sink.Reset()
+	assert.Empty(t, sink.Snapshot())
+}
+
+func TestInMemoryMetricsSink_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	sink, _ := NewInMemoryMetricsSink(nil)
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			if idx%2 == 0 {
+				sink.RecordExecution(&ExecutionRecord{FunctionName: "a"})
+				return
+			}
+			_ = sink.Snapshot()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(numCalls/2), sink.Snapshot()["a"].Calls)
+}
@@ -1 +1,44 @@
 package builtInFunctions
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ExecutionInterceptor wraps the execution of the built-in functions of a container. It can inspect or change the
+// call before calling next, inspect or change the result after, or return without calling next at all.
+type ExecutionInterceptor interface {
+	Intercept(call *BuiltInFunctionCall, next ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error)
+	IsInterfaceNil() bool
+}
+
+type executionInterceptorsHolder interface {
+	AddExecutionInterceptor(interceptor ExecutionInterceptor) error
+}
+
+// ExecutionMetricsSink receives one record for every execution of a built-in function
+type ExecutionMetricsSink interface {
+	RecordExecution(record *ExecutionRecord)
+	IsInterfaceNil() bool
+}
+
+// FuncGasCostHandler is implemented by the built-in functions that charge a base gas cost
+type FuncGasCostHandler interface {
+	FuncGasCost() uint64
+}
+
+// ActivationFlagHandler is implemented by the built-in functions gated by an enable epoch flag
+type ActivationFlagHandler interface {
+	// ActivationFlag returns the flag that gates the function, or an empty flag if the function is always active.
+	// The function is active while the flag is enabled.
+	ActivationFlag() core.EnableEpochFlag
+	// IsDeactivationFlag returns true if the flag is enabled until its epoch instead of from it, so the epoch of the
+	// flag is the one from which the function is no longer active
+	IsDeactivationFlag() bool
+}
+
+// ArgumentsSchemaHandler is implemented by the functions that publish the schema of their arguments themselves. The
+// functions of this package are described by GetFunctionSchema.
+type ArgumentsSchemaHandler interface {
+	ArgumentsSchema() *FunctionSchema
+}
@@ -50,6 +50,14 @@ func (k *saveKeyValueStorage) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	k.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (k *saveKeyValueStorage) FuncGasCost() uint64 {
+	k.mutExecution.RLock()
+	defer k.mutExecution.RUnlock()
+
+	return k.funcGasCost
+}
+
 // ProcessBuiltinFunction will save the value for the selected key
 func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 	_, acntDest vmcommon.UserAccountHandler,
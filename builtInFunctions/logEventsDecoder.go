@@ -0,0 +1,627 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const numTopicsPerToken = 3
+
+// LogEvent defines the typed form of a log entry emitted by a built-in function
+type LogEvent interface {
+	EventIdentifier() string
+}
+
+// BaseLogEvent holds the identifier and the address of the decoded log entry
+type BaseLogEvent struct {
+	Identifier string
+	Address    []byte
+}
+
+// EventIdentifier returns the identifier of the log entry, which is the name of the built-in function
+func (event *BaseLogEvent) EventIdentifier() string {
+	return event.Identifier
+}
+
+// ESDTTransferEvent is emitted by ESDTTransfer and ESDTNFTTransfer. Address is the sender.
+type ESDTTransferEvent struct {
+	BaseLogEvent
+	Token    []byte
+	Nonce    uint64
+	Value    *big.Int
+	Receiver []byte
+}
+
+// MultiESDTTransferEvent is emitted by MultiESDTNFTTransfer. Address is the sender. Before ScToScLogEventFlag a
+// separate log entry was emitted for each token, so the event holds a single transfer. Afterward, one log entry
+// holds all the transfers.
+type MultiESDTTransferEvent struct {
+	BaseLogEvent
+	Transfers []*TopicTokenData
+	Receiver  []byte
+}
+
+// ESDTQuantityEvent is emitted by the functions that change the supply of a token: ESDTBurn, ESDTLocalBurn,
+// ESDTLocalMint, ESDTNFTBurn and ESDTNFTAddQuantity. Address is the caller.
+type ESDTQuantityEvent struct {
+	BaseLogEvent
+	Token []byte
+	Nonce uint64
+	Value *big.Int
+}
+
+// NFTCreateEvent is emitted by ESDTNFTCreate. Address is the creator.
+type NFTCreateEvent struct {
+	BaseLogEvent
+	Token    []byte
+	Nonce    uint64
+	Quantity *big.Int
+	ESDTData *esdt.ESDigitalToken
+}
+
+// ESDTMetaDataEvent is emitted by ESDTMetaDataRecreate and ESDTMetaDataUpdate. Address is the caller.
+type ESDTMetaDataEvent struct {
+	BaseLogEvent
+	Token    []byte
+	Nonce    uint64
+	ESDTData *esdt.ESDigitalToken
+}
+
+// NFTUpdateAttributesEvent is emitted by ESDTNFTUpdateAttributes. Address is the caller.
+type NFTUpdateAttributesEvent struct {
+	BaseLogEvent
+	Token      []byte
+	Nonce      uint64
+	Attributes []byte
+}
+
+// NFTURIsEvent is emitted by ESDTNFTAddURI and ESDTSetNewURIs. Address is the caller.
+type NFTURIsEvent struct {
+	BaseLogEvent
+	Token []byte
+	Nonce uint64
+	URIs  [][]byte
+}
+
+// ModifyRoyaltiesEvent is emitted by ESDTModifyRoyalties. Address is the caller.
+type ModifyRoyaltiesEvent struct {
+	BaseLogEvent
+	Token     []byte
+	Nonce     uint64
+	Royalties uint32
+}
+
+// ModifyCreatorEvent is emitted by ESDTModifyCreator. Address is the caller, which becomes the new creator.
+type ModifyCreatorEvent struct {
+	BaseLogEvent
+	Token []byte
+	Nonce uint64
+}
+
+// FreezeEvent is emitted by ESDTFreeze and ESDTUnFreeze. Address is the caller.
+type FreezeEvent struct {
+	BaseLogEvent
+	Token   []byte
+	Nonce   uint64
+	Balance *big.Int
+	Account []byte
+	Frozen  bool
+}
+
+// WipeEvent is emitted by ESDTWipe. Address is the caller.
+type WipeEvent struct {
+	BaseLogEvent
+	Token       []byte
+	Nonce       uint64
+	WipedAmount *big.Int
+	Account     []byte
+}
+
+// ESDTRolesEvent is emitted by ESDTSetRole and ESDTUnSetRole. Address is the account whose roles were changed.
+type ESDTRolesEvent struct {
+	BaseLogEvent
+	Token []byte
+	Roles [][]byte
+	Set   bool
+}
+
+// TransferRoleAddressesEvent is emitted by ESDTTransferRoleAddAddress and ESDTTransferRoleDeleteAddress.
+// Address is the system account.
+type TransferRoleAddressesEvent struct {
+	BaseLogEvent
+	Token     []byte
+	Addresses [][]byte
+	Added     bool
+}
+
+// NFTCreateRoleTransferEvent is emitted by ESDTNFTCreateRoleTransfer, once for the account that lost the role
+// and once for the account that received it
+type NFTCreateRoleTransferEvent struct {
+	BaseLogEvent
+	Token   []byte
+	Account []byte
+	HasRole bool
+}
+
+// ChangeOwnerAddressEvent is emitted by ChangeOwnerAddress. Address is the smart contract.
+type ChangeOwnerAddressEvent struct {
+	BaseLogEvent
+	NewOwner []byte
+}
+
+// ClaimDeveloperRewardsEvent is emitted by ClaimDeveloperRewards. Address is the smart contract.
+type ClaimDeveloperRewardsEvent struct {
+	BaseLogEvent
+	Value     *big.Int
+	Developer []byte
+}
+
+// SetUserNameEvent is emitted by SetUserName. Address is the account, OldUserName is empty if the account had no
+// username before.
+type SetUserNameEvent struct {
+	BaseLogEvent
+	OldUserName []byte
+}
+
+// DeleteUserNameEvent is emitted by DeleteUserName. Address is the account.
+type DeleteUserNameEvent struct {
+	BaseLogEvent
+	OldUserName []byte
+}
+
+// SetGuardianEvent is emitted by SetGuardian. Address is the guarded account.
+type SetGuardianEvent struct {
+	BaseLogEvent
+	Guardian   []byte
+	ServiceUID []byte
+}
+
+// GuardAccountEvent is emitted by GuardAccount and UnGuardAccount. Address is the account.
+type GuardAccountEvent struct {
+	BaseLogEvent
+	Guarded bool
+}
+
+type logEventDecodeFunc func(logEntry *vmcommon.LogEntry) (LogEvent, error)
+
+type logEventsDecoder struct {
+	marshaller vmcommon.Marshalizer
+	decoders   map[string]logEventDecodeFunc
+}
+
+// NewLogEventsDecoder creates a component able to decode the log entries emitted by the built-in functions
+func NewLogEventsDecoder(marshaller vmcommon.Marshalizer) (*logEventsDecoder, error) {
+	if check.IfNil(marshaller) {
+		return nil, ErrNilMarshalizer
+	}
+
+	d := &logEventsDecoder{
+		marshaller: marshaller,
+	}
+	d.decoders = map[string]logEventDecodeFunc{
+		core.BuiltInFunctionESDTTransfer:                      d.decodeESDTTransfer,
+		core.BuiltInFunctionESDTNFTTransfer:                   d.decodeESDTTransfer,
+		core.BuiltInFunctionMultiESDTNFTTransfer:              d.decodeMultiESDTTransfer,
+		core.BuiltInFunctionESDTBurn:                          d.decodeESDTQuantity,
+		core.BuiltInFunctionESDTLocalBurn:                     d.decodeESDTQuantity,
+		core.BuiltInFunctionESDTLocalMint:                     d.decodeESDTQuantity,
+		core.BuiltInFunctionESDTNFTBurn:                       d.decodeESDTQuantity,
+		core.BuiltInFunctionESDTNFTAddQuantity:                d.decodeESDTQuantity,
+		core.BuiltInFunctionESDTNFTCreate:                     d.decodeNFTCreate,
+		core.ESDTMetaDataRecreate:                             d.decodeESDTMetaData,
+		core.ESDTMetaDataUpdate:                               d.decodeESDTMetaData,
+		core.BuiltInFunctionESDTNFTUpdateAttributes:           d.decodeNFTUpdateAttributes,
+		core.BuiltInFunctionESDTNFTAddURI:                     d.decodeNFTURIs,
+		core.ESDTSetNewURIs:                                   d.decodeNFTURIs,
+		core.ESDTModifyRoyalties:                              d.decodeModifyRoyalties,
+		core.ESDTModifyCreator:                                d.decodeModifyCreator,
+		core.BuiltInFunctionESDTFreeze:                        d.decodeFreeze,
+		core.BuiltInFunctionESDTUnFreeze:                      d.decodeFreeze,
+		core.BuiltInFunctionESDTWipe:                          d.decodeWipe,
+		core.BuiltInFunctionSetESDTRole:                       d.decodeESDTRoles,
+		core.BuiltInFunctionUnSetESDTRole:                     d.decodeESDTRoles,
+		vmcommon.BuiltInFunctionESDTTransferRoleAddAddress:    d.decodeTransferRoleAddresses,
+		vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress: d.decodeTransferRoleAddresses,
+		core.BuiltInFunctionESDTNFTCreateRoleTransfer:         d.decodeNFTCreateRoleTransfer,
+		core.BuiltInFunctionChangeOwnerAddress:                d.decodeChangeOwnerAddress,
+		core.BuiltInFunctionClaimDeveloperRewards:             d.decodeClaimDeveloperRewards,
+		core.BuiltInFunctionSetUserName:                       d.decodeSetUserName,
+		deleteUserNameFuncName:                                d.decodeDeleteUserName,
+		core.BuiltInFunctionSetGuardian:                       d.decodeSetGuardian,
+		core.BuiltInFunctionGuardAccount:                      d.decodeGuardAccount,
+		core.BuiltInFunctionUnGuardAccount:                    d.decodeGuardAccount,
+	}
+
+	return d, nil
+}
+
+// Decode returns the typed event of a log entry emitted by a built-in function
+func (d *logEventsDecoder) Decode(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	if logEntry == nil {
+		return nil, ErrNilLogEntry
+	}
+
+	decode, found := d.decoders[string(logEntry.Identifier)]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLogEvent, logEntry.Identifier)
+	}
+
+	return decode(logEntry)
+}
+
+// DecodeLogs returns the typed events of the provided log entries emitted by built-in functions, in the same order.
+// The other log entries, like the ones emitted by smart contracts, are skipped.
+func (d *logEventsDecoder) DecodeLogs(logEntries []*vmcommon.LogEntry) ([]LogEvent, error) {
+	events := make([]LogEvent, 0, len(logEntries))
+	for i, logEntry := range logEntries {
+		if logEntry != nil && !d.isBuiltInLogEntry(logEntry) {
+			continue
+		}
+
+		event, err := d.Decode(logEntry)
+		if err != nil {
+			return nil, fmt.Errorf("%w for log entry %d", err, i)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (d *logEventsDecoder) isBuiltInLogEntry(logEntry *vmcommon.LogEntry) bool {
+	_, found := d.decoders[string(logEntry.Identifier)]
+	return found
+}
+
+func newBaseLogEvent(logEntry *vmcommon.LogEntry) BaseLogEvent {
+	return BaseLogEvent{
+		Identifier: string(logEntry.Identifier),
+		Address:    logEntry.Address,
+	}
+}
+
+func checkNumTopics(logEntry *vmcommon.LogEntry, minNumTopics int, maxNumTopics int) error {
+	numTopics := len(logEntry.Topics)
+	if numTopics < minNumTopics || (maxNumTopics >= 0 && numTopics > maxNumTopics) {
+		return fmt.Errorf("%w: %s has %d topics", ErrInvalidLogEventTopics, logEntry.Identifier, numTopics)
+	}
+
+	return nil
+}
+
+func bytesToUint64(value []byte) uint64 {
+	return big.NewInt(0).SetBytes(value).Uint64()
+}
+
+func bytesToBigInt(value []byte) *big.Int {
+	return big.NewInt(0).SetBytes(value)
+}
+
+func (d *logEventsDecoder) decodeESDTData(logEntry *vmcommon.LogEntry, esdtDataBytes []byte) (*esdt.ESDigitalToken, error) {
+	// the log entry is emitted without the ESDT data if it could not be marshalled
+	if len(esdtDataBytes) == 0 {
+		return nil, nil
+	}
+
+	esdtData := &esdt.ESDigitalToken{}
+	err := d.marshaller.Unmarshal(esdtData, esdtDataBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s for %s", ErrInvalidLogEventTopics, err.Error(), logEntry.Identifier)
+	}
+
+	return esdtData, nil
+}
+
+func (d *logEventsDecoder) decodeESDTTransfer(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTTransferEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		Value:        bytesToBigInt(logEntry.Topics[2]),
+		Receiver:     logEntry.Topics[3],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeMultiESDTTransfer(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	numTokenTopics := len(logEntry.Topics) - 1
+	if numTokenTopics%numTopicsPerToken != 0 {
+		return nil, fmt.Errorf("%w: %s has %d topics", ErrInvalidLogEventTopics, logEntry.Identifier, len(logEntry.Topics))
+	}
+
+	transfers := make([]*TopicTokenData, 0, numTokenTopics/numTopicsPerToken)
+	for i := 0; i < numTokenTopics; i += numTopicsPerToken {
+		transfers = append(transfers, &TopicTokenData{
+			TokenID: logEntry.Topics[i],
+			Nonce:   bytesToUint64(logEntry.Topics[i+1]),
+			Value:   bytesToBigInt(logEntry.Topics[i+2]),
+		})
+	}
+
+	return &MultiESDTTransferEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Transfers:    transfers,
+		Receiver:     logEntry.Topics[numTokenTopics],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeESDTQuantity(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken, numTopicsPerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTQuantityEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		Value:        bytesToBigInt(logEntry.Topics[2]),
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeNFTCreate(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	esdtData, err := d.decodeESDTData(logEntry, logEntry.Topics[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTCreateEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		Quantity:     bytesToBigInt(logEntry.Topics[2]),
+		ESDTData:     esdtData,
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeESDTMetaData(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	esdtData, err := d.decodeESDTData(logEntry, logEntry.Topics[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTMetaDataEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		ESDTData:     esdtData,
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeNFTUpdateAttributes(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTUpdateAttributesEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		Attributes:   logEntry.Topics[3],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeNFTURIs(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTURIsEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		URIs:         logEntry.Topics[numTopicsPerToken:],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeModifyRoyalties(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModifyRoyaltiesEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		Royalties:    uint32(bytesToUint64(logEntry.Topics[3])),
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeModifyCreator(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken, numTopicsPerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModifyCreatorEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeFreeze(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FreezeEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		Balance:      bytesToBigInt(logEntry.Topics[2]),
+		Account:      logEntry.Topics[3],
+		Frozen:       string(logEntry.Identifier) == core.BuiltInFunctionESDTFreeze,
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeWipe(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WipeEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Nonce:        bytesToUint64(logEntry.Topics[1]),
+		WipedAmount:  bytesToBigInt(logEntry.Topics[2]),
+		Account:      logEntry.Topics[3],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeESDTRoles(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTRolesEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Roles:        logEntry.Topics[numTopicsPerToken:],
+		Set:          string(logEntry.Identifier) == core.BuiltInFunctionSetESDTRole,
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeTransferRoleAddresses(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferRoleAddressesEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Addresses:    logEntry.Topics[numTopicsPerToken:],
+		Added:        string(logEntry.Identifier) == vmcommon.BuiltInFunctionESDTTransferRoleAddAddress,
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeNFTCreateRoleTransfer(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, numTopicsPerToken+1, numTopicsPerToken+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasRole, err := strconv.ParseBool(string(logEntry.Topics[3]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s for %s", ErrInvalidLogEventTopics, err.Error(), logEntry.Identifier)
+	}
+
+	return &NFTCreateRoleTransferEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Token:        logEntry.Topics[0],
+		Account:      logEntry.Address,
+		HasRole:      hasRole,
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeChangeOwnerAddress(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeOwnerAddressEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		NewOwner:     logEntry.Topics[0],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeClaimDeveloperRewards(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClaimDeveloperRewardsEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Value:        bytesToBigInt(logEntry.Topics[0]),
+		Developer:    logEntry.Topics[1],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeSetUserName(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetUserNameEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		OldUserName:  logEntry.Topics[0],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeDeleteUserName(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteUserNameEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		OldUserName:  logEntry.Topics[0],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeSetGuardian(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetGuardianEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Guardian:     logEntry.Topics[0],
+		ServiceUID:   logEntry.Topics[1],
+	}, nil
+}
+
+func (d *logEventsDecoder) decodeGuardAccount(logEntry *vmcommon.LogEntry) (LogEvent, error) {
+	err := checkNumTopics(logEntry, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GuardAccountEvent{
+		BaseLogEvent: newBaseLogEvent(logEntry),
+		Guarded:      string(logEntry.Identifier) == core.BuiltInFunctionGuardAccount,
+	}, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *logEventsDecoder) IsInterfaceNil() bool {
+	return d == nil
+}
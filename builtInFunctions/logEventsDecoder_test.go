@@ -0,0 +1,320 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLogEventsDecoder(t *testing.T) *logEventsDecoder {
+	decoder, err := NewLogEventsDecoder(&mock.MarshalizerMock{})
+	require.Nil(t, err)
+
+	return decoder
+}
+
+func TestNewLogEventsDecoder(t *testing.T) {
+	t.Parallel()
+
+	decoder, err := NewLogEventsDecoder(nil)
+	assert.Nil(t, decoder)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	decoder, err = NewLogEventsDecoder(&mock.MarshalizerMock{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(decoder))
+}
+
+func TestLogEventsDecoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	decoder := createLogEventsDecoder(t)
+	caller := []byte("caller")
+	receiver := []byte("receiver")
+	token := []byte("TOKEN-abcdef")
+
+	t.Run("nil and unknown log entries should error", func(t *testing.T) {
+		t.Parallel()
+
+		event, err := decoder.Decode(nil)
+		assert.Nil(t, event)
+		assert.Equal(t, ErrNilLogEntry, err)
+
+		event, err = decoder.Decode(&vmcommon.LogEntry{Identifier: []byte("writeLog")})
+		assert.Nil(t, event)
+		assert.True(t, errors.Is(err, ErrUnknownLogEvent))
+	})
+	t.Run("wrong number of topics should error", func(t *testing.T) {
+		t.Parallel()
+
+		event, err := decoder.Decode(&vmcommon.LogEntry{
+			Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+			Topics:     [][]byte{token, nil, big.NewInt(10).Bytes()},
+		})
+		assert.Nil(t, event)
+		assert.True(t, errors.Is(err, ErrInvalidLogEventTopics))
+
+		event, err = decoder.Decode(&vmcommon.LogEntry{
+			Identifier: []byte(core.BuiltInFunctionMultiESDTNFTTransfer),
+			Topics:     [][]byte{token, nil, big.NewInt(10).Bytes(), token, receiver},
+		})
+		assert.Nil(t, event)
+		assert.True(t, errors.Is(err, ErrInvalidLogEventTopics))
+	})
+	t.Run("ESDTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput:  vmcommon.VMInput{CallerAddr: caller, Arguments: [][]byte{token, big.NewInt(10).Bytes()}},
+			Function: core.BuiltInFunctionESDTTransfer,
+		}
+		vmOutput := &vmcommon.VMOutput{}
+		addESDTEntryForTransferInVMOutput(vmInput, vmOutput, []byte(core.BuiltInFunctionESDTTransfer), receiver, []*TopicTokenData{{token, 0, big.NewInt(10)}})
+
+		event, err := decoder.Decode(vmOutput.Logs[0])
+		require.Nil(t, err)
+		assert.Equal(t, &ESDTTransferEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionESDTTransfer, Address: caller},
+			Token:        token,
+			Nonce:        0,
+			Value:        big.NewInt(10),
+			Receiver:     receiver,
+		}, event)
+		assert.Equal(t, core.BuiltInFunctionESDTTransfer, event.EventIdentifier())
+	})
+	t.Run("MultiESDTNFTTransfer with both topic layouts", func(t *testing.T) {
+		t.Parallel()
+
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput:  vmcommon.VMInput{CallerAddr: caller},
+			Function: core.BuiltInFunctionMultiESDTNFTTransfer,
+		}
+		identifier := []byte(core.BuiltInFunctionMultiESDTNFTTransfer)
+		transfers := []*TopicTokenData{
+			{TokenID: token, Nonce: 0, Value: big.NewInt(10)},
+			{TokenID: []byte("NFT-012345"), Nonce: 7, Value: big.NewInt(1)},
+		}
+
+		oldOutput := &vmcommon.VMOutput{}
+		for _, transfer := range transfers {
+			addESDTEntryInVMOutput(oldOutput, identifier, transfer.TokenID, transfer.Nonce, transfer.Value, caller, receiver)
+		}
+		oldEvents, err := decoder.DecodeLogs(oldOutput.Logs)
+		require.Nil(t, err)
+		require.Len(t, oldEvents, 2)
+		for i, event := range oldEvents {
+			assert.Equal(t, &MultiESDTTransferEvent{
+				BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionMultiESDTNFTTransfer, Address: caller},
+				Transfers:    []*TopicTokenData{transfers[i]},
+				Receiver:     receiver,
+			}, event)
+		}
+
+		newOutput := &vmcommon.VMOutput{}
+		addESDTEntryForTransferInVMOutput(vmInput, newOutput, identifier, receiver, transfers)
+		newEvents, err := decoder.DecodeLogs(newOutput.Logs)
+		require.Nil(t, err)
+		require.Len(t, newEvents, 1)
+		assert.Equal(t, &MultiESDTTransferEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionMultiESDTNFTTransfer, Address: caller},
+			Transfers:    transfers,
+			Receiver:     receiver,
+		}, newEvents[0])
+	})
+	t.Run("ESDTNFTCreate should decode the ESDT data", func(t *testing.T) {
+		t.Parallel()
+
+		esdtData := &esdt.ESDigitalToken{
+			Type:  uint32(core.NonFungible),
+			Value: big.NewInt(1),
+			TokenMetaData: &esdt.MetaData{
+				Nonce:   3,
+				Name:    []byte("name"),
+				Creator: caller,
+			},
+		}
+		esdtDataBytes, _ := (&mock.MarshalizerMock{}).Marshal(esdtData)
+
+		vmOutput := &vmcommon.VMOutput{}
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTNFTCreate), token, 3, big.NewInt(1), caller, esdtDataBytes)
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTNFTCreate), token, 4, big.NewInt(1), caller, nil)
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTNFTCreate), token, 5, big.NewInt(1), caller, []byte("not esdt data"))
+
+		event, err := decoder.Decode(vmOutput.Logs[0])
+		require.Nil(t, err)
+		assert.Equal(t, &NFTCreateEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionESDTNFTCreate, Address: caller},
+			Token:        token,
+			Nonce:        3,
+			Quantity:     big.NewInt(1),
+			ESDTData:     esdtData,
+		}, event)
+
+		event, err = decoder.Decode(vmOutput.Logs[1])
+		require.Nil(t, err)
+		assert.Nil(t, event.(*NFTCreateEvent).ESDTData)
+
+		_, err = decoder.Decode(vmOutput.Logs[2])
+		assert.True(t, errors.Is(err, ErrInvalidLogEventTopics))
+	})
+	t.Run("ESDTFreeze and ESDTWipe", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTFreeze), token, 0, big.NewInt(100), caller, receiver)
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTUnFreeze), token, 0, big.NewInt(100), caller, receiver)
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTWipe), token, 2, big.NewInt(100), caller, receiver)
+
+		events, err := decoder.DecodeLogs(vmOutput.Logs)
+		require.Nil(t, err)
+		assert.Equal(t, &FreezeEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionESDTFreeze, Address: caller},
+			Token:        token,
+			Balance:      big.NewInt(100),
+			Account:      receiver,
+			Frozen:       true,
+		}, events[0])
+		assert.False(t, events[1].(*FreezeEvent).Frozen)
+		assert.Equal(t, &WipeEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionESDTWipe, Address: caller},
+			Token:        token,
+			Nonce:        2,
+			WipedAmount:  big.NewInt(100),
+			Account:      receiver,
+		}, events[2])
+	})
+	t.Run("ESDT roles and NFT create role transfer", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionSetESDTRole), token, 0, big.NewInt(0), receiver, []byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleLocalBurn))
+		addESDTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionESDTNFTCreateRoleTransfer), token, 0, big.NewInt(0), receiver, boolToSlice(true))
+
+		events, err := decoder.DecodeLogs(vmOutput.Logs)
+		require.Nil(t, err)
+		assert.Equal(t, &ESDTRolesEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionSetESDTRole, Address: receiver},
+			Token:        token,
+			Roles:        [][]byte{[]byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleLocalBurn)},
+			Set:          true,
+		}, events[0])
+		assert.Equal(t, &NFTCreateRoleTransferEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionESDTNFTCreateRoleTransfer, Address: receiver},
+			Token:        token,
+			Account:      receiver,
+			HasRole:      true,
+		}, events[1])
+	})
+	t.Run("guardian events", func(t *testing.T) {
+		t.Parallel()
+
+		logs := []*vmcommon.LogEntry{
+			{
+				Address:    caller,
+				Identifier: []byte(core.BuiltInFunctionSetGuardian),
+				Topics:     [][]byte{receiver, []byte("serviceUID")},
+			},
+			{
+				Address:    caller,
+				Identifier: []byte(core.BuiltInFunctionUnGuardAccount),
+			},
+		}
+
+		events, err := decoder.DecodeLogs(logs)
+		require.Nil(t, err)
+		assert.Equal(t, &SetGuardianEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionSetGuardian, Address: caller},
+			Guardian:     receiver,
+			ServiceUID:   []byte("serviceUID"),
+		}, events[0])
+		assert.Equal(t, &GuardAccountEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionUnGuardAccount, Address: caller},
+			Guarded:      false,
+		}, events[1])
+
+		logs[1].Topics = [][]byte{receiver}
+		events, err = decoder.DecodeLogs(logs)
+		assert.Nil(t, events)
+		assert.True(t, errors.Is(err, ErrInvalidLogEventTopics))
+		assert.Contains(t, err.Error(), "for log entry 1")
+	})
+	t.Run("SetUserName emitted by the function", func(t *testing.T) {
+		t.Parallel()
+
+		dnsAddress := []byte("DNS")
+		function, err := NewSaveUserNameFunc(
+			1,
+			map[string]struct{}{string(dnsAddress): {}},
+			make(map[string]struct{}),
+			&mock.EnableEpochsHandlerStub{},
+		)
+		require.Nil(t, err)
+
+		account := mock.NewUserAccount(receiver)
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  dnsAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 10,
+				Arguments:   [][]byte{[]byte("alice.elrond")},
+			},
+			RecipientAddr: receiver,
+			Function:      core.BuiltInFunctionSetUserName,
+		}
+		vmOutput, err := function.ProcessBuiltinFunction(nil, account, vmInput)
+		require.Nil(t, err)
+
+		events, err := decoder.DecodeLogs(vmOutput.Logs)
+		require.Nil(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, &SetUserNameEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionSetUserName, Address: receiver},
+			OldUserName:  nil,
+		}, events[0])
+	})
+	t.Run("DecodeLogs should skip the log entries not emitted by built-in functions", func(t *testing.T) {
+		t.Parallel()
+
+		logs := []*vmcommon.LogEntry{
+			{Identifier: []byte("writeLog"), Address: caller, Topics: [][]byte{[]byte("data")}},
+			{Identifier: []byte(core.BuiltInFunctionChangeOwnerAddress), Address: caller, Topics: [][]byte{receiver}},
+			{Identifier: []byte(core.CompletedTxEventIdentifier), Address: caller},
+		}
+
+		events, err := decoder.DecodeLogs(logs)
+		require.Nil(t, err)
+		assert.Equal(t, []LogEvent{
+			&ChangeOwnerAddressEvent{
+				BaseLogEvent: BaseLogEvent{Identifier: core.BuiltInFunctionChangeOwnerAddress, Address: caller},
+				NewOwner:     receiver,
+			},
+		}, events)
+
+		events, err = decoder.DecodeLogs([]*vmcommon.LogEntry{logs[0], nil})
+		assert.Nil(t, events)
+		assert.True(t, errors.Is(err, ErrNilLogEntry))
+	})
+	t.Run("ESDTModifyRoyalties", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		addESDTEntryInVMOutput(vmOutput, []byte(core.ESDTModifyRoyalties), token, 9, big.NewInt(0), caller, big.NewInt(500).Bytes())
+
+		event, err := decoder.Decode(vmOutput.Logs[0])
+		require.Nil(t, err)
+		assert.Equal(t, &ModifyRoyaltiesEvent{
+			BaseLogEvent: BaseLogEvent{Identifier: core.ESDTModifyRoyalties, Address: caller},
+			Token:        token,
+			Nonce:        9,
+			Royalties:    500,
+		}, event)
+	})
+}
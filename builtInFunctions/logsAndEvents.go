@@ -1,19 +1,12 @@
 package builtInFunctions
 
 import (
-	"bytes"
-	"fmt"
 	"math/big"
 	"strconv"
 
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
-const (
-	esdtIdentifierSeparator  = "-"
-	esdtRandomSequenceLength = 6
-)
-
 // TopicTokenData groups data that will end up in Topics section of LogEntry
 type TopicTokenData struct {
 	TokenID []byte
@@ -79,22 +72,6 @@ func newEntryForESDT(identifier, tokenID []byte, nonce uint64, value *big.Int, a
 	return logEntry
 }
 
-func extractTokenIdentifierAndNonceESDTWipe(args []byte) ([]byte, uint64) {
-	argsSplit := bytes.Split(args, []byte(esdtIdentifierSeparator))
-	if len(argsSplit) < 2 {
-		return args, 0
-	}
-
-	if len(argsSplit[1]) <= esdtRandomSequenceLength {
-		return args, 0
-	}
-
-	identifier := []byte(fmt.Sprintf("%s-%s", argsSplit[0], argsSplit[1][:esdtRandomSequenceLength]))
-	nonce := big.NewInt(0).SetBytes(argsSplit[1][esdtRandomSequenceLength:])
-
-	return identifier, nonce.Uint64()
-}
-
 func boolToSlice(b bool) []byte {
 	return []byte(strconv.FormatBool(b))
 }
@@ -1,7 +1,6 @@
 package builtInFunctions
 
 import (
-	"encoding/hex"
 	"math/big"
 	"testing"
 
@@ -22,21 +21,3 @@ func TestNewEntryForNFT(t *testing.T) {
 		Data:       nil,
 	}, vmOutput.Logs[0])
 }
-
-func TestExtractTokenIdentifierAndNonceESDTWipe(t *testing.T) {
-	t.Parallel()
-
-	hexArg := "534b4537592d37336262636404"
-	args, _ := hex.DecodeString(hexArg)
-
-	identifier, nonce := extractTokenIdentifierAndNonceESDTWipe(args)
-	require.Equal(t, uint64(4), nonce)
-	require.Equal(t, []byte("SKE7Y-73bbcd"), identifier)
-
-	hexArg = "5745474c442d376662623930"
-	args, _ = hex.DecodeString(hexArg)
-
-	identifier, nonce = extractTokenIdentifierAndNonceESDTWipe(args)
-	require.Equal(t, uint64(0), nonce)
-	require.Equal(t, []byte("WEGLD-7fbb90"), identifier)
-}
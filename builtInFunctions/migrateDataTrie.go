@@ -36,9 +36,7 @@ func NewMigrateDataTrieFunc(
 		accounts:    accounts,
 	}
 
-	mdt.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(MigrateDataTrieFlag)
-	}
+	mdt.baseActiveHandler = newFlagActiveHandler(enableEpochsHandler, MigrateDataTrieFlag)
 
 	return mdt, nil
 }
@@ -154,7 +152,7 @@ func checkArgumentsForMigrateDataTrie(
 		return ErrNilVmInput
 	}
 	if input.GasProvided < cost.TrieLoadPerNode+cost.TrieStorePerNode {
-		return fmt.Errorf("not enough gas, gas provided: %d, trie load cost: %d, trie store cost: %d", input.GasProvided, cost.TrieLoadPerNode, cost.TrieStorePerNode)
+		return fmt.Errorf("%w, gas provided: %d, trie load cost: %d, trie store cost: %d", ErrNotEnoughGasForDataTrieMigration, input.GasProvided, cost.TrieLoadPerNode, cost.TrieStorePerNode)
 	}
 	if input.CallValue.Cmp(zero) != 0 {
 		return ErrBuiltInFunctionCalledWithValue
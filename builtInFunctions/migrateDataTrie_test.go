@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"math/big"
-	"strings"
 	"sync"
 	"testing"
 
@@ -80,7 +79,9 @@ func TestMigrateDataTrie_ProcessBuiltinFunction(t *testing.T) {
 		mdtf, _ := NewMigrateDataTrieFunc(builtInCost, &mock.EnableEpochsHandlerStub{}, &mock.AccountsStub{})
 		vmOutput, err := mdtf.ProcessBuiltinFunction(mock.NewUserAccount([]byte("sender")), mock.NewUserAccount([]byte("dest")), input)
 		assert.Nil(t, vmOutput)
-		assert.True(t, strings.Contains(err.Error(), "not enough gas"))
+		assert.Equal(t, "not enough gas, gas provided: 100, trie load cost: 40, trie store cost: 61", err.Error())
+		assert.True(t, errors.Is(err, ErrNotEnoughGasForDataTrieMigration))
+		assert.Equal(t, vmcommon.OutOfGas, vmcommon.GetReturnCodeFromError(err))
 	})
 	t.Run("invalid call value", func(t *testing.T) {
 		t.Parallel()
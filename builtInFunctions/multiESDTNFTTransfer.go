@@ -79,9 +79,7 @@ func NewESDTNFTMultiTransferFunc(
 		baseTokenID: []byte(vmcommon.EGLDIdentifier),
 	}
 
-	e.baseActiveHandler.activeHandler = func() bool {
-		return e.enableEpochsHandler.IsFlagEnabled(ESDTNFTImprovementV1Flag)
-	}
+	e.baseActiveHandler = newFlagActiveHandler(e.enableEpochsHandler, ESDTNFTImprovementV1Flag)
 
 	return e, nil
 }
@@ -108,6 +106,14 @@ func (e *esdtNFTMultiTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (e *esdtNFTMultiTransfer) FuncGasCost() uint64 {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost
+}
+
 // ProcessBuiltinFunction resolves ESDT NFT transfer roles function call
 // Requires the following arguments:
 // arg0 - destination address
@@ -62,6 +62,14 @@ func (s *saveUserName) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	s.mutExecution.Unlock()
 }
 
+// FuncGasCost returns the gas cost charged by the function
+func (s *saveUserName) FuncGasCost() uint64 {
+	s.mutExecution.RLock()
+	defer s.mutExecution.RUnlock()
+
+	return s.gasCost
+}
+
 func inputCheckForUserNameCall(
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -33,9 +33,7 @@ func NewSetGuardianFunc(args SetGuardianArgs) (*setGuardian, error) {
 	setGuardianFunc := &setGuardian{
 		baseAccountGuarder: base,
 	}
-	setGuardianFunc.activeHandler = func() bool {
-		return args.EnableEpochsHandler.IsFlagEnabled(SetGuardianFlag)
-	}
+	setGuardianFunc.baseActiveHandler = newFlagActiveHandler(args.EnableEpochsHandler, SetGuardianFlag)
 
 	return setGuardianFunc, nil
 }
@@ -0,0 +1,166 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const lengthPrefixSize = 4
+
+// Value is a value that can be encoded with the MultiversX serialization format. The top level encoding is the one
+// of the smart contract call arguments, the nested encoding is the one of the values inside composite values.
+type Value interface {
+	EncodeNested(writer io.Writer) error
+	EncodeTopLevel(writer io.Writer) error
+	DecodeNested(reader io.Reader) error
+	DecodeTopLevel(data []byte) error
+}
+
+// EncodeTopLevel returns the top level encoding of the value
+func EncodeTopLevel(value Value) ([]byte, error) {
+	if value == nil {
+		return nil, ErrNilValue
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0))
+	err := value.EncodeTopLevel(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// EncodeNested returns the nested encoding of the value
+func EncodeNested(value Value) ([]byte, error) {
+	if value == nil {
+		return nil, ErrNilValue
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0))
+	err := value.EncodeNested(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// DecodeTopLevel decodes the top level encoded data into the value
+func DecodeTopLevel(data []byte, value Value) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	return value.DecodeTopLevel(data)
+}
+
+// DecodeNested decodes the nested encoded data into the value. All the data must be used.
+func DecodeNested(data []byte, value Value) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	return decodeNestedExactly(data, value)
+}
+
+// EncodeArguments returns the top level encodings of the values, to be used as smart contract call arguments
+func EncodeArguments(values ...Value) ([][]byte, error) {
+	arguments := make([][]byte, 0, len(values))
+	for i, value := range values {
+		argument, err := EncodeTopLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w for argument %d", err, i)
+		}
+
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+// DecodeArguments decodes each smart contract call argument into the value at the same position
+func DecodeArguments(arguments [][]byte, values ...Value) error {
+	if len(arguments) != len(values) {
+		return fmt.Errorf("%w, expected %d, got %d", ErrInvalidNumberOfArguments, len(values), len(arguments))
+	}
+
+	for i, value := range values {
+		err := DecodeTopLevel(arguments[i], value)
+		if err != nil {
+			return fmt.Errorf("%w for argument %d", err, i)
+		}
+	}
+
+	return nil
+}
+
+func decodeNestedExactly(data []byte, value Value) error {
+	reader := bytes.NewReader(data)
+	err := value.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+	if reader.Len() != 0 {
+		return ErrTrailingBytes
+	}
+
+	return nil
+}
+
+func writeLength(writer io.Writer, length int) error {
+	if uint64(length) > uint64(^uint32(0)) {
+		return fmt.Errorf("%w: length %d", ErrValueOutOfRange, length)
+	}
+
+	prefix := make([]byte, lengthPrefixSize)
+	binary.BigEndian.PutUint32(prefix, uint32(length))
+	_, err := writer.Write(prefix)
+
+	return err
+}
+
+func readLength(reader io.Reader) (uint32, error) {
+	prefix, err := readBytes(reader, lengthPrefixSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(prefix), nil
+}
+
+// readBytes reads exactly length bytes, growing the buffer as the data arrives, so that a forged length does not
+// allocate more than the available data
+func readBytes(reader io.Reader, length uint64) ([]byte, error) {
+	buffer := bytes.NewBuffer(make([]byte, 0))
+	read, err := io.CopyN(buffer, reader, int64(length))
+	if uint64(read) < length {
+		return nil, ErrUnexpectedEndOfData
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeWithLength(writer io.Writer, data []byte) error {
+	err := writeLength(writer, len(data))
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+	return err
+}
+
+func readWithLength(reader io.Reader) ([]byte, error) {
+	length, err := readLength(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return readBytes(reader, uint64(length))
+}
@@ -0,0 +1,186 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecodeHex(t *testing.T, encoded string) []byte {
+	data, err := hex.DecodeString(encoded)
+	require.Nil(t, err)
+	return data
+}
+
+func TestEncodings(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{0xAA}, AddressLength)
+	testCases := []struct {
+		name     string
+		value    Value
+		topLevel string
+		nested   string
+	}{
+		{name: "u8", value: &U8Value{Value: 0}, topLevel: "", nested: "00"},
+		{name: "u16", value: &U16Value{Value: 1}, topLevel: "01", nested: "0001"},
+		{name: "u32", value: &U32Value{Value: 256}, topLevel: "0100", nested: "00000100"},
+		{name: "u64", value: &U64Value{Value: math.MaxUint64}, topLevel: "ffffffffffffffff", nested: "ffffffffffffffff"},
+		{name: "i8 negative", value: &I8Value{Value: -1}, topLevel: "ff", nested: "ff"},
+		{name: "i16 positive with top bit", value: &I16Value{Value: 128}, topLevel: "0080", nested: "0080"},
+		{name: "i32 min byte", value: &I32Value{Value: -128}, topLevel: "80", nested: "ffffff80"},
+		{name: "i64 below min byte", value: &I64Value{Value: -129}, topLevel: "ff7f", nested: "ffffffffffffff7f"},
+		{name: "i64 zero", value: &I64Value{Value: 0}, topLevel: "", nested: "0000000000000000"},
+		{name: "big uint", value: &BigUintValue{Value: big.NewInt(1000)}, topLevel: "03e8", nested: "0000000203e8"},
+		{name: "big uint zero", value: &BigUintValue{Value: big.NewInt(0)}, topLevel: "", nested: "00000000"},
+		{name: "big int negative", value: &BigIntValue{Value: big.NewInt(-1)}, topLevel: "ff", nested: "00000001ff"},
+		{name: "big int positive", value: &BigIntValue{Value: big.NewInt(255)}, topLevel: "00ff", nested: "0000000200ff"},
+		{name: "bool true", value: &BoolValue{Value: true}, topLevel: "01", nested: "01"},
+		{name: "bool false", value: &BoolValue{Value: false}, topLevel: "", nested: "00"},
+		{name: "bytes", value: &BytesValue{Value: []byte("abc")}, topLevel: "616263", nested: "00000003616263"},
+		{name: "string", value: &StringValue{Value: "TKN-abcdef"}, topLevel: "544b4e2d616263646566", nested: "0000000a544b4e2d616263646566"},
+		{name: "address", value: &AddressValue{Value: address}, topLevel: hex.EncodeToString(address), nested: hex.EncodeToString(address)},
+		{name: "unset option", value: &OptionValue{Value: &U32Value{}}, topLevel: "", nested: "00"},
+		{name: "set option", value: &OptionValue{IsSet: true, Value: &U32Value{Value: 5}}, topLevel: "0100000005", nested: "0100000005"},
+		{
+			name:     "list",
+			value:    &ListValue{Items: []Value{&U16Value{Value: 1}, &U16Value{Value: 2}}, NewItem: func() Value { return &U16Value{} }},
+			topLevel: "00010002",
+			nested:   "0000000200010002",
+		},
+		{
+			name:     "struct",
+			value:    &StructValue{Fields: []Value{&BigUintValue{Value: big.NewInt(5)}, &BytesValue{Value: []byte("a")}, &U8Value{Value: 1}}},
+			topLevel: "00000001050000000161" + "01",
+			nested:   "00000001050000000161" + "01",
+		},
+		{
+			name: "list of structs with options",
+			value: &ListValue{
+				Items: []Value{
+					&StructValue{Fields: []Value{&I32Value{Value: -2}, &OptionValue{IsSet: true, Value: &BoolValue{Value: true}}}},
+					&StructValue{Fields: []Value{&I32Value{Value: 3}, &OptionValue{Value: &BoolValue{}}}},
+				},
+				NewItem: func() Value {
+					return &StructValue{Fields: []Value{&I32Value{}, &OptionValue{Value: &BoolValue{}}}}
+				},
+			},
+			topLevel: "fffffffe0101" + "0000000300",
+			nested:   "00000002" + "fffffffe0101" + "0000000300",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			topLevel, err := EncodeTopLevel(testCase.value)
+			require.Nil(t, err)
+			assert.Equal(t, testCase.topLevel, hex.EncodeToString(topLevel))
+
+			nested, err := EncodeNested(testCase.value)
+			require.Nil(t, err)
+			assert.Equal(t, testCase.nested, hex.EncodeToString(nested))
+
+			require.Nil(t, DecodeTopLevel(topLevel, testCase.value))
+			reencoded, _ := EncodeTopLevel(testCase.value)
+			assert.Equal(t, topLevel, reencoded)
+
+			require.Nil(t, DecodeNested(nested, testCase.value))
+			reencoded, _ = EncodeNested(testCase.value)
+			assert.Equal(t, nested, reencoded)
+		})
+	}
+}
+
+func TestSignedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	values := []int64{0, 1, -1, 127, 128, -128, -129, 255, -256, 32767, -32768, math.MaxInt64, math.MinInt64}
+	for _, value := range values {
+		bigValue := &BigIntValue{}
+		encoded, err := EncodeTopLevel(&BigIntValue{Value: big.NewInt(value)})
+		require.Nil(t, err)
+		require.Nil(t, DecodeTopLevel(encoded, bigValue))
+		assert.Equal(t, value, bigValue.Value.Int64())
+
+		fixedValue := &I64Value{}
+		require.Nil(t, DecodeTopLevel(encoded, fixedValue))
+		assert.Equal(t, value, fixedValue.Value)
+	}
+
+	huge, _ := big.NewInt(0).SetString("-340282366920938463463374607431768211457", 10)
+	decoded := &BigIntValue{}
+	encoded, _ := EncodeNested(&BigIntValue{Value: huge})
+	require.Nil(t, DecodeNested(encoded, decoded))
+	assert.Equal(t, huge, decoded.Value)
+}
+
+func TestDecodingErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ErrNilValue, DecodeTopLevel(nil, nil))
+	assert.Equal(t, ErrNilValue, DecodeNested(nil, nil))
+	assert.Equal(t, ErrTrailingBytes, DecodeNested(mustDecodeHex(t, "000100"), &U16Value{}))
+	assert.Equal(t, ErrUnexpectedEndOfData, DecodeNested(mustDecodeHex(t, "00"), &U16Value{}))
+	assert.True(t, errors.Is(DecodeTopLevel(mustDecodeHex(t, "010000"), &U16Value{}), ErrValueOutOfRange))
+	assert.True(t, errors.Is(DecodeTopLevel(mustDecodeHex(t, "ff0000"), &I16Value{}), ErrValueOutOfRange))
+	assert.Equal(t, ErrInvalidBool, DecodeTopLevel(mustDecodeHex(t, "02"), &BoolValue{}))
+	assert.Equal(t, ErrInvalidBool, DecodeTopLevel(mustDecodeHex(t, "0101"), &BoolValue{}))
+	assert.Equal(t, ErrInvalidOptionMarker, DecodeNested(mustDecodeHex(t, "02"), &OptionValue{Value: &U8Value{}}))
+	assert.Equal(t, ErrNilValue, DecodeNested(mustDecodeHex(t, "0101"), &OptionValue{}))
+	assert.Equal(t, ErrTrailingBytes, DecodeTopLevel(mustDecodeHex(t, "0001"), &OptionValue{Value: &U8Value{}}))
+	assert.True(t, errors.Is(DecodeTopLevel([]byte{1}, &AddressValue{}), ErrInvalidAddressLength))
+	assert.Equal(t, ErrNilItemFactory, DecodeTopLevel([]byte{1}, &ListValue{}))
+	assert.Equal(t, ErrUnexpectedEndOfData, DecodeTopLevel(mustDecodeHex(t, "000100"), &ListValue{NewItem: func() Value { return &U16Value{} }}))
+	assert.Equal(t, ErrTrailingBytes, DecodeTopLevel(mustDecodeHex(t, "0102"), &StructValue{Fields: []Value{&U8Value{}}}))
+
+	// a forged length must fail on the missing data, not allocate it
+	assert.Equal(t, ErrUnexpectedEndOfData, DecodeNested(mustDecodeHex(t, "ffffffff00"), &BytesValue{}))
+	assert.Equal(t, ErrUnexpectedEndOfData, DecodeNested(mustDecodeHex(t, "ffffffff00"), &ListValue{NewItem: func() Value { return &U8Value{} }}))
+}
+
+func TestEncodingErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := EncodeTopLevel(nil)
+	assert.Equal(t, ErrNilValue, err)
+	_, err = EncodeNested(&BigUintValue{})
+	assert.Equal(t, ErrNilValue, err)
+	_, err = EncodeTopLevel(&BigUintValue{Value: big.NewInt(-1)})
+	assert.Equal(t, ErrNegativeValue, err)
+	_, err = EncodeTopLevel(&BigIntValue{})
+	assert.Equal(t, ErrNilValue, err)
+	_, err = EncodeTopLevel(&AddressValue{Value: []byte{1}})
+	assert.True(t, errors.Is(err, ErrInvalidAddressLength))
+	_, err = EncodeTopLevel(&OptionValue{IsSet: true})
+	assert.Equal(t, ErrNilValue, err)
+	_, err = EncodeTopLevel(&StructValue{Fields: []Value{nil}})
+	assert.Equal(t, ErrNilValue, err)
+}
+
+func TestEncodeDecodeArguments(t *testing.T) {
+	t.Parallel()
+
+	arguments, err := EncodeArguments(&StringValue{Value: "TKN"}, &BigUintValue{Value: big.NewInt(10)}, &OptionValue{})
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("TKN"), {10}, {}}, arguments)
+
+	token, value, option := &StringValue{}, &BigUintValue{}, &OptionValue{Value: &U8Value{}}
+	require.Nil(t, DecodeArguments(arguments, token, value, option))
+	assert.Equal(t, "TKN", token.Value)
+	assert.Equal(t, big.NewInt(10), value.Value)
+	assert.False(t, option.IsSet)
+
+	assert.True(t, errors.Is(DecodeArguments(arguments, token), ErrInvalidNumberOfArguments))
+	assert.True(t, errors.Is(DecodeArguments([][]byte{{1, 2}}, &U8Value{}), ErrValueOutOfRange))
+	_, err = EncodeArguments(&StringValue{}, nil)
+	assert.True(t, errors.Is(err, ErrNilValue))
+}
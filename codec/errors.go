@@ -0,0 +1,33 @@
+package codec
+
+import "errors"
+
+// ErrNilValue signals that a nil value has been provided
+var ErrNilValue = errors.New("nil value")
+
+// ErrUnexpectedEndOfData signals that the encoded data ended before the value was fully decoded
+var ErrUnexpectedEndOfData = errors.New("unexpected end of data")
+
+// ErrTrailingBytes signals that bytes were left over after the value was decoded
+var ErrTrailingBytes = errors.New("trailing bytes after the decoded value")
+
+// ErrValueOutOfRange signals that the value does not fit the type
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// ErrNegativeValue signals that a negative value has been provided for an unsigned type
+var ErrNegativeValue = errors.New("negative value for an unsigned type")
+
+// ErrInvalidBool signals that the encoded bool is neither true nor false
+var ErrInvalidBool = errors.New("invalid bool")
+
+// ErrInvalidOptionMarker signals that the encoded option does not start with 0 or 1
+var ErrInvalidOptionMarker = errors.New("invalid option marker")
+
+// ErrInvalidAddressLength signals that the address does not have the expected length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrNilItemFactory signals that a list can not be decoded without an item factory
+var ErrNilItemFactory = errors.New("nil item factory")
+
+// ErrInvalidNumberOfArguments signals that the number of arguments does not match the number of values
+var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
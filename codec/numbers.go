@@ -0,0 +1,424 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// U8Value is an unsigned integer of 1 byte
+type U8Value struct {
+	Value uint8
+}
+
+// U16Value is an unsigned integer of 2 bytes
+type U16Value struct {
+	Value uint16
+}
+
+// U32Value is an unsigned integer of 4 bytes
+type U32Value struct {
+	Value uint32
+}
+
+// U64Value is an unsigned integer of 8 bytes
+type U64Value struct {
+	Value uint64
+}
+
+// I8Value is a signed integer of 1 byte
+type I8Value struct {
+	Value int8
+}
+
+// I16Value is a signed integer of 2 bytes
+type I16Value struct {
+	Value int16
+}
+
+// I32Value is a signed integer of 4 bytes
+type I32Value struct {
+	Value int32
+}
+
+// I64Value is a signed integer of 8 bytes
+type I64Value struct {
+	Value int64
+}
+
+// BigUintValue is an unsigned integer of any size
+type BigUintValue struct {
+	Value *big.Int
+}
+
+// BigIntValue is a signed integer of any size
+type BigIntValue struct {
+	Value *big.Int
+}
+
+// EncodeNested writes the value on 1 byte
+func (v *U8Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 1)
+}
+
+// EncodeTopLevel writes the value without the leading zeros
+func (v *U8Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelUnsigned(writer, uint64(v.Value))
+}
+
+// DecodeNested reads the value from 1 byte
+func (v *U8Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 1)
+	v.Value = uint8(value)
+	return err
+}
+
+// DecodeTopLevel reads the value from at most 1 byte
+func (v *U8Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelUnsigned(data, 1)
+	v.Value = uint8(value)
+	return err
+}
+
+// EncodeNested writes the value on 2 bytes
+func (v *U16Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 2)
+}
+
+// EncodeTopLevel writes the value without the leading zeros
+func (v *U16Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelUnsigned(writer, uint64(v.Value))
+}
+
+// DecodeNested reads the value from 2 bytes
+func (v *U16Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 2)
+	v.Value = uint16(value)
+	return err
+}
+
+// DecodeTopLevel reads the value from at most 2 bytes
+func (v *U16Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelUnsigned(data, 2)
+	v.Value = uint16(value)
+	return err
+}
+
+// EncodeNested writes the value on 4 bytes
+func (v *U32Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 4)
+}
+
+// EncodeTopLevel writes the value without the leading zeros
+func (v *U32Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelUnsigned(writer, uint64(v.Value))
+}
+
+// DecodeNested reads the value from 4 bytes
+func (v *U32Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 4)
+	v.Value = uint32(value)
+	return err
+}
+
+// DecodeTopLevel reads the value from at most 4 bytes
+func (v *U32Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelUnsigned(data, 4)
+	v.Value = uint32(value)
+	return err
+}
+
+// EncodeNested writes the value on 8 bytes
+func (v *U64Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, v.Value, 8)
+}
+
+// EncodeTopLevel writes the value without the leading zeros
+func (v *U64Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelUnsigned(writer, v.Value)
+}
+
+// DecodeNested reads the value from 8 bytes
+func (v *U64Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 8)
+	v.Value = value
+	return err
+}
+
+// DecodeTopLevel reads the value from at most 8 bytes
+func (v *U64Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelUnsigned(data, 8)
+	v.Value = value
+	return err
+}
+
+// EncodeNested writes the two's complement of the value on 1 byte
+func (v *I8Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 1)
+}
+
+// EncodeTopLevel writes the shortest two's complement of the value
+func (v *I8Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelSigned(writer, big.NewInt(int64(v.Value)))
+}
+
+// DecodeNested reads the two's complement of the value from 1 byte
+func (v *I8Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 1)
+	v.Value = int8(value)
+	return err
+}
+
+// DecodeTopLevel reads the two's complement of the value from at most 1 byte
+func (v *I8Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelFixedSigned(data, 1)
+	v.Value = int8(value)
+	return err
+}
+
+// EncodeNested writes the two's complement of the value on 2 bytes
+func (v *I16Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 2)
+}
+
+// EncodeTopLevel writes the shortest two's complement of the value
+func (v *I16Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelSigned(writer, big.NewInt(int64(v.Value)))
+}
+
+// DecodeNested reads the two's complement of the value from 2 bytes
+func (v *I16Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 2)
+	v.Value = int16(value)
+	return err
+}
+
+// DecodeTopLevel reads the two's complement of the value from at most 2 bytes
+func (v *I16Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelFixedSigned(data, 2)
+	v.Value = int16(value)
+	return err
+}
+
+// EncodeNested writes the two's complement of the value on 4 bytes
+func (v *I32Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 4)
+}
+
+// EncodeTopLevel writes the shortest two's complement of the value
+func (v *I32Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelSigned(writer, big.NewInt(int64(v.Value)))
+}
+
+// DecodeNested reads the two's complement of the value from 4 bytes
+func (v *I32Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 4)
+	v.Value = int32(value)
+	return err
+}
+
+// DecodeTopLevel reads the two's complement of the value from at most 4 bytes
+func (v *I32Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelFixedSigned(data, 4)
+	v.Value = int32(value)
+	return err
+}
+
+// EncodeNested writes the two's complement of the value on 8 bytes
+func (v *I64Value) EncodeNested(writer io.Writer) error {
+	return encodeFixedUnsigned(writer, uint64(v.Value), 8)
+}
+
+// EncodeTopLevel writes the shortest two's complement of the value
+func (v *I64Value) EncodeTopLevel(writer io.Writer) error {
+	return encodeTopLevelSigned(writer, big.NewInt(v.Value))
+}
+
+// DecodeNested reads the two's complement of the value from 8 bytes
+func (v *I64Value) DecodeNested(reader io.Reader) error {
+	value, err := decodeFixedUnsigned(reader, 8)
+	v.Value = int64(value)
+	return err
+}
+
+// DecodeTopLevel reads the two's complement of the value from at most 8 bytes
+func (v *I64Value) DecodeTopLevel(data []byte) error {
+	value, err := decodeTopLevelFixedSigned(data, 8)
+	v.Value = value
+	return err
+}
+
+// EncodeNested writes the length of the value on 4 bytes, followed by the value without the leading zeros
+func (v *BigUintValue) EncodeNested(writer io.Writer) error {
+	data, err := bigUintBytes(v.Value)
+	if err != nil {
+		return err
+	}
+
+	return writeWithLength(writer, data)
+}
+
+// EncodeTopLevel writes the value without the leading zeros
+func (v *BigUintValue) EncodeTopLevel(writer io.Writer) error {
+	data, err := bigUintBytes(v.Value)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+	return err
+}
+
+// DecodeNested reads the length of the value from 4 bytes, followed by the value
+func (v *BigUintValue) DecodeNested(reader io.Reader) error {
+	data, err := readWithLength(reader)
+	if err != nil {
+		return err
+	}
+
+	return v.DecodeTopLevel(data)
+}
+
+// DecodeTopLevel reads the value from all the data
+func (v *BigUintValue) DecodeTopLevel(data []byte) error {
+	v.Value = big.NewInt(0).SetBytes(data)
+	return nil
+}
+
+// EncodeNested writes the length of the value on 4 bytes, followed by the shortest two's complement of the value
+func (v *BigIntValue) EncodeNested(writer io.Writer) error {
+	if v.Value == nil {
+		return ErrNilValue
+	}
+
+	return writeWithLength(writer, signedBytes(v.Value))
+}
+
+// EncodeTopLevel writes the shortest two's complement of the value
+func (v *BigIntValue) EncodeTopLevel(writer io.Writer) error {
+	if v.Value == nil {
+		return ErrNilValue
+	}
+
+	return encodeTopLevelSigned(writer, v.Value)
+}
+
+// DecodeNested reads the length of the value from 4 bytes, followed by the two's complement of the value
+func (v *BigIntValue) DecodeNested(reader io.Reader) error {
+	data, err := readWithLength(reader)
+	if err != nil {
+		return err
+	}
+
+	return v.DecodeTopLevel(data)
+}
+
+// DecodeTopLevel reads the two's complement of the value from all the data
+func (v *BigIntValue) DecodeTopLevel(data []byte) error {
+	v.Value = signedFromBytes(data)
+	return nil
+}
+
+func encodeFixedUnsigned(writer io.Writer, value uint64, size int) error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, value)
+	_, err := writer.Write(data[8-size:])
+
+	return err
+}
+
+func decodeFixedUnsigned(reader io.Reader, size int) (uint64, error) {
+	data, err := readBytes(reader, uint64(size))
+	if err != nil {
+		return 0, err
+	}
+
+	value := uint64(0)
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+
+	return value, nil
+}
+
+func encodeTopLevelUnsigned(writer io.Writer, value uint64) error {
+	_, err := writer.Write(big.NewInt(0).SetUint64(value).Bytes())
+	return err
+}
+
+func decodeTopLevelUnsigned(data []byte, size int) (uint64, error) {
+	if len(data) > size {
+		return 0, fmt.Errorf("%w: %d bytes for a %d bytes unsigned integer", ErrValueOutOfRange, len(data), size)
+	}
+
+	value := uint64(0)
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+
+	return value, nil
+}
+
+func encodeTopLevelSigned(writer io.Writer, value *big.Int) error {
+	_, err := writer.Write(signedBytes(value))
+	return err
+}
+
+func decodeTopLevelFixedSigned(data []byte, size int) (int64, error) {
+	if len(data) > size {
+		return 0, fmt.Errorf("%w: %d bytes for a %d bytes signed integer", ErrValueOutOfRange, len(data), size)
+	}
+
+	return signedFromBytes(data).Int64(), nil
+}
+
+func bigUintBytes(value *big.Int) ([]byte, error) {
+	if value == nil {
+		return nil, ErrNilValue
+	}
+	if value.Sign() < 0 {
+		return nil, ErrNegativeValue
+	}
+
+	return value.Bytes(), nil
+}
+
+// signedBytes returns the shortest big endian two's complement of the value, empty for 0
+func signedBytes(value *big.Int) []byte {
+	switch value.Sign() {
+	case 0:
+		return make([]byte, 0)
+	case 1:
+		data := value.Bytes()
+		if data[0]&0x80 != 0 {
+			data = append([]byte{0}, data...)
+		}
+		return data
+	}
+
+	// the two's complement of a negative value is the bitwise complement of -value-1
+	data := big.NewInt(0).Sub(big.NewInt(0).Neg(value), big.NewInt(1)).Bytes()
+	for i := range data {
+		data[i] = ^data[i]
+	}
+	if len(data) == 0 || data[0]&0x80 == 0 {
+		data = append([]byte{0xFF}, data...)
+	}
+
+	return data
+}
+
+func signedFromBytes(data []byte) *big.Int {
+	if len(data) == 0 || data[0]&0x80 == 0 {
+		return big.NewInt(0).SetBytes(data)
+	}
+
+	complement := make([]byte, len(data))
+	for i := range data {
+		complement[i] = ^data[i]
+	}
+	value := big.NewInt(0).SetBytes(complement)
+
+	return value.Neg(value).Sub(value, big.NewInt(1))
+}
@@ -0,0 +1,377 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const (
+	falseByte  = byte(0)
+	trueByte   = byte(1)
+	noneMarker = byte(0)
+	someMarker = byte(1)
+)
+
+// AddressLength is the length of the encoded addresses
+const AddressLength = 32
+
+// BoolValue is a bool, encoded as 1 or 0
+type BoolValue struct {
+	Value bool
+}
+
+// BytesValue is a byte slice of any length, such as a token identifier
+type BytesValue struct {
+	Value []byte
+}
+
+// StringValue is a string of any length
+type StringValue struct {
+	Value string
+}
+
+// AddressValue is an address of AddressLength bytes
+type AddressValue struct {
+	Value []byte
+}
+
+// OptionValue is an optional value. Value is the value encoded when IsSet, and the value to decode into.
+type OptionValue struct {
+	IsSet bool
+	Value Value
+}
+
+// ListValue is a list of values of the same type. NewItem creates the values to decode the items into.
+type ListValue struct {
+	Items   []Value
+	NewItem func() Value
+}
+
+// StructValue is a fixed sequence of values, such as the fields of a struct or of a tuple
+type StructValue struct {
+	Fields []Value
+}
+
+// EncodeNested writes the value on 1 byte
+func (v *BoolValue) EncodeNested(writer io.Writer) error {
+	encoded := falseByte
+	if v.Value {
+		encoded = trueByte
+	}
+
+	_, err := writer.Write([]byte{encoded})
+	return err
+}
+
+// EncodeTopLevel writes 1 for true and nothing for false
+func (v *BoolValue) EncodeTopLevel(writer io.Writer) error {
+	if !v.Value {
+		return nil
+	}
+
+	_, err := writer.Write([]byte{trueByte})
+	return err
+}
+
+// DecodeNested reads the value from 1 byte
+func (v *BoolValue) DecodeNested(reader io.Reader) error {
+	data, err := readBytes(reader, 1)
+	if err != nil {
+		return err
+	}
+
+	return v.decode(data[0])
+}
+
+// DecodeTopLevel reads the value from at most 1 byte
+func (v *BoolValue) DecodeTopLevel(data []byte) error {
+	switch len(data) {
+	case 0:
+		v.Value = false
+		return nil
+	case 1:
+		return v.decode(data[0])
+	default:
+		return ErrInvalidBool
+	}
+}
+
+func (v *BoolValue) decode(encoded byte) error {
+	switch encoded {
+	case falseByte:
+		v.Value = false
+	case trueByte:
+		v.Value = true
+	default:
+		return ErrInvalidBool
+	}
+
+	return nil
+}
+
+// EncodeNested writes the length of the value on 4 bytes, followed by the value
+func (v *BytesValue) EncodeNested(writer io.Writer) error {
+	return writeWithLength(writer, v.Value)
+}
+
+// EncodeTopLevel writes the value
+func (v *BytesValue) EncodeTopLevel(writer io.Writer) error {
+	_, err := writer.Write(v.Value)
+	return err
+}
+
+// DecodeNested reads the length of the value from 4 bytes, followed by the value
+func (v *BytesValue) DecodeNested(reader io.Reader) error {
+	data, err := readWithLength(reader)
+	if err != nil {
+		return err
+	}
+
+	v.Value = data
+	return nil
+}
+
+// DecodeTopLevel reads the value from all the data
+func (v *BytesValue) DecodeTopLevel(data []byte) error {
+	v.Value = bytes.Clone(data)
+	return nil
+}
+
+// EncodeNested writes the length of the value on 4 bytes, followed by the value
+func (v *StringValue) EncodeNested(writer io.Writer) error {
+	return writeWithLength(writer, []byte(v.Value))
+}
+
+// EncodeTopLevel writes the value
+func (v *StringValue) EncodeTopLevel(writer io.Writer) error {
+	_, err := io.WriteString(writer, v.Value)
+	return err
+}
+
+// DecodeNested reads the length of the value from 4 bytes, followed by the value
+func (v *StringValue) DecodeNested(reader io.Reader) error {
+	data, err := readWithLength(reader)
+	if err != nil {
+		return err
+	}
+
+	v.Value = string(data)
+	return nil
+}
+
+// DecodeTopLevel reads the value from all the data
+func (v *StringValue) DecodeTopLevel(data []byte) error {
+	v.Value = string(data)
+	return nil
+}
+
+// EncodeNested writes the address, without a length
+func (v *AddressValue) EncodeNested(writer io.Writer) error {
+	return v.EncodeTopLevel(writer)
+}
+
+// EncodeTopLevel writes the address
+func (v *AddressValue) EncodeTopLevel(writer io.Writer) error {
+	if len(v.Value) != AddressLength {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidAddressLength, len(v.Value))
+	}
+
+	_, err := writer.Write(v.Value)
+	return err
+}
+
+// DecodeNested reads the address from AddressLength bytes
+func (v *AddressValue) DecodeNested(reader io.Reader) error {
+	data, err := readBytes(reader, AddressLength)
+	if err != nil {
+		return err
+	}
+
+	v.Value = data
+	return nil
+}
+
+// DecodeTopLevel reads the address from all the data
+func (v *AddressValue) DecodeTopLevel(data []byte) error {
+	if len(data) != AddressLength {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidAddressLength, len(data))
+	}
+
+	v.Value = bytes.Clone(data)
+	return nil
+}
+
+// EncodeNested writes 0 for an unset option, or 1 followed by the nested encoding of the value
+func (v *OptionValue) EncodeNested(writer io.Writer) error {
+	if !v.IsSet {
+		_, err := writer.Write([]byte{noneMarker})
+		return err
+	}
+	if v.Value == nil {
+		return ErrNilValue
+	}
+
+	_, err := writer.Write([]byte{someMarker})
+	if err != nil {
+		return err
+	}
+
+	return v.Value.EncodeNested(writer)
+}
+
+// EncodeTopLevel writes nothing for an unset option, or 1 followed by the nested encoding of the value
+func (v *OptionValue) EncodeTopLevel(writer io.Writer) error {
+	if !v.IsSet {
+		return nil
+	}
+
+	return v.EncodeNested(writer)
+}
+
+// DecodeNested reads the marker and, for a set option, the nested encoding of the value
+func (v *OptionValue) DecodeNested(reader io.Reader) error {
+	marker, err := readBytes(reader, 1)
+	if err != nil {
+		return err
+	}
+
+	switch marker[0] {
+	case noneMarker:
+		v.IsSet = false
+		return nil
+	case someMarker:
+		if v.Value == nil {
+			return ErrNilValue
+		}
+		v.IsSet = true
+		return v.Value.DecodeNested(reader)
+	default:
+		return ErrInvalidOptionMarker
+	}
+}
+
+// DecodeTopLevel reads an unset option from empty data, or a set option from all the data
+func (v *OptionValue) DecodeTopLevel(data []byte) error {
+	if len(data) == 0 {
+		v.IsSet = false
+		return nil
+	}
+
+	return decodeNestedExactly(data, v)
+}
+
+// EncodeNested writes the number of items on 4 bytes, followed by the nested encoding of each item
+func (v *ListValue) EncodeNested(writer io.Writer) error {
+	err := writeLength(writer, len(v.Items))
+	if err != nil {
+		return err
+	}
+
+	return v.EncodeTopLevel(writer)
+}
+
+// EncodeTopLevel writes the nested encoding of each item
+func (v *ListValue) EncodeTopLevel(writer io.Writer) error {
+	return encodeAllNested(writer, v.Items)
+}
+
+// DecodeNested reads the number of items from 4 bytes, followed by the nested encoding of each item
+func (v *ListValue) DecodeNested(reader io.Reader) error {
+	if v.NewItem == nil {
+		return ErrNilItemFactory
+	}
+	length, err := readLength(reader)
+	if err != nil {
+		return err
+	}
+
+	v.Items = make([]Value, 0)
+	for i := uint32(0); i < length; i++ {
+		err = v.decodeItem(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeTopLevel reads the nested encoding of items up to the end of the data
+func (v *ListValue) DecodeTopLevel(data []byte) error {
+	if v.NewItem == nil {
+		return ErrNilItemFactory
+	}
+
+	reader := bytes.NewReader(data)
+	v.Items = make([]Value, 0)
+	for reader.Len() > 0 {
+		err := v.decodeItem(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *ListValue) decodeItem(reader io.Reader) error {
+	item := v.NewItem()
+	if item == nil {
+		return ErrNilValue
+	}
+
+	err := item.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+
+	v.Items = append(v.Items, item)
+	return nil
+}
+
+// EncodeNested writes the nested encoding of each field
+func (v *StructValue) EncodeNested(writer io.Writer) error {
+	return encodeAllNested(writer, v.Fields)
+}
+
+// EncodeTopLevel writes the nested encoding of each field
+func (v *StructValue) EncodeTopLevel(writer io.Writer) error {
+	return encodeAllNested(writer, v.Fields)
+}
+
+// DecodeNested reads the nested encoding of each field
+func (v *StructValue) DecodeNested(reader io.Reader) error {
+	for _, field := range v.Fields {
+		if field == nil {
+			return ErrNilValue
+		}
+
+		err := field.DecodeNested(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeTopLevel reads the nested encoding of each field from all the data
+func (v *StructValue) DecodeTopLevel(data []byte) error {
+	return decodeNestedExactly(data, v)
+}
+
+func encodeAllNested(writer io.Writer, values []Value) error {
+	for _, value := range values {
+		if value == nil {
+			return ErrNilValue
+		}
+
+		err := value.EncodeNested(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
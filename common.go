@@ -4,14 +4,9 @@ import (
 	"math/big"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-common-go/tokenIdentifier"
 )
 
-const tickerMinLength = 3
-const tickerMaxLength = 10
-const additionalRandomCharsLength = 6
-const identifierMinLength = tickerMinLength + additionalRandomCharsLength + 1
-const identifierMaxLength = tickerMaxLength + additionalRandomCharsLength + 1
-
 // ESDTDeleteMetadata represents the defined built in function name for esdt delete metadata
 const ESDTDeleteMetadata = "ESDTDeleteMetadata"
 
@@ -34,65 +29,11 @@ const BuiltInFunctionESDTTransferRoleDeleteAddress = "ESDTTransferRoleDeleteAddr
 const ESDTRoleBurnForAll = "ESDTRoleBurnForAll"
 
 // EGLDIdentifier represents the identifier for the EGLD in case of a transfer with MultIESDTNFTTransfer built-in function
-const EGLDIdentifier = "EGLD-000000"
+const EGLDIdentifier = tokenIdentifier.EGLDIdentifier
 
 // ValidateToken - validates the token ID
 func ValidateToken(tokenID []byte) bool {
-	tokenIDLen := len(tokenID)
-	if tokenIDLen < identifierMinLength || tokenIDLen > identifierMaxLength {
-		return false
-	}
-
-	tickerLen := tokenIDLen - additionalRandomCharsLength
-
-	if !isTickerValid(tokenID[0 : tickerLen-1]) {
-		return false
-	}
-
-	// dash char between the random chars and the ticker
-	if tokenID[tickerLen-1] != '-' {
-		return false
-	}
-
-	if !randomCharsAreValid(tokenID[tickerLen:tokenIDLen]) {
-		return false
-	}
-
-	return true
-}
-
-// ticker must be all uppercase alphanumeric
-func isTickerValid(tickerName []byte) bool {
-	if len(tickerName) < tickerMinLength || len(tickerName) > tickerMaxLength {
-		return false
-	}
-	for _, ch := range tickerName {
-		isBigCharacter := ch >= 'A' && ch <= 'Z'
-		isNumber := ch >= '0' && ch <= '9'
-		isReadable := isBigCharacter || isNumber
-		if !isReadable {
-			return false
-		}
-	}
-
-	return true
-}
-
-// random chars are alphanumeric lowercase
-func randomCharsAreValid(chars []byte) bool {
-	if len(chars) != additionalRandomCharsLength {
-		return false
-	}
-	for _, ch := range chars {
-		isSmallCharacter := ch >= 'a' && ch <= 'f'
-		isNumber := ch >= '0' && ch <= '9'
-		isReadable := isSmallCharacter || isNumber
-		if !isReadable {
-			return false
-		}
-	}
-
-	return true
+	return tokenIdentifier.ValidateTokenID(tokenID) == nil
 }
 
 // ZeroValueIfNil returns 0 if the input is nil, otherwise returns the input
@@ -0,0 +1,243 @@
+package dryRun
+
+import (
+	"bytes"
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.UserAccountHandler = (*overlayAccount)(nil)
+var _ vmcommon.AccountDataHandler = (*overlayDataHandler)(nil)
+
+// overlayDataHandler reads through to the data handler of the base account and keeps all the writes for itself
+type overlayDataHandler struct {
+	base   vmcommon.AccountDataHandler
+	writes map[string][]byte
+}
+
+func newOverlayDataHandler(base vmcommon.AccountDataHandler) *overlayDataHandler {
+	return &overlayDataHandler{
+		base:   base,
+		writes: make(map[string][]byte),
+	}
+}
+
+// RetrieveValue returns the value written in the overlay, or the value of the base account if the key was not written
+func (handler *overlayDataHandler) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, found := handler.writes[string(key)]
+	if found {
+		return copyBytes(value), 0, nil
+	}
+	if handler.base == nil {
+		return nil, 0, nil
+	}
+
+	return handler.base.RetrieveValue(key)
+}
+
+// SaveKeyValue writes the value in the overlay. An empty value marks the key as removed.
+func (handler *overlayDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	handler.writes[string(key)] = copyBytes(value)
+	return nil
+}
+
+// MigrateDataTrieLeaves does nothing, as a migration would change the base account
+func (handler *overlayDataHandler) MigrateDataTrieLeaves(_ vmcommon.ArgsMigrateDataTrieLeaves) error {
+	return nil
+}
+
+// hasSameWrite returns true if the key was written with the same value in both handlers, or in none of them
+func (handler *overlayDataHandler) hasSameWrite(other *overlayDataHandler, key string) bool {
+	value, found := handler.writes[key]
+	otherValue, otherFound := other.writes[key]
+
+	return found == otherFound && bytes.Equal(value, otherValue)
+}
+
+func (handler *overlayDataHandler) clone() *overlayDataHandler {
+	cloned := newOverlayDataHandler(handler.base)
+	for key, value := range handler.writes {
+		cloned.writes[key] = copyBytes(value)
+	}
+
+	return cloned
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *overlayDataHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
+
+// overlayAccount is a copy-on-write view of a base account: the fields are read from the base account when the
+// overlay account is created and all the changes, including the data trie writes, stay in the overlay
+type overlayAccount struct {
+	address         []byte
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	codeHash        []byte
+	rootHash        []byte
+	codeMetadata    []byte
+	ownerAddress    []byte
+	userName        []byte
+	dataHandler     *overlayDataHandler
+}
+
+func newOverlayAccount(base vmcommon.UserAccountHandler) *overlayAccount {
+	return &overlayAccount{
+		address:         copyBytes(base.AddressBytes()),
+		nonce:           base.GetNonce(),
+		balance:         copyBigInt(base.GetBalance()),
+		developerReward: copyBigInt(base.GetDeveloperReward()),
+		codeHash:        copyBytes(base.GetCodeHash()),
+		rootHash:        copyBytes(base.GetRootHash()),
+		codeMetadata:    copyBytes(base.GetCodeMetadata()),
+		ownerAddress:    copyBytes(base.GetOwnerAddress()),
+		userName:        copyBytes(base.GetUserName()),
+		dataHandler:     newOverlayDataHandler(base.AccountDataHandler()),
+	}
+}
+
+// AddressBytes returns the address of the account
+func (account *overlayAccount) AddressBytes() []byte {
+	return account.address
+}
+
+// IncreaseNonce adds the provided value to the nonce
+func (account *overlayAccount) IncreaseNonce(nonce uint64) {
+	account.nonce += nonce
+}
+
+// GetNonce returns the nonce of the account
+func (account *overlayAccount) GetNonce() uint64 {
+	return account.nonce
+}
+
+// GetCodeMetadata returns the code metadata
+func (account *overlayAccount) GetCodeMetadata() []byte {
+	return account.codeMetadata
+}
+
+// SetCodeMetadata sets the code metadata
+func (account *overlayAccount) SetCodeMetadata(codeMetadata []byte) {
+	account.codeMetadata = copyBytes(codeMetadata)
+}
+
+// GetCodeHash returns the code hash of the base account
+func (account *overlayAccount) GetCodeHash() []byte {
+	return account.codeHash
+}
+
+// GetRootHash returns the data trie root hash of the base account, which does not include the overlay writes
+func (account *overlayAccount) GetRootHash() []byte {
+	return account.rootHash
+}
+
+// AccountDataHandler returns the copy-on-write data handler of the account
+func (account *overlayAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return account.dataHandler
+}
+
+// AddToBalance adds the provided value, which may be negative, to the balance
+func (account *overlayAccount) AddToBalance(value *big.Int) error {
+	newBalance := big.NewInt(0).Add(account.balance, vmcommon.ZeroValueIfNil(value))
+	if newBalance.Sign() < 0 {
+		return ErrInsufficientFunds
+	}
+
+	account.balance = newBalance
+	return nil
+}
+
+// SubFromBalance subtracts the provided value from the balance
+func (account *overlayAccount) SubFromBalance(value *big.Int) error {
+	return account.AddToBalance(big.NewInt(0).Neg(vmcommon.ZeroValueIfNil(value)))
+}
+
+// GetBalance returns a copy of the balance
+func (account *overlayAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(account.balance)
+}
+
+// ClaimDeveloperRewards returns the developer reward and resets it, if the sender is the owner
+func (account *overlayAccount) ClaimDeveloperRewards(sndAddress []byte) (*big.Int, error) {
+	if !bytes.Equal(sndAddress, account.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	reward := account.developerReward
+	account.developerReward = big.NewInt(0)
+
+	return reward, nil
+}
+
+// GetDeveloperReward returns a copy of the developer reward
+func (account *overlayAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(account.developerReward)
+}
+
+// ChangeOwnerAddress sets the new owner, if the sender is the current owner
+func (account *overlayAccount) ChangeOwnerAddress(sndAddress []byte, newAddress []byte) error {
+	if !bytes.Equal(sndAddress, account.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(account.address) {
+		return ErrInvalidAddressLength
+	}
+
+	account.ownerAddress = copyBytes(newAddress)
+	return nil
+}
+
+// SetOwnerAddress sets the owner
+func (account *overlayAccount) SetOwnerAddress(address []byte) {
+	account.ownerAddress = copyBytes(address)
+}
+
+// GetOwnerAddress returns the owner
+func (account *overlayAccount) GetOwnerAddress() []byte {
+	return account.ownerAddress
+}
+
+// SetUserName sets the user name
+func (account *overlayAccount) SetUserName(userName []byte) {
+	account.userName = copyBytes(userName)
+}
+
+// GetUserName returns the user name
+func (account *overlayAccount) GetUserName() []byte {
+	return account.userName
+}
+
+func (account *overlayAccount) clone() *overlayAccount {
+	return &overlayAccount{
+		address:         copyBytes(account.address),
+		nonce:           account.nonce,
+		balance:         copyBigInt(account.balance),
+		developerReward: copyBigInt(account.developerReward),
+		codeHash:        copyBytes(account.codeHash),
+		rootHash:        copyBytes(account.rootHash),
+		codeMetadata:    copyBytes(account.codeMetadata),
+		ownerAddress:    copyBytes(account.ownerAddress),
+		userName:        copyBytes(account.userName),
+		dataHandler:     account.dataHandler.clone(),
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (account *overlayAccount) IsInterfaceNil() bool {
+	return account == nil
+}
+
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
+
+func copyBigInt(value *big.Int) *big.Int {
+	return big.NewInt(0).Set(vmcommon.ZeroValueIfNil(value))
+}
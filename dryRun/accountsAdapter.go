@@ -0,0 +1,288 @@
+package dryRun
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var log = logger.GetOrCreate("dryRun")
+
+var _ vmcommon.AccountsAdapter = (*accountsAdapter)(nil)
+
+// StorageChange is a storage key written during the dry run. An empty value means that the key was removed.
+type StorageChange struct {
+	Address []byte
+	Key     []byte
+	Value   []byte
+}
+
+// journalEntry holds the overlay state of an account before it was saved or removed. A nil previous account means
+// that the account was not in the overlay.
+type journalEntry struct {
+	address    string
+	previous   *overlayAccount
+	wasRemoved bool
+}
+
+// accountsAdapter is a copy-on-write overlay over a base accounts adapter. Accounts are read from the base adapter
+// and wrapped, while saved and removed accounts only change the overlay, so that the base state is never modified.
+type accountsAdapter struct {
+	mut      sync.RWMutex
+	base     vmcommon.AccountsAdapter
+	accounts map[string]*overlayAccount
+	removed  map[string]struct{}
+	journal  []*journalEntry
+}
+
+// NewAccountsAdapter creates a dry run overlay over the provided accounts adapter. To run a built-in function in
+// simulate mode, the function must be created by a built-in functions creator that uses this overlay as accounts
+// adapter, as some functions load and save accounts, like the system account, on their own.
+func NewAccountsAdapter(base vmcommon.AccountsAdapter) (*accountsAdapter, error) {
+	if check.IfNil(base) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	return &accountsAdapter{
+		base:     base,
+		accounts: make(map[string]*overlayAccount),
+		removed:  make(map[string]struct{}),
+		journal:  make([]*journalEntry, 0),
+	}, nil
+}
+
+// GetExistingAccount returns a copy-on-write account, or ErrAccountNotFound if it was removed in the overlay
+func (adapter *accountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return adapter.getAccount(address, false)
+}
+
+// LoadAccount returns a copy-on-write account, which is new if the account does not exist
+func (adapter *accountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return adapter.getAccount(address, true)
+}
+
+func (adapter *accountsAdapter) getAccount(address []byte, createIfMissing bool) (vmcommon.AccountHandler, error) {
+	adapter.mut.RLock()
+	account, found := adapter.accounts[string(address)]
+	_, removed := adapter.removed[string(address)]
+	adapter.mut.RUnlock()
+
+	if found {
+		return account.clone(), nil
+	}
+	if removed {
+		if !createIfMissing {
+			return nil, ErrAccountNotFound
+		}
+		return newEmptyOverlayAccount(address), nil
+	}
+
+	getBaseAccount := adapter.base.GetExistingAccount
+	if createIfMissing {
+		getBaseAccount = adapter.base.LoadAccount
+	}
+	baseAccount, err := getBaseAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	userAccount, ok := baseAccount.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return newOverlayAccount(userAccount), nil
+}
+
+// SaveAccount keeps a copy of the provided account in the overlay. The account must have been loaded from the overlay.
+func (adapter *accountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccount
+	}
+	overlayAcc, ok := account.(*overlayAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	address := string(overlayAcc.address)
+	adapter.addJournalEntry(address)
+	adapter.accounts[address] = overlayAcc.clone()
+	delete(adapter.removed, address)
+
+	return nil
+}
+
+// RemoveAccount marks the account as removed in the overlay
+func (adapter *accountsAdapter) RemoveAccount(address []byte) error {
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	adapter.addJournalEntry(string(address))
+	delete(adapter.accounts, string(address))
+	adapter.removed[string(address)] = struct{}{}
+
+	return nil
+}
+
+func (adapter *accountsAdapter) addJournalEntry(address string) {
+	_, wasRemoved := adapter.removed[address]
+	adapter.journal = append(adapter.journal, &journalEntry{
+		address:    address,
+		previous:   adapter.accounts[address],
+		wasRemoved: wasRemoved,
+	})
+}
+
+// Commit returns ErrCommitNotAllowed, as the overlay changes must never reach the base state
+func (adapter *accountsAdapter) Commit() ([]byte, error) {
+	return nil, ErrCommitNotAllowed
+}
+
+// JournalLen returns the number of overlay changes that can be reverted
+func (adapter *accountsAdapter) JournalLen() int {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	return len(adapter.journal)
+}
+
+// RevertToSnapshot undoes the overlay changes made after the provided journal length
+func (adapter *accountsAdapter) RevertToSnapshot(snapshot int) error {
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	if snapshot < 0 || snapshot > len(adapter.journal) {
+		return ErrInvalidSnapshot
+	}
+
+	for i := len(adapter.journal) - 1; i >= snapshot; i-- {
+		entry := adapter.journal[i]
+		delete(adapter.accounts, entry.address)
+		delete(adapter.removed, entry.address)
+		if entry.previous != nil {
+			adapter.accounts[entry.address] = entry.previous
+		}
+		if entry.wasRemoved {
+			adapter.removed[entry.address] = struct{}{}
+		}
+	}
+	adapter.journal = adapter.journal[:snapshot]
+
+	return nil
+}
+
+// Reset discards all the overlay changes
+func (adapter *accountsAdapter) Reset() {
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	adapter.accounts = make(map[string]*overlayAccount)
+	adapter.removed = make(map[string]struct{})
+	adapter.journal = make([]*journalEntry, 0)
+}
+
+// GetCode returns the code from the base accounts adapter
+func (adapter *accountsAdapter) GetCode(codeHash []byte) []byte {
+	return adapter.base.GetCode(codeHash)
+}
+
+// RootHash returns the root hash of the base state, which does not include the overlay changes
+func (adapter *accountsAdapter) RootHash() ([]byte, error) {
+	return adapter.base.RootHash()
+}
+
+// ChangeSet returns the storage keys written in the saved accounts, sorted by address and key. The accounts
+// removed in the overlay are not included.
+func (adapter *accountsAdapter) ChangeSet() []*StorageChange {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	changes := make([]*StorageChange, 0)
+	for _, account := range adapter.accounts {
+		for key, value := range account.dataHandler.writes {
+			changes = append(changes, newStorageChange(account.address, key, value))
+		}
+	}
+	sortStorageChanges(changes)
+
+	return changes
+}
+
+// changeSetSince returns the storage keys written in the accounts saved after the provided journal length, sorted by
+// address and key. The keys that hold the same value they had at the snapshot are not included.
+func (adapter *accountsAdapter) changeSetSince(snapshot int) []*StorageChange {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	changes := make([]*StorageChange, 0)
+	for address, previous := range adapter.previousAccountsSince(snapshot) {
+		account, found := adapter.accounts[address]
+		if !found {
+			continue
+		}
+
+		for key, value := range account.dataHandler.writes {
+			if previous != nil && previous.dataHandler.hasSameWrite(account.dataHandler, key) {
+				continue
+			}
+			changes = append(changes, newStorageChange(account.address, key, value))
+		}
+	}
+	sortStorageChanges(changes)
+
+	return changes
+}
+
+// previousAccountsSince returns the overlay accounts held at the provided journal length by the addresses that were
+// saved or removed since then. The caller must hold the mutex.
+func (adapter *accountsAdapter) previousAccountsSince(snapshot int) map[string]*overlayAccount {
+	previousAccounts := make(map[string]*overlayAccount)
+	for _, entry := range adapter.journal[snapshot:] {
+		_, found := previousAccounts[entry.address]
+		if found {
+			continue
+		}
+		previousAccounts[entry.address] = entry.previous
+	}
+
+	return previousAccounts
+}
+
+func newStorageChange(address []byte, key string, value []byte) *StorageChange {
+	return &StorageChange{
+		Address: copyBytes(address),
+		Key:     []byte(key),
+		Value:   copyBytes(value),
+	}
+}
+
+func sortStorageChanges(changes []*StorageChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		addressCompare := bytes.Compare(changes[i].Address, changes[j].Address)
+		if addressCompare != 0 {
+			return addressCompare < 0
+		}
+		return bytes.Compare(changes[i].Key, changes[j].Key) < 0
+	})
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adapter *accountsAdapter) IsInterfaceNil() bool {
+	return adapter == nil
+}
+
+func newEmptyOverlayAccount(address []byte) *overlayAccount {
+	return &overlayAccount{
+		address:         copyBytes(address),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+		dataHandler:     newOverlayDataHandler(nil),
+	}
+}
@@ -0,0 +1,127 @@
+package dryRun
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/inMemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = []byte("alice___________________________")
+	bob   = []byte("bob_____________________________")
+)
+
+func createBaseAccounts(t *testing.T) vmcommon.AccountsAdapter {
+	base := inMemory.NewAccountsAdapter()
+	account := inMemory.NewUserAccount(alice)
+	require.Nil(t, account.AddToBalance(big.NewInt(100)))
+	require.Nil(t, account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value")))
+	account.SetOwnerAddress(alice)
+	require.Nil(t, base.SaveAccount(account))
+	_, err := base.Commit()
+	require.Nil(t, err)
+
+	return base
+}
+
+func TestNewAccountsAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter, err := NewAccountsAdapter(nil)
+	assert.Nil(t, adapter)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	adapter, err = NewAccountsAdapter(inMemory.NewAccountsAdapter())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(adapter))
+}
+
+func TestAccountsAdapter_ShouldNotChangeTheBaseState(t *testing.T) {
+	t.Parallel()
+
+	base := createBaseAccounts(t)
+	rootHash, _ := base.RootHash()
+	adapter, _ := NewAccountsAdapter(base)
+
+	account, err := adapter.LoadAccount(alice)
+	require.Nil(t, err)
+	userAccount := account.(*overlayAccount)
+	assert.Equal(t, big.NewInt(100), userAccount.GetBalance())
+	value, _, _ := userAccount.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+
+	require.Nil(t, userAccount.SubFromBalance(big.NewInt(40)))
+	assert.Equal(t, ErrInsufficientFunds, userAccount.SubFromBalance(big.NewInt(100)))
+	userAccount.IncreaseNonce(1)
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue([]byte("key"), nil))
+	require.Nil(t, userAccount.AccountDataHandler().SaveKeyValue([]byte("new"), []byte("data")))
+	require.Nil(t, userAccount.ChangeOwnerAddress(alice, bob))
+	require.Nil(t, adapter.SaveAccount(userAccount))
+
+	reloaded, _ := adapter.GetExistingAccount(alice)
+	assert.Equal(t, big.NewInt(60), reloaded.(*overlayAccount).GetBalance())
+	assert.Equal(t, uint64(1), reloaded.GetNonce())
+	assert.Equal(t, bob, reloaded.(*overlayAccount).GetOwnerAddress())
+	value, _, _ = reloaded.(*overlayAccount).AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Empty(t, value)
+
+	assert.Equal(t, []*StorageChange{
+		{Address: alice, Key: []byte("key"), Value: nil},
+		{Address: alice, Key: []byte("new"), Value: []byte("data")},
+	}, adapter.ChangeSet())
+
+	baseAccount, _ := base.GetExistingAccount(alice)
+	assert.Equal(t, uint64(0), baseAccount.GetNonce())
+	baseRootHash, _ := adapter.RootHash()
+	assert.Equal(t, rootHash, baseRootHash)
+	_, err = adapter.Commit()
+	assert.Equal(t, ErrCommitNotAllowed, err)
+}
+
+func TestAccountsAdapter_RemoveAndRevert(t *testing.T) {
+	t.Parallel()
+
+	adapter, _ := NewAccountsAdapter(createBaseAccounts(t))
+
+	_, err := adapter.GetExistingAccount(bob)
+	assert.Equal(t, inMemory.ErrAccountNotFound, err)
+	assert.Equal(t, ErrWrongTypeAssertion, adapter.SaveAccount(inMemory.NewUserAccount(bob)))
+	assert.Equal(t, ErrNilAccount, adapter.SaveAccount(nil))
+
+	account, _ := adapter.LoadAccount(bob)
+	_ = account.(*overlayAccount).AddToBalance(big.NewInt(5))
+	require.Nil(t, adapter.SaveAccount(account))
+	snapshot := adapter.JournalLen()
+
+	require.Nil(t, adapter.RemoveAccount(alice))
+	_, err = adapter.GetExistingAccount(alice)
+	assert.Equal(t, ErrAccountNotFound, err)
+	account, _ = adapter.LoadAccount(alice)
+	assert.Equal(t, big.NewInt(0), account.(*overlayAccount).GetBalance())
+	value, _, _ := account.(*overlayAccount).AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Nil(t, value, "a removed account must not read the base data")
+
+	assert.Equal(t, ErrInvalidSnapshot, adapter.RevertToSnapshot(adapter.JournalLen()+1))
+	require.Nil(t, adapter.RevertToSnapshot(snapshot))
+	account, err = adapter.GetExistingAccount(alice)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), account.(*overlayAccount).GetBalance())
+	account, _ = adapter.GetExistingAccount(bob)
+	assert.Equal(t, big.NewInt(5), account.(*overlayAccount).GetBalance())
+
+	require.Nil(t, adapter.RevertToSnapshot(0))
+	_, err = adapter.GetExistingAccount(bob)
+	assert.Equal(t, inMemory.ErrAccountNotFound, err)
+
+	account, _ = adapter.LoadAccount(bob)
+	_ = account.(*overlayAccount).AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	_ = adapter.SaveAccount(account)
+	adapter.Reset()
+	assert.Empty(t, adapter.ChangeSet())
+	assert.Equal(t, 0, adapter.JournalLen())
+}
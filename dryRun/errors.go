@@ -0,0 +1,36 @@
+package dryRun
+
+import "errors"
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter was provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilAccount signals that a nil account was provided
+var ErrNilAccount = errors.New("nil account")
+
+// ErrNilBuiltInFunction signals that a nil built-in function was provided
+var ErrNilBuiltInFunction = errors.New("nil built-in function")
+
+// ErrNilVMInput signals that a nil VM input was provided
+var ErrNilVMInput = errors.New("nil VM input")
+
+// ErrAccountNotFound signals that the account does not exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrWrongTypeAssertion signals that the provided object is not of the expected type
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrInvalidSnapshot signals that the snapshot to revert to is out of the journal bounds
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrCommitNotAllowed signals that a dry run overlay can not be committed
+var ErrCommitNotAllowed = errors.New("commit is not allowed on a dry run overlay")
+
+// ErrInsufficientFunds signals that the balance is too low for the requested operation
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrOperationNotPermitted signals that the caller is not allowed to perform the operation on the account
+var ErrOperationNotPermitted = errors.New("operation not permitted")
+
+// ErrInvalidAddressLength signals that the address has an invalid length
+var ErrInvalidAddressLength = errors.New("invalid address length")
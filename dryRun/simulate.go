@@ -0,0 +1,120 @@
+package dryRun
+
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// SimulationResult holds the outcome of a simulated built-in function call
+type SimulationResult struct {
+	VMOutput *vmcommon.VMOutput
+	// Changes contains the storage keys written by this call. ChangeSet returns all the keys written in the overlay.
+	Changes []*StorageChange
+}
+
+// Simulate runs the built-in function against the overlay. The sender and the destination are loaded from the
+// overlay and always saved back into it if the call succeeds, as the node does. If the function saved an account with
+// the same address on its own, like the system account, the storage keys it wrote are kept as well. A failed call
+// leaves the overlay unchanged. The function must have been created on top of this overlay, see NewAccountsAdapter.
+func (adapter *accountsAdapter) Simulate(function vmcommon.BuiltinFunction, input *vmcommon.ContractCallInput) (*SimulationResult, error) {
+	if check.IfNil(function) {
+		return nil, ErrNilBuiltInFunction
+	}
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	snapshot := adapter.JournalLen()
+	vmOutput, err := adapter.simulate(function, input, snapshot)
+	if err != nil {
+		errRevert := adapter.RevertToSnapshot(snapshot)
+		if errRevert != nil {
+			log.Warn("dryRun.Simulate: revert to snapshot", "error", errRevert)
+		}
+
+		return nil, err
+	}
+
+	return &SimulationResult{
+		VMOutput: vmOutput,
+		Changes:  adapter.changeSetSince(snapshot),
+	}, nil
+}
+
+func (adapter *accountsAdapter) simulate(function vmcommon.BuiltinFunction, input *vmcommon.ContractCallInput, snapshot int) (*vmcommon.VMOutput, error) {
+	sender, err := adapter.loadUserAccount(input.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := sender
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		destination, err = adapter.loadUserAccount(input.RecipientAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(sender, destination, input)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range []vmcommon.UserAccountHandler{sender, destination} {
+		if check.IfNil(account) {
+			continue
+		}
+
+		overlayAcc := account.(*overlayAccount)
+		adapter.mergeWritesSavedSince(overlayAcc, snapshot)
+		err = adapter.SaveAccount(overlayAcc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vmOutput, nil
+}
+
+func (adapter *accountsAdapter) loadUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	if len(address) == 0 {
+		return nil, nil
+	}
+
+	account, err := adapter.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.(*overlayAccount), nil
+}
+
+// mergeWritesSavedSince copies into the account the storage keys written by another copy of the same account that
+// was saved after the provided journal length. The keys written by the account itself take precedence.
+func (adapter *accountsAdapter) mergeWritesSavedSince(account *overlayAccount, snapshot int) {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	address := string(account.address)
+	previous, changed := adapter.previousAccountsSince(snapshot)[address]
+	saved, found := adapter.accounts[address]
+	if !changed || !found {
+		return
+	}
+
+	previousWrites := newOverlayDataHandler(nil)
+	if previous != nil {
+		previousWrites = previous.dataHandler
+	}
+	for key, value := range saved.dataHandler.writes {
+		if previousWrites.hasSameWrite(saved.dataHandler, key) {
+			continue
+		}
+		if !previousWrites.hasSameWrite(account.dataHandler, key) {
+			continue
+		}
+		account.dataHandler.writes[key] = copyBytes(value)
+	}
+}
@@ -0,0 +1,161 @@
+package dryRun
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/inMemory"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var token = []byte("TOKEN-abcdef")
+
+func createSimulationEnvironment(t *testing.T) (vmcommon.AccountsAdapter, *accountsAdapter, vmcommon.BuiltInFunctionContainer) {
+	base := inMemory.NewAccountsAdapter()
+	account := inMemory.NewUserAccount(alice)
+	esdtData, _ := (&mock.MarshalizerMock{}).Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	esdtTokenKey := []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + string(token))
+	require.Nil(t, account.AccountDataHandler().SaveKeyValue(esdtTokenKey, esdtData))
+	require.Nil(t, base.SaveAccount(account))
+	_, _ = base.Commit()
+
+	overlay, _ := NewAccountsAdapter(base)
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           mock.FillGasMapInternal(make(map[string]map[string]uint64), 1),
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      &mock.MarshalizerMock{},
+		Accounts:                         overlay,
+		ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
+		GuardedAccountHandler:            &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	return base, overlay, creator.BuiltInFunctionContainer()
+}
+
+func TestAccountsAdapter_Simulate(t *testing.T) {
+	t.Parallel()
+
+	base, overlay, container := createSimulationEnvironment(t)
+	rootHash, _ := base.RootHash()
+	transfer, _ := container.Get(core.BuiltInFunctionESDTTransfer)
+	pause, _ := container.Get(core.BuiltInFunctionESDTPause)
+
+	_, err := overlay.Simulate(nil, &vmcommon.ContractCallInput{})
+	assert.Equal(t, ErrNilBuiltInFunction, err)
+	_, err = overlay.Simulate(transfer, nil)
+	assert.Equal(t, ErrNilVMInput, err)
+
+	result, err := overlay.Simulate(transfer, &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments:   [][]byte{token, big.NewInt(30).Bytes()},
+		},
+		RecipientAddr: bob,
+		Function:      core.BuiltInFunctionESDTTransfer,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+	esdtTokenKey := []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + string(token))
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, alice, result.Changes[0].Address)
+	assert.Equal(t, bob, result.Changes[1].Address)
+	assert.Equal(t, esdtTokenKey, result.Changes[1].Key)
+
+	result, err = overlay.Simulate(pause, &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.ESDTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{token},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+		Function:      core.BuiltInFunctionESDTPause,
+	})
+	require.Nil(t, err)
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, vmcommon.SystemAccountAddress, result.Changes[0].Address, "the function save must not be overwritten")
+	assert.Len(t, overlay.ChangeSet(), 3)
+
+	snapshot := overlay.JournalLen()
+	_, err = overlay.Simulate(transfer, &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments:   [][]byte{token, big.NewInt(10).Bytes()},
+		},
+		RecipientAddr: bob,
+		Function:      core.BuiltInFunctionESDTTransfer,
+	})
+	assert.ErrorIs(t, err, builtInFunctions.ErrESDTTokenIsPaused)
+	assert.Equal(t, snapshot, overlay.JournalLen())
+
+	rootHashAfter, _ := base.RootHash()
+	assert.Equal(t, rootHash, rootHashAfter)
+	_, err = base.GetExistingAccount(bob)
+	assert.Equal(t, inMemory.ErrAccountNotFound, err)
+}
+
+func TestAccountsAdapter_SimulateShouldSaveThePassedAccounts(t *testing.T) {
+	t.Parallel()
+
+	_, overlay, _ := createSimulationEnvironment(t)
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("sender"), []byte("passed"))
+			_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("shared"), []byte("passed"))
+			_ = acntDst.AccountDataHandler().SaveKeyValue([]byte("destination"), []byte("passed"))
+
+			ownCopy, _ := overlay.LoadAccount(alice)
+			_ = ownCopy.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("function"), []byte("own"))
+			_ = ownCopy.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("shared"), []byte("own"))
+			_ = overlay.SaveAccount(ownCopy)
+
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: alice,
+			CallValue:  big.NewInt(0),
+		},
+		RecipientAddr: bob,
+	}
+
+	result, err := overlay.Simulate(function, input)
+	require.Nil(t, err)
+	expectedChanges := []*StorageChange{
+		{Address: alice, Key: []byte("function"), Value: []byte("own")},
+		{Address: alice, Key: []byte("sender"), Value: []byte("passed")},
+		{Address: alice, Key: []byte("shared"), Value: []byte("passed")},
+		{Address: bob, Key: []byte("destination"), Value: []byte("passed")},
+	}
+	assert.Equal(t, expectedChanges, result.Changes)
+
+	function.ProcessBuiltinFunctionCalled = func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("sender"), []byte("passed"))
+		_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("second"), []byte("call"))
+
+		return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+	}
+	result, err = overlay.Simulate(function, input)
+	require.Nil(t, err)
+	expectedChanges = []*StorageChange{
+		{Address: alice, Key: []byte("second"), Value: []byte("call")},
+	}
+	assert.Equal(t, expectedChanges, result.Changes, "only the changes of the call must be returned")
+	assert.Len(t, overlay.ChangeSet(), 5)
+}
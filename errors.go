@@ -16,3 +16,39 @@ var ErrTransfersNotIndexed = errors.New("unindexed transfers found")
 
 // ErrNilTransferIndexer signals that the provided transfer indexer is nil
 var ErrNilTransferIndexer = errors.New("nil NextOutputTransferIndexProvider")
+
+// ErrNilVMOutput signals that a nil VMOutput was provided
+var ErrNilVMOutput = errors.New("nil VMOutput")
+
+// ErrInvalidVMOutput signals that the VMOutput is not internally consistent
+var ErrInvalidVMOutput = errors.New("invalid VMOutput")
+
+// ErrUnknownReturnCode signals that an unknown return code name was provided
+var ErrUnknownReturnCode = errors.New("unknown return code")
+
+// ErrUnknownCallType signals that an unknown call type name was provided
+var ErrUnknownCallType = errors.New("unknown call type")
+
+// ErrNilAddressEncoder signals that a nil address encoder was provided
+var ErrNilAddressEncoder = errors.New("nil address encoder")
+
+// ErrNilVMInput signals that a nil VM input was provided
+var ErrNilVMInput = errors.New("nil VM input")
+
+// ErrInvalidBigIntString signals that a string could not be parsed as a decimal big integer
+var ErrInvalidBigIntString = errors.New("invalid big int string")
+
+// ErrInvalidHRP signals that an invalid bech32 human-readable part was provided
+var ErrInvalidHRP = errors.New("invalid bech32 human-readable part")
+
+// ErrInvalidBech32Address signals that an address could not be converted to or from bech32
+var ErrInvalidBech32Address = errors.New("invalid bech32 address")
+
+// ErrInvalidCreatorAddress signals that an invalid creator address was provided
+var ErrInvalidCreatorAddress = errors.New("invalid creator address")
+
+// ErrInvalidGasSchedule signals that the gas schedule is not valid
+var ErrInvalidGasSchedule = errors.New("invalid gas schedule")
+
+// ErrUnknownGasScheduleFormat signals that the gas schedule format is not supported
+var ErrUnknownGasScheduleFormat = errors.New("unknown gas schedule format")
@@ -0,0 +1,285 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/pelletier/go-toml"
+)
+
+// GasScheduleFormat defines the format of a gas schedule file
+type GasScheduleFormat string
+
+const (
+	// GasScheduleTOML is the TOML gas schedule format, as used by the node configuration files
+	GasScheduleTOML GasScheduleFormat = "toml"
+	// GasScheduleJSON is the JSON gas schedule format
+	GasScheduleJSON GasScheduleFormat = "json"
+)
+
+// GasScheduleError holds all the problems found while loading or validating a gas schedule
+type GasScheduleError struct {
+	Problems []string
+}
+
+// Error returns all the problems, separated by semicolons
+func (e *GasScheduleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidGasSchedule.Error(), strings.Join(e.Problems, "; "))
+}
+
+// Unwrap returns ErrInvalidGasSchedule
+func (e *GasScheduleError) Unwrap() error {
+	return ErrInvalidGasSchedule
+}
+
+// GasCostChange holds one field of the gas cost that differs between two gas schedule versions
+type GasCostChange struct {
+	Section  string
+	Field    string
+	OldValue uint64
+	NewValue uint64
+}
+
+// String returns the human-readable form of the change
+func (change *GasCostChange) String() string {
+	return fmt.Sprintf("%s.%s: %d -> %d", change.Section, change.Field, change.OldValue, change.NewValue)
+}
+
+// LoadGasScheduleFile reads the gas schedule from the provided file. The format is determined by the file extension,
+// which should be either .toml or .json
+func LoadGasScheduleFile(path string) (map[string]map[string]uint64, error) {
+	format := GasScheduleFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), "."))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadGasSchedule(data, format)
+}
+
+// LoadGasSchedule decodes the gas schedule from the provided data. All the sections are returned, not only the ones
+// used by the built-in functions, and every value must be a non-negative integer.
+func LoadGasSchedule(data []byte, format GasScheduleFormat) (map[string]map[string]uint64, error) {
+	var raw map[string]interface{}
+	switch format {
+	case GasScheduleTOML:
+		tree, err := toml.LoadBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		raw = tree.ToMap()
+	case GasScheduleJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err := decoder.Decode(&raw)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownGasScheduleFormat, format)
+	}
+
+	return convertRawGasSchedule(raw)
+}
+
+func convertRawGasSchedule(raw map[string]interface{}) (map[string]map[string]uint64, error) {
+	problems := make([]string, 0)
+	gasSchedule := make(map[string]map[string]uint64, len(raw))
+	for _, sectionName := range sortedKeys(raw) {
+		rawSection, ok := raw[sectionName].(map[string]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a section", sectionName))
+			continue
+		}
+
+		section := make(map[string]uint64, len(rawSection))
+		for _, field := range sortedKeys(rawSection) {
+			value, ok := gasValueToUint64(rawSection[field])
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s has invalid value %v", sectionName, field, rawSection[field]))
+				continue
+			}
+			section[field] = value
+		}
+		gasSchedule[sectionName] = section
+	}
+
+	if len(problems) > 0 {
+		return nil, &GasScheduleError{Problems: problems}
+	}
+
+	return gasSchedule, nil
+}
+
+func gasValueToUint64(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return uint64(v), v >= 0
+	case uint64:
+		return v, true
+	case json.Number:
+		var parsed uint64
+		_, err := fmt.Sscan(v.String(), &parsed)
+		return parsed, err == nil && fmt.Sprint(parsed) == v.String()
+	case float64:
+		// math.MaxUint64 rounds up to 2^64 as a float64, so the upper bound must be exclusive
+		isInteger := v >= 0 && v < float64(1<<64) && v == math.Trunc(v)
+		if !isInteger {
+			return 0, false
+		}
+		return uint64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// NewGasCostFromGasSchedule creates the GasCost from the BaseOperationCost and BuiltInCost sections of the provided
+// gas schedule. Unlike a plain decode, it rejects missing sections, missing fields and unknown fields
+// inside those two sections, reporting all the problems found at once. Field names are matched case-insensitively.
+func NewGasCostFromGasSchedule(gasSchedule map[string]map[string]uint64) (*GasCost, error) {
+	gasCost := &GasCost{}
+	problems := make([]string, 0)
+	problems = append(problems, fillGasCostSection(core.BaseOperationCostString, gasSchedule, &gasCost.BaseOperationCost)...)
+	problems = append(problems, fillGasCostSection(core.BuiltInCostString, gasSchedule, &gasCost.BuiltInCost)...)
+	if len(problems) > 0 {
+		return nil, &GasScheduleError{Problems: problems}
+	}
+
+	return gasCost, nil
+}
+
+// LoadGasCostFromFile loads the gas schedule file and creates the validated GasCost out of it
+func LoadGasCostFromFile(path string) (*GasCost, error) {
+	gasSchedule, err := LoadGasScheduleFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGasCostFromGasSchedule(gasSchedule)
+}
+
+func fillGasCostSection(sectionName string, gasSchedule map[string]map[string]uint64, destination interface{}) []string {
+	section, found := gasSchedule[sectionName]
+	if !found {
+		return []string{fmt.Sprintf("missing section %s", sectionName)}
+	}
+
+	problems := make([]string, 0)
+	destValue := reflect.ValueOf(destination).Elem()
+	destType := destValue.Type()
+	fieldIndexes := gasCostFieldIndexes(destType)
+
+	setFields := make(map[int]string, destType.NumField())
+	for _, key := range sortedKeys(section) {
+		index, known := fieldIndexes[strings.ToLower(key)]
+		if !known {
+			problems = append(problems, fmt.Sprintf("unknown field %s.%s", sectionName, key))
+			continue
+		}
+		previousKey, alreadySet := setFields[index]
+		if alreadySet {
+			problems = append(problems, fmt.Sprintf("duplicate field %s.%s, already set as %s", sectionName, key, previousKey))
+			continue
+		}
+
+		setFields[index] = key
+		destValue.Field(index).SetUint(section[key])
+	}
+
+	for i := 0; i < destType.NumField(); i++ {
+		fieldName := destType.Field(i).Name
+		_, isSet := setFields[i]
+		if !isSet {
+			problems = append(problems, fmt.Sprintf("missing field %s.%s", sectionName, fieldName))
+		}
+	}
+
+	return problems
+}
+
+func gasCostFieldIndexes(sectionType reflect.Type) map[string]int {
+	fieldIndexes := make(map[string]int, sectionType.NumField())
+	for i := 0; i < sectionType.NumField(); i++ {
+		fieldIndexes[strings.ToLower(sectionType.Field(i).Name)] = i
+	}
+
+	return fieldIndexes
+}
+
+// UnknownGasCostFields returns the fields of the BaseOperationCost and BuiltInCost sections of the gas schedule that
+// do not match any gas cost field, as section.field, ordered by section and field name. Field names are matched
+// case-insensitively, as in NewGasCostFromGasSchedule.
+func UnknownGasCostFields(gasSchedule map[string]map[string]uint64) []string {
+	unknownFields := make([]string, 0)
+	unknownFields = append(unknownFields, unknownGasCostSectionFields(core.BaseOperationCostString, gasSchedule, reflect.TypeOf(BaseOperationCost{}))...)
+	unknownFields = append(unknownFields, unknownGasCostSectionFields(core.BuiltInCostString, gasSchedule, reflect.TypeOf(BuiltInCost{}))...)
+
+	return unknownFields
+}
+
+func unknownGasCostSectionFields(sectionName string, gasSchedule map[string]map[string]uint64, sectionType reflect.Type) []string {
+	fieldIndexes := gasCostFieldIndexes(sectionType)
+	unknownFields := make([]string, 0)
+	for _, key := range sortedKeys(gasSchedule[sectionName]) {
+		_, known := fieldIndexes[strings.ToLower(key)]
+		if !known {
+			unknownFields = append(unknownFields, sectionName+"."+key)
+		}
+	}
+
+	return unknownFields
+}
+
+// DiffGasCosts returns all the fields that have different values in the two gas cost versions, ordered by
+// section and field name
+func DiffGasCosts(oldGasCost *GasCost, newGasCost *GasCost) []*GasCostChange {
+	if oldGasCost == nil {
+		oldGasCost = &GasCost{}
+	}
+	if newGasCost == nil {
+		newGasCost = &GasCost{}
+	}
+
+	changes := make([]*GasCostChange, 0)
+	changes = append(changes, diffGasCostSection(core.BaseOperationCostString, oldGasCost.BaseOperationCost, newGasCost.BaseOperationCost)...)
+	changes = append(changes, diffGasCostSection(core.BuiltInCostString, oldGasCost.BuiltInCost, newGasCost.BuiltInCost)...)
+
+	return changes
+}
+
+func diffGasCostSection(sectionName string, oldSection interface{}, newSection interface{}) []*GasCostChange {
+	oldValue := reflect.ValueOf(oldSection)
+	newValue := reflect.ValueOf(newSection)
+	sectionType := oldValue.Type()
+
+	changes := make([]*GasCostChange, 0)
+	for i := 0; i < sectionType.NumField(); i++ {
+		oldFieldValue := oldValue.Field(i).Uint()
+		newFieldValue := newValue.Field(i).Uint()
+		if oldFieldValue == newFieldValue {
+			continue
+		}
+
+		changes = append(changes, &GasCostChange{
+			Section:  sectionName,
+			Field:    sectionType.Field(i).Name,
+			OldValue: oldFieldValue,
+			NewValue: newFieldValue,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
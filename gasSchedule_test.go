@@ -0,0 +1,232 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGasScheduleSection(section interface{}) map[string]uint64 {
+	sectionType := reflect.TypeOf(section)
+	values := make(map[string]uint64, sectionType.NumField())
+	for i := 0; i < sectionType.NumField(); i++ {
+		values[sectionType.Field(i).Name] = uint64(i + 1)
+	}
+
+	return values
+}
+
+func createValidGasSchedule() map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: createGasScheduleSection(BaseOperationCost{}),
+		core.BuiltInCostString:       createGasScheduleSection(BuiltInCost{}),
+		"MetaChainSystemSCsCost":     {"Stake": 5000000},
+	}
+}
+
+func gasScheduleToTOML(gasSchedule map[string]map[string]uint64) string {
+	builder := strings.Builder{}
+	for _, sectionName := range sortedKeys(gasSchedule) {
+		builder.WriteString(fmt.Sprintf("[%s]\n", sectionName))
+		for _, field := range sortedKeys(gasSchedule[sectionName]) {
+			builder.WriteString(fmt.Sprintf("    %s = %d\n", field, gasSchedule[sectionName][field]))
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+func writeGasScheduleFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0644)
+	require.Nil(t, err)
+
+	return path
+}
+
+func TestLoadGasSchedule_TOMLAndJSONShouldLoadTheSameSchedule(t *testing.T) {
+	t.Parallel()
+
+	gasSchedule := createValidGasSchedule()
+	fromTOML, err := LoadGasSchedule([]byte(gasScheduleToTOML(gasSchedule)), GasScheduleTOML)
+	require.Nil(t, err)
+	assert.Equal(t, gasSchedule, fromTOML)
+
+	fromJSON, err := LoadGasSchedule([]byte(`{"BuiltInCost": {"ESDTTransfer": 200000}, "Other": {"Field": 1}}`), GasScheduleJSON)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]map[string]uint64{
+		"BuiltInCost": {"ESDTTransfer": 200000},
+		"Other":       {"Field": 1},
+	}, fromJSON)
+}
+
+func TestLoadGasSchedule_InvalidValuesShouldReportEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	data := `{"BuiltInCost": {"ESDTTransfer": -1, "ESDTBurn": 1.5, "ESDTWipe": "10", "SaveKeyValue": 1}, "Flat": 3}`
+	gasSchedule, err := LoadGasSchedule([]byte(data), GasScheduleJSON)
+	require.Nil(t, gasSchedule)
+	require.True(t, errors.Is(err, ErrInvalidGasSchedule))
+
+	gasScheduleErr := &GasScheduleError{}
+	require.True(t, errors.As(err, &gasScheduleErr))
+	assert.Equal(t, []string{
+		"BuiltInCost.ESDTBurn has invalid value 1.5",
+		"BuiltInCost.ESDTTransfer has invalid value -1",
+		"BuiltInCost.ESDTWipe has invalid value 10",
+		"Flat is not a section",
+	}, gasScheduleErr.Problems)
+
+	_, err = LoadGasSchedule([]byte("[BuiltInCost]\nESDTTransfer = -5\n"), GasScheduleTOML)
+	assert.True(t, errors.Is(err, ErrInvalidGasSchedule))
+
+	_, err = LoadGasSchedule([]byte("[BuiltInCost]\nESDTTransfer = 1.8446744073709552e19\n"), GasScheduleTOML)
+	assert.True(t, errors.Is(err, ErrInvalidGasSchedule))
+
+	_, err = LoadGasSchedule([]byte("{}"), "yaml")
+	assert.True(t, errors.Is(err, ErrUnknownGasScheduleFormat))
+}
+
+func TestGasValueToUint64_FloatBounds(t *testing.T) {
+	t.Parallel()
+
+	value, ok := gasValueToUint64(1.8446744073709552e19)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), value)
+
+	value, ok = gasValueToUint64(float64(1 << 63))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1<<63), value)
+
+	_, ok = gasValueToUint64(-1.0)
+	assert.False(t, ok)
+}
+
+func TestNewGasCostFromGasSchedule_ValidScheduleShouldWork(t *testing.T) {
+	t.Parallel()
+
+	gasSchedule := createValidGasSchedule()
+	gasSchedule[core.BuiltInCostString]["esdttransfer"] = gasSchedule[core.BuiltInCostString]["ESDTTransfer"]
+	delete(gasSchedule[core.BuiltInCostString], "ESDTTransfer")
+
+	gasCost, err := NewGasCostFromGasSchedule(gasSchedule)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), gasCost.BaseOperationCost.StorePerByte)
+	assert.Equal(t, uint64(1), gasCost.BuiltInCost.ChangeOwnerAddress)
+	assert.Equal(t, uint64(5), gasCost.BuiltInCost.ESDTTransfer)
+}
+
+func TestNewGasCostFromGasSchedule_ShouldReportEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing section", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createValidGasSchedule()
+		delete(gasSchedule, core.BaseOperationCostString)
+
+		gasCost, err := NewGasCostFromGasSchedule(gasSchedule)
+		require.Nil(t, gasCost)
+		require.True(t, errors.Is(err, ErrInvalidGasSchedule))
+		assert.Equal(t, "invalid gas schedule: missing section BaseOperationCost", err.Error())
+	})
+	t.Run("missing, unknown and duplicate fields", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createValidGasSchedule()
+		delete(gasSchedule[core.BuiltInCostString], "ESDTBurn")
+		delete(gasSchedule[core.BaseOperationCostString], "CompilePerByte")
+		gasSchedule[core.BuiltInCostString]["ESDTBrun"] = 10
+		gasSchedule[core.BuiltInCostString]["esdtTransfer"] = 10
+
+		_, err := NewGasCostFromGasSchedule(gasSchedule)
+		gasScheduleErr := &GasScheduleError{}
+		require.True(t, errors.As(err, &gasScheduleErr))
+		assert.Equal(t, []string{
+			"missing field BaseOperationCost.CompilePerByte",
+			"unknown field BuiltInCost.ESDTBrun",
+			"duplicate field BuiltInCost.esdtTransfer, already set as ESDTTransfer",
+			"missing field BuiltInCost.ESDTBurn",
+		}, gasScheduleErr.Problems)
+	})
+}
+
+func TestUnknownGasCostFields(t *testing.T) {
+	t.Parallel()
+
+	gasSchedule := createValidGasSchedule()
+	assert.Empty(t, UnknownGasCostFields(gasSchedule))
+
+	gasSchedule[core.BuiltInCostString]["ESDTBrun"] = 10
+	gasSchedule[core.BuiltInCostString]["esdtTransfer"] = 10
+	gasSchedule[core.BaseOperationCostString]["GetCode"] = 10
+	gasSchedule["MaxPerTransaction"] = map[string]uint64{"MaxBuiltInCallsPerTx": 10}
+	assert.Equal(t, []string{"BaseOperationCost.GetCode", "BuiltInCost.ESDTBrun"}, UnknownGasCostFields(gasSchedule))
+	assert.Empty(t, UnknownGasCostFields(nil))
+}
+
+func TestLoadGasCostFromFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("toml file should work", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeGasScheduleFile(t, "gasSchedule.toml", gasScheduleToTOML(createValidGasSchedule()))
+		gasCost, err := LoadGasCostFromFile(path)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(6), gasCost.BaseOperationCost.AoTPreparePerByte)
+	})
+	t.Run("json file should work", func(t *testing.T) {
+		t.Parallel()
+
+		data := `{"BaseOperationCost": {"StorePerByte": 1, "ReleasePerByte": 2, "DataCopyPerByte": 3, "PersistPerByte": 4, "CompilePerByte": 5, "AoTPreparePerByte": 6}}`
+		path := writeGasScheduleFile(t, "gasSchedule.JSON", data)
+		_, err := LoadGasCostFromFile(path)
+		require.True(t, errors.Is(err, ErrInvalidGasSchedule))
+		assert.Equal(t, "invalid gas schedule: missing section BuiltInCost", err.Error())
+	})
+	t.Run("unknown extension should error", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeGasScheduleFile(t, "gasSchedule.txt", "")
+		_, err := LoadGasCostFromFile(path)
+		assert.True(t, errors.Is(err, ErrUnknownGasScheduleFormat))
+	})
+	t.Run("missing file should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadGasCostFromFile(filepath.Join(t.TempDir(), "missing.toml"))
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
+
+func TestDiffGasCosts(t *testing.T) {
+	t.Parallel()
+
+	oldGasCost, err := NewGasCostFromGasSchedule(createValidGasSchedule())
+	require.Nil(t, err)
+	newGasCost, err := NewGasCostFromGasSchedule(createValidGasSchedule())
+	require.Nil(t, err)
+	assert.Empty(t, DiffGasCosts(oldGasCost, newGasCost))
+
+	newGasCost.BuiltInCost.ESDTTransfer = 100
+	newGasCost.BuiltInCost.ChangeOwnerAddress = 200
+	newGasCost.BaseOperationCost.StorePerByte = 300
+
+	changes := DiffGasCosts(oldGasCost, newGasCost)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "BaseOperationCost.StorePerByte: 1 -> 300", changes[0].String())
+	assert.Equal(t, "BuiltInCost.ChangeOwnerAddress: 1 -> 200", changes[1].String())
+	assert.Equal(t, &GasCostChange{Section: "BuiltInCost", Field: "ESDTTransfer", OldValue: 5, NewValue: 100}, changes[2])
+
+	assert.Len(t, DiffGasCosts(nil, oldGasCost), reflect.TypeOf(BaseOperationCost{}).NumField()+reflect.TypeOf(BuiltInCost{}).NumField())
+}
@@ -0,0 +1,206 @@
+package inMemory
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.AccountsAdapter = (*accountsAdapter)(nil)
+
+// journalEntry holds the state of an account before it was saved or removed. A nil previous account means that
+// the account did not exist. When the save also stored code, the entry holds the code previously found under the
+// code hash, with a nil previous code meaning that there was none.
+type journalEntry struct {
+	address      string
+	previous     *userAccount
+	codeHash     string
+	savedCode    bool
+	previousCode []byte
+}
+
+// accountsAdapter keeps all the accounts in memory. Accounts are handed out as copies: changes are visible to other
+// callers only after SaveAccount, as it happens with the trie backed implementation.
+type accountsAdapter struct {
+	mut      sync.RWMutex
+	accounts map[string]*userAccount
+	codes    map[string][]byte
+	journal  []*journalEntry
+}
+
+// NewAccountsAdapter creates a new, empty, in-memory accounts adapter
+func NewAccountsAdapter() *accountsAdapter {
+	return &accountsAdapter{
+		accounts: make(map[string]*userAccount),
+		codes:    make(map[string][]byte),
+		journal:  make([]*journalEntry, 0),
+	}
+}
+
+// GetExistingAccount returns a copy of the account, or ErrAccountNotFound if it does not exist
+func (adapter *accountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	account, found := adapter.accounts[string(address)]
+	if !found {
+		return nil, ErrAccountNotFound
+	}
+
+	return account.clone(), nil
+}
+
+// LoadAccount returns a copy of the account, or a new account if it does not exist
+func (adapter *accountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	account, found := adapter.accounts[string(address)]
+	if !found {
+		return NewUserAccount(address), nil
+	}
+
+	return account.clone(), nil
+}
+
+// SaveAccount stores a copy of the provided account, which must have been created by this package
+func (adapter *accountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccount
+	}
+	userAcc, ok := account.(*userAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	address := string(userAcc.address)
+	entry := &journalEntry{
+		address:  address,
+		previous: adapter.accounts[address],
+	}
+	adapter.accounts[address] = userAcc.clone()
+	if len(userAcc.codeHash) > 0 {
+		entry.codeHash = string(userAcc.codeHash)
+		entry.savedCode = true
+		entry.previousCode = adapter.codes[entry.codeHash]
+		adapter.codes[entry.codeHash] = copyBytes(userAcc.code)
+	}
+	adapter.journal = append(adapter.journal, entry)
+
+	return nil
+}
+
+// RemoveAccount removes the account. Removing an account that does not exist is not an error.
+func (adapter *accountsAdapter) RemoveAccount(address []byte) error {
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	previous, found := adapter.accounts[string(address)]
+	if !found {
+		return nil
+	}
+
+	adapter.journal = append(adapter.journal, &journalEntry{
+		address:  string(address),
+		previous: previous,
+	})
+	delete(adapter.accounts, string(address))
+
+	return nil
+}
+
+// Commit clears the journal and returns the root hash
+func (adapter *accountsAdapter) Commit() ([]byte, error) {
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	adapter.journal = make([]*journalEntry, 0)
+
+	return adapter.computeRootHash(), nil
+}
+
+// JournalLen returns the number of changes since the last commit
+func (adapter *accountsAdapter) JournalLen() int {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	return len(adapter.journal)
+}
+
+// RevertToSnapshot undoes, in reverse order, all the changes made after the provided journal length
+func (adapter *accountsAdapter) RevertToSnapshot(snapshot int) error {
+	adapter.mut.Lock()
+	defer adapter.mut.Unlock()
+
+	if snapshot < 0 || snapshot > len(adapter.journal) {
+		return ErrInvalidSnapshot
+	}
+
+	for i := len(adapter.journal) - 1; i >= snapshot; i-- {
+		entry := adapter.journal[i]
+		adapter.revertCode(entry)
+		if entry.previous == nil {
+			delete(adapter.accounts, entry.address)
+			continue
+		}
+
+		adapter.accounts[entry.address] = entry.previous
+	}
+	adapter.journal = adapter.journal[:snapshot]
+
+	return nil
+}
+
+func (adapter *accountsAdapter) revertCode(entry *journalEntry) {
+	if !entry.savedCode {
+		return
+	}
+	if entry.previousCode == nil {
+		delete(adapter.codes, entry.codeHash)
+		return
+	}
+
+	adapter.codes[entry.codeHash] = entry.previousCode
+}
+
+// GetCode returns the code saved under the provided code hash
+func (adapter *accountsAdapter) GetCode(codeHash []byte) []byte {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	return copyBytes(adapter.codes[string(codeHash)])
+}
+
+// RootHash returns the hash of all the accounts, ordered by address. It only depends on the accounts' state.
+func (adapter *accountsAdapter) RootHash() ([]byte, error) {
+	adapter.mut.RLock()
+	defer adapter.mut.RUnlock()
+
+	return adapter.computeRootHash(), nil
+}
+
+func (adapter *accountsAdapter) computeRootHash() []byte {
+	addresses := make([]string, 0, len(adapter.accounts))
+	for address := range adapter.accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	buff := &bytes.Buffer{}
+	for _, address := range addresses {
+		writeLengthPrefixed(buff, adapter.accounts[address].hash())
+	}
+
+	return hasher.Compute(buff.String())
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adapter *accountsAdapter) IsInterfaceNil() bool {
+	return adapter == nil
+}
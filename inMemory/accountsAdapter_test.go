@@ -0,0 +1,131 @@
+package inMemory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsAdapter_LoadAndSaveAccount(t *testing.T) {
+	t.Parallel()
+
+	adapter := NewAccountsAdapter()
+	assert.False(t, check.IfNil(adapter))
+
+	account, err := adapter.GetExistingAccount([]byte("alice"))
+	assert.Nil(t, account)
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	loaded, err := adapter.LoadAccount([]byte("alice"))
+	require.Nil(t, err)
+	alice := loaded.(*userAccount)
+	_ = alice.AddToBalance(big.NewInt(100))
+	_ = alice.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	alice.SetCode([]byte("code"))
+
+	_, err = adapter.GetExistingAccount([]byte("alice"))
+	assert.Equal(t, ErrAccountNotFound, err, "changes must not be visible before SaveAccount")
+
+	require.Nil(t, adapter.SaveAccount(alice))
+	_ = alice.AddToBalance(big.NewInt(1))
+
+	account, err = adapter.GetExistingAccount([]byte("alice"))
+	require.Nil(t, err)
+	saved := account.(*userAccount)
+	assert.Equal(t, big.NewInt(100), saved.GetBalance(), "the adapter must keep its own copy")
+	value, _, _ := saved.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, []byte("code"), adapter.GetCode(saved.GetCodeHash()))
+
+	assert.Equal(t, ErrNilAccount, adapter.SaveAccount(nil))
+	assert.Equal(t, ErrWrongTypeAssertion, adapter.SaveAccount(&mock.UserAccountStub{}))
+}
+
+func TestAccountsAdapter_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	adapter := NewAccountsAdapter()
+	alice := NewUserAccount([]byte("alice"))
+	_ = alice.AddToBalance(big.NewInt(100))
+	require.Nil(t, adapter.SaveAccount(alice))
+	_, _ = adapter.Commit()
+	assert.Equal(t, 0, adapter.JournalLen())
+	rootHashAfterCommit, _ := adapter.RootHash()
+
+	_ = alice.AddToBalance(big.NewInt(50))
+	require.Nil(t, adapter.SaveAccount(alice))
+	snapshot := adapter.JournalLen()
+	require.Nil(t, adapter.SaveAccount(NewUserAccount([]byte("bob"))))
+	require.Nil(t, adapter.RemoveAccount([]byte("alice")))
+	assert.Equal(t, 3, adapter.JournalLen())
+
+	require.Nil(t, adapter.RevertToSnapshot(snapshot))
+	assert.Equal(t, snapshot, adapter.JournalLen())
+	_, err := adapter.GetExistingAccount([]byte("bob"))
+	assert.Equal(t, ErrAccountNotFound, err)
+	account, err := adapter.GetExistingAccount([]byte("alice"))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(150), account.(*userAccount).GetBalance())
+
+	require.Nil(t, adapter.RevertToSnapshot(0))
+	rootHash, _ := adapter.RootHash()
+	assert.Equal(t, rootHashAfterCommit, rootHash)
+
+	assert.Equal(t, ErrInvalidSnapshot, adapter.RevertToSnapshot(1))
+	assert.Equal(t, ErrInvalidSnapshot, adapter.RevertToSnapshot(-1))
+}
+
+func TestAccountsAdapter_RevertToSnapshotShouldRevertCode(t *testing.T) {
+	t.Parallel()
+
+	adapter := NewAccountsAdapter()
+	alice := NewUserAccount([]byte("alice"))
+	alice.SetCode([]byte("old code"))
+	require.Nil(t, adapter.SaveAccount(alice))
+	oldCodeHash := alice.GetCodeHash()
+	_, _ = adapter.Commit()
+
+	alice.SetCode([]byte("new code"))
+	require.Nil(t, adapter.SaveAccount(alice))
+	newCodeHash := alice.GetCodeHash()
+	assert.Equal(t, []byte("new code"), adapter.GetCode(newCodeHash))
+
+	require.Nil(t, adapter.RevertToSnapshot(0))
+	assert.Nil(t, adapter.GetCode(newCodeHash))
+	assert.Equal(t, []byte("old code"), adapter.GetCode(oldCodeHash))
+}
+
+func TestAccountsAdapter_RootHash(t *testing.T) {
+	t.Parallel()
+
+	createAdapter := func(addresses ...string) *accountsAdapter {
+		adapter := NewAccountsAdapter()
+		for _, address := range addresses {
+			account := NewUserAccount([]byte(address))
+			_ = account.AccountDataHandler().SaveKeyValue([]byte("owner"), []byte(address))
+			_ = adapter.SaveAccount(account)
+		}
+		return adapter
+	}
+
+	first, _ := createAdapter("alice", "bob", "carol").RootHash()
+	second, _ := createAdapter("carol", "alice", "bob").RootHash()
+	assert.Equal(t, first, second, "the root hash must not depend on the insertion order")
+
+	adapter := createAdapter("alice", "bob", "carol")
+	committed, _ := adapter.Commit()
+	assert.Equal(t, first, committed)
+
+	account, _ := adapter.LoadAccount([]byte("bob"))
+	_ = account.(*userAccount).AccountDataHandler().SaveKeyValue([]byte("owner"), nil)
+	_ = adapter.SaveAccount(account)
+	changed, _ := adapter.RootHash()
+	assert.NotEqual(t, first, changed)
+
+	empty, _ := NewAccountsAdapter().RootHash()
+	assert.NotEqual(t, first, empty)
+}
@@ -0,0 +1,562 @@
+package inMemory
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.BlockchainHook = (*blockchainHook)(nil)
+
+// ArgsBlockchainHook is the DTO used to create a new in-memory blockchain hook
+type ArgsBlockchainHook struct {
+	Accounts         vmcommon.AccountsAdapter
+	BuiltInFunctions vmcommon.BuiltInFunctionContainer
+	ShardCoordinator vmcommon.Coordinator
+	Marshaller       vmcommon.Marshalizer
+	// NFTStorageHandler is optional: without it, GetESDTToken reads the token directly from the account's data trie
+	NFTStorageHandler vmcommon.SimpleESDTNFTStorageHandler
+	// GlobalSettingsHandler is optional: without it, no token is paused or has limited transfers
+	GlobalSettingsHandler vmcommon.ESDTGlobalSettingsHandler
+}
+
+// BlockInfo holds the block related values returned by the blockchain hook
+type BlockInfo struct {
+	Nonce       uint64
+	Round       uint64
+	TimeStamp   uint64
+	TimeStampMs uint64
+	RandomSeed  []byte
+	Epoch       uint32
+}
+
+// allStateHandler is implemented by the accounts that can list their whole data trie
+type allStateHandler interface {
+	GetAllState() map[string][]byte
+}
+
+type blockchainHook struct {
+	accounts              vmcommon.AccountsAdapter
+	builtInFunctions      vmcommon.BuiltInFunctionContainer
+	shardCoordinator      vmcommon.Coordinator
+	marshaller            vmcommon.Marshalizer
+	nftStorageHandler     vmcommon.SimpleESDTNFTStorageHandler
+	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler
+
+	mutBlockInfo        sync.RWMutex
+	currentBlock        BlockInfo
+	lastBlock           BlockInfo
+	epochStartBlock     BlockInfo
+	lastStateRootHash   []byte
+	roundTime           uint64
+	blockHashes         map[uint64][]byte
+	mutCompiledCode     sync.RWMutex
+	compiledCodeStorage map[string][]byte
+}
+
+// NewBlockchainHook creates a blockchain hook that keeps the block info and the compiled code in memory and
+// processes the built-in functions from the provided container on top of the provided accounts
+func NewBlockchainHook(args ArgsBlockchainHook) (*blockchainHook, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.BuiltInFunctions) {
+		return nil, ErrNilBuiltInFunctionContainer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshaller
+	}
+
+	return &blockchainHook{
+		accounts:              args.Accounts,
+		builtInFunctions:      args.BuiltInFunctions,
+		shardCoordinator:      args.ShardCoordinator,
+		marshaller:            args.Marshaller,
+		nftStorageHandler:     args.NFTStorageHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		blockHashes:           make(map[uint64][]byte),
+		compiledCodeStorage:   make(map[string][]byte),
+	}, nil
+}
+
+// SetCurrentBlockInfo sets the values returned by the Current* functions
+func (hook *blockchainHook) SetCurrentBlockInfo(info BlockInfo) {
+	hook.mutBlockInfo.Lock()
+	hook.currentBlock = copyBlockInfo(info)
+	hook.mutBlockInfo.Unlock()
+}
+
+// SetLastBlockInfo sets the values returned by the Last* functions and the state root hash of the last block
+func (hook *blockchainHook) SetLastBlockInfo(info BlockInfo, stateRootHash []byte) {
+	hook.mutBlockInfo.Lock()
+	hook.lastBlock = copyBlockInfo(info)
+	hook.lastStateRootHash = copyBytes(stateRootHash)
+	hook.mutBlockInfo.Unlock()
+}
+
+// SetEpochStartBlockInfo sets the values returned by the EpochStartBlock* functions
+func (hook *blockchainHook) SetEpochStartBlockInfo(info BlockInfo) {
+	hook.mutBlockInfo.Lock()
+	hook.epochStartBlock = copyBlockInfo(info)
+	hook.mutBlockInfo.Unlock()
+}
+
+// SetRoundTime sets the round duration
+func (hook *blockchainHook) SetRoundTime(roundTime uint64) {
+	hook.mutBlockInfo.Lock()
+	hook.roundTime = roundTime
+	hook.mutBlockInfo.Unlock()
+}
+
+// SetBlockHash sets the hash returned by GetBlockhash for the provided nonce
+func (hook *blockchainHook) SetBlockHash(nonce uint64, hash []byte) {
+	hook.mutBlockInfo.Lock()
+	hook.blockHashes[nonce] = copyBytes(hash)
+	hook.mutBlockInfo.Unlock()
+}
+
+func copyBlockInfo(info BlockInfo) BlockInfo {
+	info.RandomSeed = copyBytes(info.RandomSeed)
+	return info
+}
+
+// NewAddress computes the address of a new smart contract, as the node does
+func (hook *blockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	return vmcommon.ComputeContractAddress(creatorAddress, creatorNonce, vmType)
+}
+
+// GetStorageData returns the value saved under the provided key of the account. A missing account or key yields
+// an empty value.
+func (hook *blockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	account, err := hook.GetUserAccount(accountAddress)
+	if err == ErrAccountNotFound {
+		return make([]byte, 0), 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	value, depth, err := account.AccountDataHandler().RetrieveValue(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	if value == nil {
+		value = make([]byte, 0)
+	}
+
+	return value, depth, nil
+}
+
+// GetBlockhash returns the hash set for the provided nonce
+func (hook *blockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	hash, found := hook.blockHashes[nonce]
+	if !found {
+		return nil, ErrBlockHashNotFound
+	}
+
+	return copyBytes(hash), nil
+}
+
+// LastNonce returns the nonce of the last block
+func (hook *blockchainHook) LastNonce() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.lastBlock.Nonce
+}
+
+// LastRound returns the round of the last block
+func (hook *blockchainHook) LastRound() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.lastBlock.Round
+}
+
+// LastTimeStamp returns the timestamp of the last block
+func (hook *blockchainHook) LastTimeStamp() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.lastBlock.TimeStamp
+}
+
+// LastTimeStampMs returns the timestamp of the last block in milliseconds
+func (hook *blockchainHook) LastTimeStampMs() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.lastBlock.TimeStampMs
+}
+
+// LastRandomSeed returns the random seed of the last block
+func (hook *blockchainHook) LastRandomSeed() []byte {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return copyBytes(hook.lastBlock.RandomSeed)
+}
+
+// LastEpoch returns the epoch of the last block
+func (hook *blockchainHook) LastEpoch() uint32 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.lastBlock.Epoch
+}
+
+// GetStateRootHash returns the state root hash of the last block
+func (hook *blockchainHook) GetStateRootHash() []byte {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return copyBytes(hook.lastStateRootHash)
+}
+
+// CurrentNonce returns the nonce of the current block
+func (hook *blockchainHook) CurrentNonce() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.currentBlock.Nonce
+}
+
+// CurrentRound returns the round of the current block
+func (hook *blockchainHook) CurrentRound() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.currentBlock.Round
+}
+
+// CurrentTimeStamp returns the timestamp of the current block
+func (hook *blockchainHook) CurrentTimeStamp() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.currentBlock.TimeStamp
+}
+
+// CurrentTimeStampMs returns the timestamp of the current block in milliseconds
+func (hook *blockchainHook) CurrentTimeStampMs() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.currentBlock.TimeStampMs
+}
+
+// CurrentRandomSeed returns the random seed of the current block
+func (hook *blockchainHook) CurrentRandomSeed() []byte {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return copyBytes(hook.currentBlock.RandomSeed)
+}
+
+// CurrentEpoch returns the epoch of the current block
+func (hook *blockchainHook) CurrentEpoch() uint32 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.currentBlock.Epoch
+}
+
+// RoundTime returns the round duration
+func (hook *blockchainHook) RoundTime() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.roundTime
+}
+
+// EpochStartBlockTimeStampMs returns the timestamp of the epoch start block in milliseconds
+func (hook *blockchainHook) EpochStartBlockTimeStampMs() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.epochStartBlock.TimeStampMs
+}
+
+// EpochStartBlockNonce returns the nonce of the epoch start block
+func (hook *blockchainHook) EpochStartBlockNonce() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.epochStartBlock.Nonce
+}
+
+// EpochStartBlockRound returns the round of the epoch start block
+func (hook *blockchainHook) EpochStartBlockRound() uint64 {
+	hook.mutBlockInfo.RLock()
+	defer hook.mutBlockInfo.RUnlock()
+
+	return hook.epochStartBlock.Round
+}
+
+// ProcessBuiltInFunction processes the built-in function the same way the node does: the sender is loaded only if
+// it is in the self shard and an error is returned if it does not exist, the destination is loaded or created only
+// if it is in the self shard, and both are saved only if the function succeeded
+func (hook *blockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	function, err := hook.builtInFunctions.Get(input.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	sndAccount, dstAccount, err := hook.getUserAccounts(input)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(sndAccount, dstAccount, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.IfNil(sndAccount) {
+		err = hook.accounts.SaveAccount(sndAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !check.IfNil(dstAccount) && !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		err = hook.accounts.SaveAccount(dstAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vmOutput, nil
+}
+
+func (hook *blockchainHook) getUserAccounts(input *vmcommon.ContractCallInput) (vmcommon.UserAccountHandler, vmcommon.UserAccountHandler, error) {
+	var err error
+	var sndAccount vmcommon.UserAccountHandler
+	if hook.shardCoordinator.ComputeId(input.CallerAddr) == hook.shardCoordinator.SelfId() {
+		sndAccount, err = hook.GetUserAccount(input.CallerAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var dstAccount vmcommon.UserAccountHandler
+	if hook.shardCoordinator.ComputeId(input.RecipientAddr) == hook.shardCoordinator.SelfId() {
+		if !check.IfNil(sndAccount) && bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+			return sndAccount, sndAccount, nil
+		}
+
+		dstAccount, err = hook.loadUserAccount(input.RecipientAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return sndAccount, dstAccount, nil
+}
+
+// GetBuiltinFunctionNames returns the names of the functions from the container
+func (hook *blockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	return hook.builtInFunctions.Keys()
+}
+
+// GetAllState returns all the key-value pairs of the account's data trie
+func (hook *blockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	account, err := hook.GetUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	stateHandler, ok := account.(allStateHandler)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return stateHandler.GetAllState(), nil
+}
+
+// GetUserAccount returns the existing account
+func (hook *blockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := hook.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return castToUserAccount(account)
+}
+
+func (hook *blockchainHook) loadUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := hook.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return castToUserAccount(account)
+}
+
+func castToUserAccount(account vmcommon.AccountHandler) (vmcommon.UserAccountHandler, error) {
+	if check.IfNil(account) {
+		return nil, ErrNilAccount
+	}
+	userAcc, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAcc, nil
+}
+
+// GetCode returns the code of the account
+func (hook *blockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return hook.accounts.GetCode(account.GetCodeHash())
+}
+
+// GetShardOfAddress returns the shard of the address
+func (hook *blockchainHook) GetShardOfAddress(address []byte) uint32 {
+	return hook.shardCoordinator.ComputeId(address)
+}
+
+// IsSmartContract returns true if the address has the smart contract format
+func (hook *blockchainHook) IsSmartContract(address []byte) bool {
+	return vmcommon.IsSmartContractAddress(address)
+}
+
+// IsPayable returns true if the receiver is a user address or a smart contract with the payable flag set, or with
+// the payable by smart contract flag set when the sender is a smart contract. A smart contract that is not in the
+// self shard is considered payable, as its code metadata is not known.
+func (hook *blockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	if !hook.IsSmartContract(recvAddress) {
+		return true, nil
+	}
+	if hook.shardCoordinator.ComputeId(recvAddress) != hook.shardCoordinator.SelfId() {
+		return true, nil
+	}
+
+	account, err := hook.GetUserAccount(recvAddress)
+	if err != nil {
+		return false, err
+	}
+
+	metadata := vmcommon.CodeMetadataFromBytes(account.GetCodeMetadata())
+	return metadata.Payable || metadata.PayableBySC && hook.IsSmartContract(sndAddress), nil
+}
+
+// SaveCompiledCode saves the compiled code in memory
+func (hook *blockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	hook.mutCompiledCode.Lock()
+	hook.compiledCodeStorage[string(codeHash)] = copyBytes(code)
+	hook.mutCompiledCode.Unlock()
+}
+
+// GetCompiledCode returns the compiled code, if it was saved
+func (hook *blockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	hook.mutCompiledCode.RLock()
+	defer hook.mutCompiledCode.RUnlock()
+
+	code, found := hook.compiledCodeStorage[string(codeHash)]
+	return found, copyBytes(code)
+}
+
+// ClearCompiledCodes removes all the compiled codes
+func (hook *blockchainHook) ClearCompiledCodes() {
+	hook.mutCompiledCode.Lock()
+	hook.compiledCodeStorage = make(map[string][]byte)
+	hook.mutCompiledCode.Unlock()
+}
+
+// GetESDTToken returns the ESDT token held by the account. A missing account or token yields an empty token.
+func (hook *blockchainHook) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	esdtData := &esdt.ESDigitalToken{Value: big.NewInt(0)}
+
+	account, err := hook.GetUserAccount(address)
+	if err == ErrAccountNotFound {
+		return esdtData, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	esdtTokenKey := hook.esdtTokenKey(tokenID)
+	if !check.IfNil(hook.nftStorageHandler) {
+		esdtData, _, err = hook.nftStorageHandler.GetESDTNFTTokenOnDestination(account, esdtTokenKey, nonce)
+		return esdtData, err
+	}
+
+	if nonce > 0 {
+		esdtTokenKey = append(esdtTokenKey, big.NewInt(0).SetUint64(nonce).Bytes()...)
+	}
+	value, _, err := account.AccountDataHandler().RetrieveValue(esdtTokenKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return esdtData, nil
+	}
+
+	err = hook.marshaller.Unmarshal(esdtData, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return esdtData, nil
+}
+
+// IsPaused returns true if the token is globally paused
+func (hook *blockchainHook) IsPaused(tokenID []byte) bool {
+	if check.IfNil(hook.globalSettingsHandler) {
+		return false
+	}
+
+	return hook.globalSettingsHandler.IsPaused(hook.esdtTokenKey(tokenID))
+}
+
+// IsLimitedTransfer returns true if the token has limited transfers
+func (hook *blockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	if check.IfNil(hook.globalSettingsHandler) {
+		return false
+	}
+
+	return hook.globalSettingsHandler.IsLimitedTransfer(hook.esdtTokenKey(tokenID))
+}
+
+func (hook *blockchainHook) esdtTokenKey(tokenID []byte) []byte {
+	return []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + string(tokenID))
+}
+
+// GetSnapshot returns the journal length of the accounts adapter
+func (hook *blockchainHook) GetSnapshot() int {
+	return hook.accounts.JournalLen()
+}
+
+// RevertToSnapshot reverts the accounts adapter to the provided journal length
+func (hook *blockchainHook) RevertToSnapshot(snapshot int) error {
+	return hook.accounts.RevertToSnapshot(snapshot)
+}
+
+// ExecuteSmartContractCallOnOtherVM returns ErrNotSupported, as there are no VMs behind the in-memory hook
+func (hook *blockchainHook) ExecuteSmartContractCallOnOtherVM(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return nil, ErrNotSupported
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hook *blockchainHook) IsInterfaceNil() bool {
+	return hook == nil
+}
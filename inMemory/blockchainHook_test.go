@@ -0,0 +1,301 @@
+package inMemory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = []byte("alice___________________________")
+	bob   = []byte("bob_____________________________")
+	token = []byte("TOKEN-abcdef")
+)
+
+func createBlockchainHookWithBuiltInFunctions(t *testing.T) (*blockchainHook, *accountsAdapter) {
+	accounts := NewAccountsAdapter()
+	marshaller := &mock.MarshalizerMock{}
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(1)
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           mock.FillGasMapInternal(make(map[string]map[string]uint64), 1),
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      marshaller,
+		Accounts:                         accounts,
+		ShardCoordinator:                 shardCoordinator,
+		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
+		GuardedAccountHandler:            &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	hook, err := NewBlockchainHook(ArgsBlockchainHook{
+		Accounts:         accounts,
+		BuiltInFunctions: creator.BuiltInFunctionContainer(),
+		ShardCoordinator: shardCoordinator,
+		Marshaller:       marshaller,
+	})
+	require.Nil(t, err)
+
+	return hook, accounts
+}
+
+func saveESDTBalance(t *testing.T, accounts *accountsAdapter, address []byte, value int64) {
+	account, _ := accounts.LoadAccount(address)
+	esdtData, _ := (&mock.MarshalizerMock{}).Marshal(&esdt.ESDigitalToken{Value: big.NewInt(value)})
+	esdtTokenKey := []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + string(token))
+	_ = account.(*userAccount).AccountDataHandler().SaveKeyValue(esdtTokenKey, esdtData)
+	require.Nil(t, accounts.SaveAccount(account))
+}
+
+func TestNewBlockchainHook(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func() ArgsBlockchainHook {
+		return ArgsBlockchainHook{
+			Accounts:         NewAccountsAdapter(),
+			BuiltInFunctions: builtInFunctions.NewBuiltInFunctionContainer(),
+			ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+			Marshaller:       &mock.MarshalizerMock{},
+		}
+	}
+
+	args := createArgs()
+	args.Accounts = nil
+	hook, err := NewBlockchainHook(args)
+	assert.Nil(t, hook)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	args = createArgs()
+	args.BuiltInFunctions = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+	args = createArgs()
+	args.ShardCoordinator = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilShardCoordinator, err)
+
+	args = createArgs()
+	args.Marshaller = nil
+	_, err = NewBlockchainHook(args)
+	assert.Equal(t, ErrNilMarshaller, err)
+
+	hook, err = NewBlockchainHook(createArgs())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(hook))
+}
+
+func TestBlockchainHook_BlockInfo(t *testing.T) {
+	t.Parallel()
+
+	hook, _ := createBlockchainHookWithBuiltInFunctions(t)
+	hook.SetCurrentBlockInfo(BlockInfo{Nonce: 10, Round: 11, TimeStamp: 12, TimeStampMs: 12000, RandomSeed: []byte("current"), Epoch: 2})
+	hook.SetLastBlockInfo(BlockInfo{Nonce: 9, Round: 10, TimeStamp: 6, TimeStampMs: 6000, RandomSeed: []byte("last"), Epoch: 1}, []byte("root hash"))
+	hook.SetEpochStartBlockInfo(BlockInfo{Nonce: 5, Round: 6, TimeStampMs: 3000})
+	hook.SetRoundTime(6000)
+	hook.SetBlockHash(9, []byte("hash"))
+
+	assert.Equal(t, uint64(10), hook.CurrentNonce())
+	assert.Equal(t, uint64(11), hook.CurrentRound())
+	assert.Equal(t, uint64(12), hook.CurrentTimeStamp())
+	assert.Equal(t, uint64(12000), hook.CurrentTimeStampMs())
+	assert.Equal(t, []byte("current"), hook.CurrentRandomSeed())
+	assert.Equal(t, uint32(2), hook.CurrentEpoch())
+	assert.Equal(t, uint64(9), hook.LastNonce())
+	assert.Equal(t, uint64(10), hook.LastRound())
+	assert.Equal(t, uint64(6), hook.LastTimeStamp())
+	assert.Equal(t, uint64(6000), hook.LastTimeStampMs())
+	assert.Equal(t, []byte("last"), hook.LastRandomSeed())
+	assert.Equal(t, uint32(1), hook.LastEpoch())
+	assert.Equal(t, []byte("root hash"), hook.GetStateRootHash())
+	assert.Equal(t, uint64(5), hook.EpochStartBlockNonce())
+	assert.Equal(t, uint64(6), hook.EpochStartBlockRound())
+	assert.Equal(t, uint64(3000), hook.EpochStartBlockTimeStampMs())
+	assert.Equal(t, uint64(6000), hook.RoundTime())
+
+	hash, err := hook.GetBlockhash(9)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hash"), hash)
+	_, err = hook.GetBlockhash(8)
+	assert.Equal(t, ErrBlockHashNotFound, err)
+}
+
+func TestBlockchainHook_Accounts(t *testing.T) {
+	t.Parallel()
+
+	hook, accounts := createBlockchainHookWithBuiltInFunctions(t)
+
+	value, _, err := hook.GetStorageData(alice, []byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{}, value)
+
+	account := NewUserAccount(alice)
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	account.SetCode([]byte("code"))
+	require.Nil(t, accounts.SaveAccount(account))
+
+	value, _, err = hook.GetStorageData(alice, []byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	state, err := hook.GetAllState(alice)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("value")}, state)
+
+	userAcc, err := hook.GetUserAccount(alice)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("code"), hook.GetCode(userAcc))
+
+	esdtData, err := hook.GetESDTToken(alice, token, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), esdtData.Value)
+	saveESDTBalance(t, accounts, alice, 42)
+	esdtData, err = hook.GetESDTToken(alice, token, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(42), esdtData.Value)
+
+	address, err := hook.NewAddress(alice, 0, []byte{5, 0})
+	require.Nil(t, err)
+	assert.True(t, hook.IsSmartContract(address))
+	assert.False(t, hook.IsSmartContract(alice))
+
+	payable, err := hook.IsPayable(alice, bob)
+	assert.Nil(t, err)
+	assert.True(t, payable)
+	contract := NewUserAccount(address)
+	contract.SetCodeMetadata((&vmcommon.CodeMetadata{Payable: false}).ToBytes())
+	require.Nil(t, accounts.SaveAccount(contract))
+	payable, err = hook.IsPayable(alice, address)
+	assert.Nil(t, err)
+	assert.False(t, payable)
+	contract.SetCodeMetadata((&vmcommon.CodeMetadata{PayableBySC: true}).ToBytes())
+	require.Nil(t, accounts.SaveAccount(contract))
+	payable, err = hook.IsPayable(alice, address)
+	assert.Nil(t, err)
+	assert.False(t, payable)
+	payable, err = hook.IsPayable(address, address)
+	assert.Nil(t, err)
+	assert.True(t, payable)
+
+	hook.SaveCompiledCode([]byte("hash"), []byte("compiled"))
+	found, compiled := hook.GetCompiledCode([]byte("hash"))
+	assert.True(t, found)
+	assert.Equal(t, []byte("compiled"), compiled)
+	hook.ClearCompiledCodes()
+	found, _ = hook.GetCompiledCode([]byte("hash"))
+	assert.False(t, found)
+
+	_, err = hook.ExecuteSmartContractCallOnOtherVM(&vmcommon.ContractCallInput{})
+	assert.Equal(t, ErrNotSupported, err)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	hook, accounts := createBlockchainHookWithBuiltInFunctions(t)
+	assert.Contains(t, hook.GetBuiltinFunctionNames(), core.BuiltInFunctionESDTTransfer)
+
+	saveESDTBalance(t, accounts, alice, 100)
+	_, _ = accounts.Commit()
+	rootHashBefore, _ := accounts.RootHash()
+
+	_, err := hook.ProcessBuiltInFunction(nil)
+	assert.Equal(t, ErrNilVMInput, err)
+
+	snapshot := hook.GetSnapshot()
+	vmOutput, err := hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments:   [][]byte{token, big.NewInt(30).Bytes()},
+		},
+		RecipientAddr: bob,
+		Function:      core.BuiltInFunctionESDTTransfer,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	aliceESDT, _ := hook.GetESDTToken(alice, token, 0)
+	bobESDT, _ := hook.GetESDTToken(bob, token, 0)
+	assert.Equal(t, big.NewInt(70), aliceESDT.Value)
+	assert.Equal(t, big.NewInt(30), bobESDT.Value)
+
+	_, err = hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments:   [][]byte{token, big.NewInt(1000).Bytes()},
+		},
+		RecipientAddr: bob,
+		Function:      core.BuiltInFunctionESDTTransfer,
+	})
+	assert.NotNil(t, err)
+	aliceESDT, _ = hook.GetESDTToken(alice, token, 0)
+	assert.Equal(t, big.NewInt(70), aliceESDT.Value, "a failed call must not change the state")
+
+	_, err = hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 1000,
+			Arguments:   [][]byte{[]byte("key"), []byte("value")},
+		},
+		RecipientAddr: alice,
+		Function:      core.BuiltInFunctionSaveKeyValue,
+	})
+	require.Nil(t, err)
+	value, _, _ := hook.GetStorageData(alice, []byte("key"))
+	assert.Equal(t, []byte("value"), value)
+
+	require.Nil(t, hook.RevertToSnapshot(snapshot))
+	rootHashAfter, _ := accounts.RootHash()
+	assert.Equal(t, rootHashBefore, rootHashAfter)
+	_, err = hook.GetUserAccount(bob)
+	assert.Equal(t, ErrAccountNotFound, err)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionShouldRunTheExecutionInterceptors(t *testing.T) {
+	t.Parallel()
+
+	hook, accounts := createBlockchainHookWithBuiltInFunctions(t)
+	saveESDTBalance(t, accounts, alice, 100)
+
+	intercepted := make([]string, 0)
+	holder, ok := hook.builtInFunctions.(interface {
+		AddExecutionInterceptor(interceptor builtInFunctions.ExecutionInterceptor) error
+	})
+	require.True(t, ok)
+	err := holder.AddExecutionInterceptor(builtInFunctions.ExecutionInterceptorFunc(
+		func(call *builtInFunctions.BuiltInFunctionCall, next builtInFunctions.ProcessBuiltInFunctionHandler) (*vmcommon.VMOutput, error) {
+			intercepted = append(intercepted, call.FunctionName)
+			return next(call)
+		}))
+	require.Nil(t, err)
+
+	_, err = hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  alice,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments:   [][]byte{token, big.NewInt(30).Bytes()},
+		},
+		RecipientAddr: bob,
+		Function:      core.BuiltInFunctionESDTTransfer,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{core.BuiltInFunctionESDTTransfer}, intercepted)
+}
@@ -0,0 +1,45 @@
+package inMemory
+
+import "errors"
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter was provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilBuiltInFunctionContainer signals that a nil built-in function container was provided
+var ErrNilBuiltInFunctionContainer = errors.New("nil built-in function container")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator was provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilMarshaller signals that a nil marshaller was provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrNilAccount signals that a nil account was provided
+var ErrNilAccount = errors.New("nil account")
+
+// ErrNilVMInput signals that a nil VM input was provided
+var ErrNilVMInput = errors.New("nil VM input")
+
+// ErrAccountNotFound signals that the account does not exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrWrongTypeAssertion signals that the provided object is not of the expected type
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrInvalidSnapshot signals that the snapshot to revert to is out of the journal bounds
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrInsufficientFunds signals that the balance is too low for the requested operation
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrOperationNotPermitted signals that the caller is not allowed to perform the operation on the account
+var ErrOperationNotPermitted = errors.New("operation not permitted")
+
+// ErrInvalidAddressLength signals that the address has an invalid length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrBlockHashNotFound signals that there is no block hash set for the requested nonce
+var ErrBlockHashNotFound = errors.New("block hash not found")
+
+// ErrNotSupported signals that the operation is not supported by the in-memory implementation
+var ErrNotSupported = errors.New("operation not supported by the in-memory implementation")
@@ -0,0 +1,291 @@
+package inMemory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.UserAccountHandler = (*userAccount)(nil)
+var _ vmcommon.AccountDataHandler = (*dataTrie)(nil)
+
+var hasher = keccak.NewKeccak()
+
+// dataTrie is the in-memory key-value storage of an account
+type dataTrie struct {
+	data map[string][]byte
+}
+
+func newDataTrie() *dataTrie {
+	return &dataTrie{
+		data: make(map[string][]byte),
+	}
+}
+
+// RetrieveValue returns the value stored under the provided key, or nil if the key is not set. The depth is always 0.
+func (trie *dataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, found := trie.data[string(key)]
+	if !found {
+		return nil, 0, nil
+	}
+
+	return copyBytes(value), 0, nil
+}
+
+// SaveKeyValue stores the value under the provided key. An empty value removes the key.
+func (trie *dataTrie) SaveKeyValue(key []byte, value []byte) error {
+	if len(value) == 0 {
+		delete(trie.data, string(key))
+		return nil
+	}
+
+	trie.data[string(key)] = copyBytes(value)
+	return nil
+}
+
+// MigrateDataTrieLeaves does nothing, as the in-memory data trie has no leaf versions
+func (trie *dataTrie) MigrateDataTrieLeaves(_ vmcommon.ArgsMigrateDataTrieLeaves) error {
+	return nil
+}
+
+// rootHash is the hash of all the key-value pairs, ordered by key. An empty data trie has a nil root hash.
+func (trie *dataTrie) rootHash() []byte {
+	if len(trie.data) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(trie.data))
+	for key := range trie.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buff := &bytes.Buffer{}
+	for _, key := range keys {
+		writeLengthPrefixed(buff, []byte(key))
+		writeLengthPrefixed(buff, trie.data[key])
+	}
+
+	return hasher.Compute(buff.String())
+}
+
+func (trie *dataTrie) clone() *dataTrie {
+	cloned := newDataTrie()
+	for key, value := range trie.data {
+		cloned.data[key] = copyBytes(value)
+	}
+
+	return cloned
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (trie *dataTrie) IsInterfaceNil() bool {
+	return trie == nil
+}
+
+// userAccount is the in-memory account handled by the accounts adapter
+type userAccount struct {
+	address         []byte
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	code            []byte
+	codeHash        []byte
+	codeMetadata    []byte
+	ownerAddress    []byte
+	userName        []byte
+	dataTrie        *dataTrie
+}
+
+// NewUserAccount creates a new, empty, account with the provided address
+func NewUserAccount(address []byte) *userAccount {
+	return &userAccount{
+		address:         copyBytes(address),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+		dataTrie:        newDataTrie(),
+	}
+}
+
+// AddressBytes returns the address of the account
+func (account *userAccount) AddressBytes() []byte {
+	return account.address
+}
+
+// IncreaseNonce adds the provided value to the nonce
+func (account *userAccount) IncreaseNonce(nonce uint64) {
+	account.nonce += nonce
+}
+
+// GetNonce returns the nonce of the account
+func (account *userAccount) GetNonce() uint64 {
+	return account.nonce
+}
+
+// GetCodeMetadata returns the code metadata
+func (account *userAccount) GetCodeMetadata() []byte {
+	return account.codeMetadata
+}
+
+// SetCodeMetadata sets the code metadata
+func (account *userAccount) SetCodeMetadata(codeMetadata []byte) {
+	account.codeMetadata = copyBytes(codeMetadata)
+}
+
+// GetCodeHash returns the hash of the account's code
+func (account *userAccount) GetCodeHash() []byte {
+	return account.codeHash
+}
+
+// SetCode sets the code of the account and its hash. The code is stored by the accounts adapter on SaveAccount.
+func (account *userAccount) SetCode(code []byte) {
+	account.code = copyBytes(code)
+	account.codeHash = nil
+	if len(code) > 0 {
+		account.codeHash = hasher.Compute(string(code))
+	}
+}
+
+// GetRootHash returns the root hash of the account's data trie
+func (account *userAccount) GetRootHash() []byte {
+	return account.dataTrie.rootHash()
+}
+
+// AccountDataHandler returns the data trie of the account
+func (account *userAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return account.dataTrie
+}
+
+// GetAllState returns a copy of all the key-value pairs of the account's data trie
+func (account *userAccount) GetAllState() map[string][]byte {
+	return account.dataTrie.clone().data
+}
+
+// AddToBalance adds the provided value, which may be negative, to the balance
+func (account *userAccount) AddToBalance(value *big.Int) error {
+	newBalance := big.NewInt(0).Add(account.balance, vmcommon.ZeroValueIfNil(value))
+	if newBalance.Sign() < 0 {
+		return ErrInsufficientFunds
+	}
+
+	account.balance = newBalance
+	return nil
+}
+
+// SubFromBalance subtracts the provided value from the balance
+func (account *userAccount) SubFromBalance(value *big.Int) error {
+	return account.AddToBalance(big.NewInt(0).Neg(vmcommon.ZeroValueIfNil(value)))
+}
+
+// GetBalance returns a copy of the balance
+func (account *userAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(account.balance)
+}
+
+// AddToDeveloperReward adds the provided value to the developer reward
+func (account *userAccount) AddToDeveloperReward(value *big.Int) {
+	account.developerReward = big.NewInt(0).Add(account.developerReward, vmcommon.ZeroValueIfNil(value))
+}
+
+// ClaimDeveloperRewards returns the developer reward and resets it, if the sender is the owner
+func (account *userAccount) ClaimDeveloperRewards(sndAddress []byte) (*big.Int, error) {
+	if !bytes.Equal(sndAddress, account.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	reward := account.developerReward
+	account.developerReward = big.NewInt(0)
+
+	return reward, nil
+}
+
+// GetDeveloperReward returns a copy of the developer reward
+func (account *userAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(account.developerReward)
+}
+
+// ChangeOwnerAddress sets the new owner, if the sender is the current owner
+func (account *userAccount) ChangeOwnerAddress(sndAddress []byte, newAddress []byte) error {
+	if !bytes.Equal(sndAddress, account.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(account.address) {
+		return ErrInvalidAddressLength
+	}
+
+	account.ownerAddress = copyBytes(newAddress)
+	return nil
+}
+
+// SetOwnerAddress sets the owner
+func (account *userAccount) SetOwnerAddress(address []byte) {
+	account.ownerAddress = copyBytes(address)
+}
+
+// GetOwnerAddress returns the owner
+func (account *userAccount) GetOwnerAddress() []byte {
+	return account.ownerAddress
+}
+
+// SetUserName sets the user name
+func (account *userAccount) SetUserName(userName []byte) {
+	account.userName = copyBytes(userName)
+}
+
+// GetUserName returns the user name
+func (account *userAccount) GetUserName() []byte {
+	return account.userName
+}
+
+func (account *userAccount) clone() *userAccount {
+	return &userAccount{
+		address:         copyBytes(account.address),
+		nonce:           account.nonce,
+		balance:         big.NewInt(0).Set(account.balance),
+		developerReward: big.NewInt(0).Set(account.developerReward),
+		code:            copyBytes(account.code),
+		codeHash:        copyBytes(account.codeHash),
+		codeMetadata:    copyBytes(account.codeMetadata),
+		ownerAddress:    copyBytes(account.ownerAddress),
+		userName:        copyBytes(account.userName),
+		dataTrie:        account.dataTrie.clone(),
+	}
+}
+
+// hash covers all the fields that are part of the state, with the data trie represented by its root hash
+func (account *userAccount) hash() []byte {
+	buff := &bytes.Buffer{}
+	writeLengthPrefixed(buff, account.address)
+	_ = binary.Write(buff, binary.BigEndian, account.nonce)
+	writeLengthPrefixed(buff, account.balance.Bytes())
+	writeLengthPrefixed(buff, account.developerReward.Bytes())
+	writeLengthPrefixed(buff, account.codeHash)
+	writeLengthPrefixed(buff, account.codeMetadata)
+	writeLengthPrefixed(buff, account.ownerAddress)
+	writeLengthPrefixed(buff, account.userName)
+	writeLengthPrefixed(buff, account.GetRootHash())
+
+	return hasher.Compute(buff.String())
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (account *userAccount) IsInterfaceNil() bool {
+	return account == nil
+}
+
+func writeLengthPrefixed(buff *bytes.Buffer, data []byte) {
+	_ = binary.Write(buff, binary.BigEndian, uint32(len(data)))
+	buff.Write(data)
+}
+
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
@@ -522,6 +522,13 @@ type NextOutputTransferIndexProvider interface {
 	IsInterfaceNil() bool
 }
 
+// AddressEncoder converts addresses to and from their human-readable form
+type AddressEncoder interface {
+	Encode(address []byte) (string, error)
+	Decode(encoded string) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
 // BlockchainDataProvider is an interface for getting blockchain data
 type BlockchainDataProvider interface {
 	SetBlockchainHook(BlockchainDataHook) error
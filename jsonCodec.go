@@ -0,0 +1,725 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+)
+
+type hexAddressEncoder struct {
+}
+
+// NewHexAddressEncoder creates an address encoder that uses the hex representation
+func NewHexAddressEncoder() *hexAddressEncoder {
+	return &hexAddressEncoder{}
+}
+
+// Encode returns the hex representation of the address
+func (encoder *hexAddressEncoder) Encode(address []byte) (string, error) {
+	return hex.EncodeToString(address), nil
+}
+
+// Decode returns the address from its hex representation
+func (encoder *hexAddressEncoder) Decode(encoded string) ([]byte, error) {
+	return hex.DecodeString(encoded)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (encoder *hexAddressEncoder) IsInterfaceNil() bool {
+	return encoder == nil
+}
+
+type jsonAsyncArguments struct {
+	CallID                       string `json:"callID,omitempty"`
+	CallerCallID                 string `json:"callerCallID,omitempty"`
+	CallbackAsyncInitiatorCallID string `json:"callbackAsyncInitiatorCallID,omitempty"`
+	GasAccumulated               uint64 `json:"gasAccumulated"`
+}
+
+type jsonESDTTransfer struct {
+	ESDTValue      string `json:"esdtValue,omitempty"`
+	ESDTTokenName  string `json:"esdtTokenName,omitempty"`
+	ESDTTokenType  uint32 `json:"esdtTokenType"`
+	ESDTTokenNonce uint64 `json:"esdtTokenNonce"`
+}
+
+type jsonVMInput struct {
+	CallerAddr           string              `json:"callerAddr,omitempty"`
+	Arguments            []string            `json:"arguments"`
+	AsyncArguments       *jsonAsyncArguments `json:"asyncArguments,omitempty"`
+	CallValue            string              `json:"callValue,omitempty"`
+	CallType             string              `json:"callType"`
+	GasPrice             uint64              `json:"gasPrice"`
+	GasProvided          uint64              `json:"gasProvided"`
+	GasLocked            uint64              `json:"gasLocked"`
+	OriginalTxHash       string              `json:"originalTxHash,omitempty"`
+	CurrentTxHash        string              `json:"currentTxHash,omitempty"`
+	PrevTxHash           string              `json:"prevTxHash,omitempty"`
+	ESDTTransfers        []*jsonESDTTransfer `json:"esdtTransfers"`
+	ReturnCallAfterError bool                `json:"returnCallAfterError"`
+	TxGuardian           string              `json:"txGuardian,omitempty"`
+	OriginalCallerAddr   string              `json:"originalCallerAddr,omitempty"`
+	RelayerAddr          string              `json:"relayerAddr,omitempty"`
+}
+
+type jsonContractCallInput struct {
+	jsonVMInput
+	RecipientAddr     string `json:"recipientAddr,omitempty"`
+	Function          string `json:"function"`
+	AllowInitFunction bool   `json:"allowInitFunction"`
+}
+
+type jsonContractCreateInput struct {
+	jsonVMInput
+	ContractCode         string `json:"contractCode,omitempty"`
+	ContractCodeMetadata string `json:"contractCodeMetadata,omitempty"`
+}
+
+type jsonStorageUpdate struct {
+	Offset  string `json:"offset,omitempty"`
+	Data    string `json:"data,omitempty"`
+	Written bool   `json:"written"`
+}
+
+type jsonOutputTransfer struct {
+	Index         uint32 `json:"index"`
+	Value         string `json:"value,omitempty"`
+	GasLimit      uint64 `json:"gasLimit"`
+	GasLocked     uint64 `json:"gasLocked"`
+	AsyncData     string `json:"asyncData,omitempty"`
+	Data          string `json:"data,omitempty"`
+	CallType      string `json:"callType"`
+	SenderAddress string `json:"senderAddress,omitempty"`
+}
+
+type jsonOutputAccount struct {
+	Address                       string                        `json:"address,omitempty"`
+	Nonce                         uint64                        `json:"nonce"`
+	Balance                       string                        `json:"balance,omitempty"`
+	StorageUpdates                map[string]*jsonStorageUpdate `json:"storageUpdates"`
+	Code                          string                        `json:"code,omitempty"`
+	CodeMetadata                  string                        `json:"codeMetadata,omitempty"`
+	CodeDeployerAddress           string                        `json:"codeDeployerAddress,omitempty"`
+	BalanceDelta                  string                        `json:"balanceDelta,omitempty"`
+	OutputTransfers               []*jsonOutputTransfer         `json:"outputTransfers"`
+	GasUsed                       uint64                        `json:"gasUsed"`
+	BytesAddedToStorage           uint64                        `json:"bytesAddedToStorage"`
+	BytesDeletedFromStorage       uint64                        `json:"bytesDeletedFromStorage"`
+	BytesConsumedByTxAsNetworking uint64                        `json:"bytesConsumedByTxAsNetworking"`
+}
+
+type jsonLogEntry struct {
+	Identifier string   `json:"identifier,omitempty"`
+	Address    string   `json:"address,omitempty"`
+	Topics     []string `json:"topics"`
+	Data       []string `json:"data"`
+}
+
+type jsonVMOutput struct {
+	ReturnData      []string                      `json:"returnData"`
+	ReturnCode      string                        `json:"returnCode"`
+	ReturnMessage   string                        `json:"returnMessage,omitempty"`
+	GasRemaining    uint64                        `json:"gasRemaining"`
+	GasRefund       string                        `json:"gasRefund,omitempty"`
+	OutputAccounts  map[string]*jsonOutputAccount `json:"outputAccounts"`
+	DeletedAccounts []string                      `json:"deletedAccounts"`
+	TouchedAccounts []string                      `json:"touchedAccounts"`
+	Logs            []*jsonLogEntry               `json:"logs"`
+}
+
+type jsonCodec struct {
+	addressEncoder AddressEncoder
+}
+
+// NewJSONCodec creates a codec able to save and load VM inputs and outputs as readable JSON.
+// Addresses are converted using the provided address encoder (e.g. NewHexAddressEncoder or a bech32 converter),
+// all the other byte slices are hex encoded, big integers are written as decimal strings and the call types and
+// return codes are written by name. Nil and empty byte slices are considered equivalent and are decoded as nil.
+func NewJSONCodec(addressEncoder AddressEncoder) (*jsonCodec, error) {
+	if check.IfNil(addressEncoder) {
+		return nil, ErrNilAddressEncoder
+	}
+
+	return &jsonCodec{
+		addressEncoder: addressEncoder,
+	}, nil
+}
+
+// MarshalContractCallInput returns the JSON representation of the contract call input
+func (codec *jsonCodec) MarshalContractCallInput(input *ContractCallInput) ([]byte, error) {
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	conv := &jsonConverter{addressEncoder: codec.addressEncoder}
+	jsonInput := &jsonContractCallInput{
+		jsonVMInput:       conv.fromVMInput(&input.VMInput),
+		RecipientAddr:     conv.fromAddress(input.RecipientAddr),
+		Function:          input.Function,
+		AllowInitFunction: input.AllowInitFunction,
+	}
+	if conv.err != nil {
+		return nil, conv.err
+	}
+
+	return json.MarshalIndent(jsonInput, "", "  ")
+}
+
+// UnmarshalContractCallInput loads the contract call input from its JSON representation
+func (codec *jsonCodec) UnmarshalContractCallInput(data []byte) (*ContractCallInput, error) {
+	jsonInput := &jsonContractCallInput{}
+	err := json.Unmarshal(data, jsonInput)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := &jsonConverter{addressEncoder: codec.addressEncoder}
+	input := &ContractCallInput{
+		VMInput:           conv.toVMInput(&jsonInput.jsonVMInput),
+		RecipientAddr:     conv.toAddress(jsonInput.RecipientAddr),
+		Function:          jsonInput.Function,
+		AllowInitFunction: jsonInput.AllowInitFunction,
+	}
+	if conv.err != nil {
+		return nil, conv.err
+	}
+
+	return input, nil
+}
+
+// MarshalContractCreateInput returns the JSON representation of the contract create input
+func (codec *jsonCodec) MarshalContractCreateInput(input *ContractCreateInput) ([]byte, error) {
+	if input == nil {
+		return nil, ErrNilVMInput
+	}
+
+	conv := &jsonConverter{addressEncoder: codec.addressEncoder}
+	jsonInput := &jsonContractCreateInput{
+		jsonVMInput:          conv.fromVMInput(&input.VMInput),
+		ContractCode:         hex.EncodeToString(input.ContractCode),
+		ContractCodeMetadata: hex.EncodeToString(input.ContractCodeMetadata),
+	}
+	if conv.err != nil {
+		return nil, conv.err
+	}
+
+	return json.MarshalIndent(jsonInput, "", "  ")
+}
+
+// UnmarshalContractCreateInput loads the contract create input from its JSON representation
+func (codec *jsonCodec) UnmarshalContractCreateInput(data []byte) (*ContractCreateInput, error) {
+	jsonInput := &jsonContractCreateInput{}
+	err := json.Unmarshal(data, jsonInput)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := &jsonConverter{addressEncoder: codec.addressEncoder}
+	input := &ContractCreateInput{
+		VMInput:              conv.toVMInput(&jsonInput.jsonVMInput),
+		ContractCode:         conv.toBytes(jsonInput.ContractCode),
+		ContractCodeMetadata: conv.toBytes(jsonInput.ContractCodeMetadata),
+	}
+	if conv.err != nil {
+		return nil, conv.err
+	}
+
+	return input, nil
+}
+
+// MarshalVMOutput returns the JSON representation of the VMOutput
+func (codec *jsonCodec) MarshalVMOutput(vmOutput *VMOutput) ([]byte, error) {
+	if vmOutput == nil {
+		return nil, ErrNilVMOutput
+	}
+
+	conv := &jsonConverter{addressEncoder: codec.addressEncoder}
+	jsonOutput := conv.fromVMOutput(vmOutput)
+	if conv.err != nil {
+		return nil, conv.err
+	}
+
+	return json.MarshalIndent(jsonOutput, "", "  ")
+}
+
+// UnmarshalVMOutput loads the VMOutput from its JSON representation
+func (codec *jsonCodec) UnmarshalVMOutput(data []byte) (*VMOutput, error) {
+	jsonOutput := &jsonVMOutput{}
+	err := json.Unmarshal(data, jsonOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := &jsonConverter{addressEncoder: codec.addressEncoder}
+	vmOutput := conv.toVMOutput(jsonOutput)
+	if conv.err != nil {
+		return nil, conv.err
+	}
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (codec *jsonCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
+
+// jsonConverter converts between the VM structures and their JSON representation. The first error encountered
+// is kept and all the following conversions become no-ops. Missing call types and return codes are decoded as
+// DirectCall and Ok, respectively.
+type jsonConverter struct {
+	addressEncoder AddressEncoder
+	err            error
+}
+
+func (conv *jsonConverter) setError(err error, field string, value string) {
+	if conv.err == nil {
+		conv.err = fmt.Errorf("%w for %s, value %s", err, field, value)
+	}
+}
+
+func (conv *jsonConverter) fromAddress(address []byte) string {
+	if conv.err != nil || len(address) == 0 {
+		return ""
+	}
+
+	encoded, err := conv.addressEncoder.Encode(address)
+	if err != nil {
+		conv.setError(err, "address", hex.EncodeToString(address))
+	}
+
+	return encoded
+}
+
+func (conv *jsonConverter) toAddress(encoded string) []byte {
+	if conv.err != nil || len(encoded) == 0 {
+		return nil
+	}
+
+	address, err := conv.addressEncoder.Decode(encoded)
+	if err != nil {
+		conv.setError(err, "address", encoded)
+	}
+
+	return address
+}
+
+func (conv *jsonConverter) fromAddresses(addresses [][]byte) []string {
+	if addresses == nil {
+		return nil
+	}
+
+	encoded := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		encoded = append(encoded, conv.fromAddress(address))
+	}
+
+	return encoded
+}
+
+func (conv *jsonConverter) toAddresses(encoded []string) [][]byte {
+	if encoded == nil {
+		return nil
+	}
+
+	addresses := make([][]byte, 0, len(encoded))
+	for _, item := range encoded {
+		addresses = append(addresses, conv.toAddress(item))
+	}
+
+	return addresses
+}
+
+func (conv *jsonConverter) toBytes(encoded string) []byte {
+	if conv.err != nil || len(encoded) == 0 {
+		return nil
+	}
+
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		conv.setError(err, "bytes", encoded)
+	}
+
+	return decoded
+}
+
+func fromBytesSlice(values [][]byte) []string {
+	if values == nil {
+		return nil
+	}
+
+	encoded := make([]string, 0, len(values))
+	for _, value := range values {
+		encoded = append(encoded, hex.EncodeToString(value))
+	}
+
+	return encoded
+}
+
+func (conv *jsonConverter) toBytesSlice(encoded []string) [][]byte {
+	if encoded == nil {
+		return nil
+	}
+
+	values := make([][]byte, 0, len(encoded))
+	for _, item := range encoded {
+		values = append(values, conv.toBytes(item))
+	}
+
+	return values
+}
+
+func fromBigInt(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
+
+func (conv *jsonConverter) toBigInt(encoded string) *big.Int {
+	if conv.err != nil || len(encoded) == 0 {
+		return nil
+	}
+
+	value, ok := big.NewInt(0).SetString(encoded, 10)
+	if !ok {
+		conv.setError(ErrInvalidBigIntString, "big int", encoded)
+		return nil
+	}
+
+	return value
+}
+
+const unknownCallTypePrefix = "unknown call type: "
+
+func fromCallType(callType vm.CallType) string {
+	name := callType.ToString()
+	if name == vm.UnknownStr {
+		return unknownCallTypePrefix + strconv.Itoa(int(callType))
+	}
+
+	return name
+}
+
+func (conv *jsonConverter) toCallType(name string) vm.CallType {
+	if conv.err != nil || len(name) == 0 {
+		return vm.DirectCall
+	}
+
+	for callType := vm.DirectCall; callType <= vm.ExecOnDestByCaller; callType++ {
+		if callType.ToString() == name {
+			return callType
+		}
+	}
+
+	// unknown call types are parsed back from their "unknown call type: N" form
+	if strings.HasPrefix(name, unknownCallTypePrefix) {
+		value, err := strconv.Atoi(strings.TrimPrefix(name, unknownCallTypePrefix))
+		if err == nil && fromCallType(vm.CallType(value)) == name {
+			return vm.CallType(value)
+		}
+	}
+
+	conv.setError(ErrUnknownCallType, "call type", name)
+	return vm.DirectCall
+}
+
+func (conv *jsonConverter) toReturnCode(name string) ReturnCode {
+	if conv.err != nil || len(name) == 0 {
+		return Ok
+	}
+
+	returnCode, err := ReturnCodeFromString(name)
+	if err != nil {
+		conv.setError(err, "return code", name)
+	}
+
+	return returnCode
+}
+
+func (conv *jsonConverter) fromVMInput(input *VMInput) jsonVMInput {
+	jsonInput := jsonVMInput{
+		CallerAddr:           conv.fromAddress(input.CallerAddr),
+		Arguments:            fromBytesSlice(input.Arguments),
+		CallValue:            fromBigInt(input.CallValue),
+		CallType:             fromCallType(input.CallType),
+		GasPrice:             input.GasPrice,
+		GasProvided:          input.GasProvided,
+		GasLocked:            input.GasLocked,
+		OriginalTxHash:       hex.EncodeToString(input.OriginalTxHash),
+		CurrentTxHash:        hex.EncodeToString(input.CurrentTxHash),
+		PrevTxHash:           hex.EncodeToString(input.PrevTxHash),
+		ReturnCallAfterError: input.ReturnCallAfterError,
+		TxGuardian:           conv.fromAddress(input.TxGuardian),
+		OriginalCallerAddr:   conv.fromAddress(input.OriginalCallerAddr),
+		RelayerAddr:          conv.fromAddress(input.RelayerAddr),
+	}
+
+	if input.AsyncArguments != nil {
+		jsonInput.AsyncArguments = &jsonAsyncArguments{
+			CallID:                       hex.EncodeToString(input.AsyncArguments.CallID),
+			CallerCallID:                 hex.EncodeToString(input.AsyncArguments.CallerCallID),
+			CallbackAsyncInitiatorCallID: hex.EncodeToString(input.AsyncArguments.CallbackAsyncInitiatorCallID),
+			GasAccumulated:               input.AsyncArguments.GasAccumulated,
+		}
+	}
+
+	if input.ESDTTransfers != nil {
+		jsonInput.ESDTTransfers = make([]*jsonESDTTransfer, 0, len(input.ESDTTransfers))
+		for _, transfer := range input.ESDTTransfers {
+			if transfer == nil {
+				jsonInput.ESDTTransfers = append(jsonInput.ESDTTransfers, nil)
+				continue
+			}
+
+			jsonInput.ESDTTransfers = append(jsonInput.ESDTTransfers, &jsonESDTTransfer{
+				ESDTValue:      fromBigInt(transfer.ESDTValue),
+				ESDTTokenName:  hex.EncodeToString(transfer.ESDTTokenName),
+				ESDTTokenType:  transfer.ESDTTokenType,
+				ESDTTokenNonce: transfer.ESDTTokenNonce,
+			})
+		}
+	}
+
+	return jsonInput
+}
+
+func (conv *jsonConverter) toVMInput(jsonInput *jsonVMInput) VMInput {
+	input := VMInput{
+		CallerAddr:           conv.toAddress(jsonInput.CallerAddr),
+		Arguments:            conv.toBytesSlice(jsonInput.Arguments),
+		CallValue:            conv.toBigInt(jsonInput.CallValue),
+		CallType:             conv.toCallType(jsonInput.CallType),
+		GasPrice:             jsonInput.GasPrice,
+		GasProvided:          jsonInput.GasProvided,
+		GasLocked:            jsonInput.GasLocked,
+		OriginalTxHash:       conv.toBytes(jsonInput.OriginalTxHash),
+		CurrentTxHash:        conv.toBytes(jsonInput.CurrentTxHash),
+		PrevTxHash:           conv.toBytes(jsonInput.PrevTxHash),
+		ReturnCallAfterError: jsonInput.ReturnCallAfterError,
+		TxGuardian:           conv.toAddress(jsonInput.TxGuardian),
+		OriginalCallerAddr:   conv.toAddress(jsonInput.OriginalCallerAddr),
+		RelayerAddr:          conv.toAddress(jsonInput.RelayerAddr),
+	}
+
+	if jsonInput.AsyncArguments != nil {
+		input.AsyncArguments = &AsyncArguments{
+			CallID:                       conv.toBytes(jsonInput.AsyncArguments.CallID),
+			CallerCallID:                 conv.toBytes(jsonInput.AsyncArguments.CallerCallID),
+			CallbackAsyncInitiatorCallID: conv.toBytes(jsonInput.AsyncArguments.CallbackAsyncInitiatorCallID),
+			GasAccumulated:               jsonInput.AsyncArguments.GasAccumulated,
+		}
+	}
+
+	if jsonInput.ESDTTransfers != nil {
+		input.ESDTTransfers = make([]*ESDTTransfer, 0, len(jsonInput.ESDTTransfers))
+		for _, transfer := range jsonInput.ESDTTransfers {
+			if transfer == nil {
+				input.ESDTTransfers = append(input.ESDTTransfers, nil)
+				continue
+			}
+
+			input.ESDTTransfers = append(input.ESDTTransfers, &ESDTTransfer{
+				ESDTValue:      conv.toBigInt(transfer.ESDTValue),
+				ESDTTokenName:  conv.toBytes(transfer.ESDTTokenName),
+				ESDTTokenType:  transfer.ESDTTokenType,
+				ESDTTokenNonce: transfer.ESDTTokenNonce,
+			})
+		}
+	}
+
+	return input
+}
+
+func (conv *jsonConverter) fromOutputAccount(account *OutputAccount) *jsonOutputAccount {
+	if account == nil {
+		return nil
+	}
+
+	jsonAccount := &jsonOutputAccount{
+		Address:                       conv.fromAddress(account.Address),
+		Nonce:                         account.Nonce,
+		Balance:                       fromBigInt(account.Balance),
+		Code:                          hex.EncodeToString(account.Code),
+		CodeMetadata:                  hex.EncodeToString(account.CodeMetadata),
+		CodeDeployerAddress:           conv.fromAddress(account.CodeDeployerAddress),
+		BalanceDelta:                  fromBigInt(account.BalanceDelta),
+		GasUsed:                       account.GasUsed,
+		BytesAddedToStorage:           account.BytesAddedToStorage,
+		BytesDeletedFromStorage:       account.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: account.BytesConsumedByTxAsNetworking,
+	}
+
+	if account.StorageUpdates != nil {
+		jsonAccount.StorageUpdates = make(map[string]*jsonStorageUpdate, len(account.StorageUpdates))
+		for key, update := range account.StorageUpdates {
+			var jsonUpdate *jsonStorageUpdate
+			if update != nil {
+				jsonUpdate = &jsonStorageUpdate{
+					Offset:  hex.EncodeToString(update.Offset),
+					Data:    hex.EncodeToString(update.Data),
+					Written: update.Written,
+				}
+			}
+			jsonAccount.StorageUpdates[hex.EncodeToString([]byte(key))] = jsonUpdate
+		}
+	}
+
+	if account.OutputTransfers != nil {
+		jsonAccount.OutputTransfers = make([]*jsonOutputTransfer, 0, len(account.OutputTransfers))
+		for _, transfer := range account.OutputTransfers {
+			jsonAccount.OutputTransfers = append(jsonAccount.OutputTransfers, &jsonOutputTransfer{
+				Index:         transfer.Index,
+				Value:         fromBigInt(transfer.Value),
+				GasLimit:      transfer.GasLimit,
+				GasLocked:     transfer.GasLocked,
+				AsyncData:     hex.EncodeToString(transfer.AsyncData),
+				Data:          hex.EncodeToString(transfer.Data),
+				CallType:      fromCallType(transfer.CallType),
+				SenderAddress: conv.fromAddress(transfer.SenderAddress),
+			})
+		}
+	}
+
+	return jsonAccount
+}
+
+func (conv *jsonConverter) toOutputAccount(jsonAccount *jsonOutputAccount) *OutputAccount {
+	if jsonAccount == nil {
+		return nil
+	}
+
+	account := &OutputAccount{
+		Address:                       conv.toAddress(jsonAccount.Address),
+		Nonce:                         jsonAccount.Nonce,
+		Balance:                       conv.toBigInt(jsonAccount.Balance),
+		Code:                          conv.toBytes(jsonAccount.Code),
+		CodeMetadata:                  conv.toBytes(jsonAccount.CodeMetadata),
+		CodeDeployerAddress:           conv.toAddress(jsonAccount.CodeDeployerAddress),
+		BalanceDelta:                  conv.toBigInt(jsonAccount.BalanceDelta),
+		GasUsed:                       jsonAccount.GasUsed,
+		BytesAddedToStorage:           jsonAccount.BytesAddedToStorage,
+		BytesDeletedFromStorage:       jsonAccount.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: jsonAccount.BytesConsumedByTxAsNetworking,
+	}
+
+	if jsonAccount.StorageUpdates != nil {
+		account.StorageUpdates = make(map[string]*StorageUpdate, len(jsonAccount.StorageUpdates))
+		for key, jsonUpdate := range jsonAccount.StorageUpdates {
+			var update *StorageUpdate
+			if jsonUpdate != nil {
+				update = &StorageUpdate{
+					Offset:  conv.toBytes(jsonUpdate.Offset),
+					Data:    conv.toBytes(jsonUpdate.Data),
+					Written: jsonUpdate.Written,
+				}
+			}
+			account.StorageUpdates[string(conv.toBytes(key))] = update
+		}
+	}
+
+	if jsonAccount.OutputTransfers != nil {
+		account.OutputTransfers = make([]OutputTransfer, 0, len(jsonAccount.OutputTransfers))
+		for _, jsonTransfer := range jsonAccount.OutputTransfers {
+			if jsonTransfer == nil {
+				jsonTransfer = &jsonOutputTransfer{CallType: fromCallType(vm.DirectCall)}
+			}
+
+			account.OutputTransfers = append(account.OutputTransfers, OutputTransfer{
+				Index:         jsonTransfer.Index,
+				Value:         conv.toBigInt(jsonTransfer.Value),
+				GasLimit:      jsonTransfer.GasLimit,
+				GasLocked:     jsonTransfer.GasLocked,
+				AsyncData:     conv.toBytes(jsonTransfer.AsyncData),
+				Data:          conv.toBytes(jsonTransfer.Data),
+				CallType:      conv.toCallType(jsonTransfer.CallType),
+				SenderAddress: conv.toAddress(jsonTransfer.SenderAddress),
+			})
+		}
+	}
+
+	return account
+}
+
+func (conv *jsonConverter) fromVMOutput(vmOutput *VMOutput) *jsonVMOutput {
+	jsonOutput := &jsonVMOutput{
+		ReturnData:      fromBytesSlice(vmOutput.ReturnData),
+		ReturnCode:      vmOutput.ReturnCode.String(),
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       fromBigInt(vmOutput.GasRefund),
+		DeletedAccounts: conv.fromAddresses(vmOutput.DeletedAccounts),
+		TouchedAccounts: conv.fromAddresses(vmOutput.TouchedAccounts),
+	}
+
+	if vmOutput.OutputAccounts != nil {
+		jsonOutput.OutputAccounts = make(map[string]*jsonOutputAccount, len(vmOutput.OutputAccounts))
+		for key, account := range vmOutput.OutputAccounts {
+			jsonOutput.OutputAccounts[conv.fromAddress([]byte(key))] = conv.fromOutputAccount(account)
+		}
+	}
+
+	if vmOutput.Logs != nil {
+		jsonOutput.Logs = make([]*jsonLogEntry, 0, len(vmOutput.Logs))
+		for _, logEntry := range vmOutput.Logs {
+			if logEntry == nil {
+				jsonOutput.Logs = append(jsonOutput.Logs, nil)
+				continue
+			}
+
+			jsonOutput.Logs = append(jsonOutput.Logs, &jsonLogEntry{
+				Identifier: hex.EncodeToString(logEntry.Identifier),
+				Address:    conv.fromAddress(logEntry.Address),
+				Topics:     fromBytesSlice(logEntry.Topics),
+				Data:       fromBytesSlice(logEntry.Data),
+			})
+		}
+	}
+
+	return jsonOutput
+}
+
+func (conv *jsonConverter) toVMOutput(jsonOutput *jsonVMOutput) *VMOutput {
+	vmOutput := &VMOutput{
+		ReturnData:      conv.toBytesSlice(jsonOutput.ReturnData),
+		ReturnCode:      conv.toReturnCode(jsonOutput.ReturnCode),
+		ReturnMessage:   jsonOutput.ReturnMessage,
+		GasRemaining:    jsonOutput.GasRemaining,
+		GasRefund:       conv.toBigInt(jsonOutput.GasRefund),
+		DeletedAccounts: conv.toAddresses(jsonOutput.DeletedAccounts),
+		TouchedAccounts: conv.toAddresses(jsonOutput.TouchedAccounts),
+	}
+
+	if jsonOutput.OutputAccounts != nil {
+		vmOutput.OutputAccounts = make(map[string]*OutputAccount, len(jsonOutput.OutputAccounts))
+		for key, jsonAccount := range jsonOutput.OutputAccounts {
+			vmOutput.OutputAccounts[string(conv.toAddress(key))] = conv.toOutputAccount(jsonAccount)
+		}
+	}
+
+	if jsonOutput.Logs != nil {
+		vmOutput.Logs = make([]*LogEntry, 0, len(jsonOutput.Logs))
+		for _, jsonLog := range jsonOutput.Logs {
+			if jsonLog == nil {
+				vmOutput.Logs = append(vmOutput.Logs, nil)
+				continue
+			}
+
+			vmOutput.Logs = append(vmOutput.Logs, &LogEntry{
+				Identifier: conv.toBytes(jsonLog.Identifier),
+				Address:    conv.toAddress(jsonLog.Address),
+				Topics:     conv.toBytesSlice(jsonLog.Topics),
+				Data:       conv.toBytesSlice(jsonLog.Data),
+			})
+		}
+	}
+
+	return vmOutput
+}
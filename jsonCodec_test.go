@@ -0,0 +1,268 @@
+package vmcommon
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAddressForJSON(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func createVMInputForJSON() VMInput {
+	return VMInput{
+		CallerAddr: createAddressForJSON(1),
+		Arguments:  [][]byte{[]byte("arg1"), {0}},
+		AsyncArguments: &AsyncArguments{
+			CallID:                       []byte("callID"),
+			CallerCallID:                 []byte("callerCallID"),
+			CallbackAsyncInitiatorCallID: []byte("initiator"),
+			GasAccumulated:               37,
+		},
+		CallValue:      big.NewInt(1000),
+		CallType:       vm.AsynchronousCallBack,
+		GasPrice:       1000000000,
+		GasProvided:    500000,
+		GasLocked:      1000,
+		OriginalTxHash: []byte("original"),
+		CurrentTxHash:  []byte("current"),
+		PrevTxHash:     []byte("prev"),
+		ESDTTransfers: []*ESDTTransfer{
+			{
+				ESDTValue:      big.NewInt(5),
+				ESDTTokenName:  []byte("TKN-abcdef"),
+				ESDTTokenType:  1,
+				ESDTTokenNonce: 7,
+			},
+		},
+		ReturnCallAfterError: true,
+		TxGuardian:           createAddressForJSON(2),
+		OriginalCallerAddr:   createAddressForJSON(3),
+		RelayerAddr:          createAddressForJSON(4),
+	}
+}
+
+func createVMOutputForJSON() *VMOutput {
+	address := createAddressForJSON(5)
+	return &VMOutput{
+		ReturnData:    [][]byte{[]byte("ret")},
+		ReturnCode:    SimulateFailed,
+		ReturnMessage: "message",
+		GasRemaining:  100,
+		GasRefund:     big.NewInt(-3),
+		OutputAccounts: map[string]*OutputAccount{
+			string(address): {
+				Address: address,
+				Nonce:   3,
+				Balance: big.NewInt(10),
+				StorageUpdates: map[string]*StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte("value"), Written: true},
+				},
+				Code:                          []byte("code"),
+				CodeMetadata:                  []byte{1, 0},
+				CodeDeployerAddress:           createAddressForJSON(6),
+				BalanceDelta:                  big.NewInt(-10),
+				GasUsed:                       50,
+				BytesAddedToStorage:           1,
+				BytesDeletedFromStorage:       2,
+				BytesConsumedByTxAsNetworking: 3,
+				OutputTransfers: []OutputTransfer{
+					{
+						Index:         1,
+						Value:         big.NewInt(20),
+						GasLimit:      30,
+						GasLocked:     40,
+						AsyncData:     []byte("async"),
+						Data:          []byte("data"),
+						CallType:      vm.ESDTTransferAndExecute,
+						SenderAddress: createAddressForJSON(7),
+					},
+				},
+			},
+		},
+		DeletedAccounts: [][]byte{createAddressForJSON(8)},
+		TouchedAccounts: [][]byte{createAddressForJSON(9)},
+		Logs: []*LogEntry{
+			{
+				Identifier: []byte("identifier"),
+				Address:    address,
+				Topics:     [][]byte{[]byte("topic")},
+				Data:       [][]byte{[]byte("data")},
+			},
+		},
+	}
+}
+
+func TestNewJSONCodec_NilAddressEncoderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	codec, err := NewJSONCodec(nil)
+	assert.Nil(t, codec)
+	assert.Equal(t, ErrNilAddressEncoder, err)
+}
+
+func TestJSONCodec_ContractCallInputRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewJSONCodec(NewHexAddressEncoder())
+	input := &ContractCallInput{
+		VMInput:           createVMInputForJSON(),
+		RecipientAddr:     createAddressForJSON(10),
+		Function:          "ESDTTransfer",
+		AllowInitFunction: true,
+	}
+
+	data, err := codec.MarshalContractCallInput(input)
+	require.Nil(t, err)
+	assert.Contains(t, string(data), `"callType": "asynchronousCallBack"`)
+	assert.Contains(t, string(data), `"callValue": "1000"`)
+	assert.Contains(t, string(data), `"esdtTokenName": "544b4e2d616263646566"`)
+
+	decoded, err := codec.UnmarshalContractCallInput(data)
+	require.Nil(t, err)
+	assert.Equal(t, input, decoded)
+}
+
+func TestJSONCodec_ContractCreateInputRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewJSONCodec(NewHexAddressEncoder())
+	input := &ContractCreateInput{
+		VMInput:              createVMInputForJSON(),
+		ContractCode:         []byte("wasm code"),
+		ContractCodeMetadata: []byte{5, 0},
+	}
+
+	data, err := codec.MarshalContractCreateInput(input)
+	require.Nil(t, err)
+
+	decoded, err := codec.UnmarshalContractCreateInput(data)
+	require.Nil(t, err)
+	assert.Equal(t, input, decoded)
+}
+
+func TestJSONCodec_VMOutputRoundTripWithBech32(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	codec, _ := NewJSONCodec(converter)
+	vmOutput := createVMOutputForJSON()
+
+	data, err := codec.MarshalVMOutput(vmOutput)
+	require.Nil(t, err)
+	assert.Contains(t, string(data), `"returnCode": "simulate failed"`)
+	assert.Contains(t, string(data), `"gasRefund": "-3"`)
+	assert.Contains(t, string(data), `"erd1`)
+
+	decoded, err := codec.UnmarshalVMOutput(data)
+	require.Nil(t, err)
+	assert.Equal(t, vmOutput, decoded)
+}
+
+func TestJSONCodec_InvalidValuesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewJSONCodec(NewHexAddressEncoder())
+
+	_, err := codec.UnmarshalContractCallInput([]byte(`{"callType":"unknownType"}`))
+	assert.True(t, errors.Is(err, ErrUnknownCallType))
+
+	_, err = codec.UnmarshalVMOutput([]byte(`{"returnCode":"not a code"}`))
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+
+	_, err = codec.UnmarshalVMOutput([]byte(`{"gasRefund":"12a"}`))
+	assert.True(t, errors.Is(err, ErrInvalidBigIntString))
+
+	_, err = codec.UnmarshalContractCallInput([]byte(`{"callerAddr":"zz"}`))
+	assert.NotNil(t, err)
+
+	_, err = codec.MarshalVMOutput(nil)
+	assert.Equal(t, ErrNilVMOutput, err)
+
+	_, err = codec.MarshalContractCallInput(nil)
+	assert.Equal(t, ErrNilVMInput, err)
+}
+
+func TestJSONCodec_MissingCallTypeAndReturnCodeShouldUseDefaults(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewJSONCodec(NewHexAddressEncoder())
+
+	input, err := codec.UnmarshalContractCallInput([]byte(`{"function":"SaveKeyValue","gasProvided":10}`))
+	require.Nil(t, err)
+	assert.Equal(t, vm.DirectCall, input.CallType)
+	assert.Equal(t, "SaveKeyValue", input.Function)
+	assert.Equal(t, uint64(10), input.GasProvided)
+
+	vmOutput, err := codec.UnmarshalVMOutput([]byte(`{}`))
+	require.Nil(t, err)
+	assert.Equal(t, Ok, vmOutput.ReturnCode)
+}
+
+func TestReturnCodeFromString(t *testing.T) {
+	t.Parallel()
+
+	for rc := Ok; rc <= SimulateFailed; rc++ {
+		parsed, err := ReturnCodeFromString(rc.String())
+		require.Nil(t, err)
+		assert.Equal(t, rc, parsed)
+	}
+
+	parsed, err := ReturnCodeFromString("unknown error, code: 13")
+	require.Nil(t, err)
+	assert.Equal(t, ReturnCode(13), parsed)
+
+	parsed, err = ReturnCodeFromString(ReturnCode(-2).String())
+	require.Nil(t, err)
+	assert.Equal(t, ReturnCode(-2), parsed)
+
+	_, err = ReturnCodeFromString("unknown error, code: 013")
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+
+	_, err = ReturnCodeFromString("unknown error, code: abc")
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+
+	_, err = ReturnCodeFromString("not a return code")
+	assert.True(t, errors.Is(err, ErrUnknownReturnCode))
+}
+
+func TestJSONCodec_UnknownReturnCodeShouldRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewJSONCodec(NewHexAddressEncoder())
+
+	data, err := codec.MarshalVMOutput(&VMOutput{ReturnCode: ReturnCode(42)})
+	require.Nil(t, err)
+
+	vmOutput, err := codec.UnmarshalVMOutput(data)
+	require.Nil(t, err)
+	assert.Equal(t, ReturnCode(42), vmOutput.ReturnCode)
+}
+
+func TestJSONCodec_UnknownCallTypeShouldRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewJSONCodec(NewHexAddressEncoder())
+
+	data, err := codec.MarshalContractCallInput(&ContractCallInput{VMInput: VMInput{CallType: vm.CallType(42)}})
+	require.Nil(t, err)
+	assert.Contains(t, string(data), `"callType": "unknown call type: 42"`)
+
+	input, err := codec.UnmarshalContractCallInput(data)
+	require.Nil(t, err)
+	assert.Equal(t, vm.CallType(42), input.CallType)
+
+	_, err = codec.UnmarshalContractCallInput([]byte(`{"callType":"unknown call type: 042"}`))
+	assert.True(t, errors.Is(err, ErrUnknownCallType))
+	_, err = codec.UnmarshalContractCallInput([]byte(`{"callType":"unknown call type: 1"}`))
+	assert.True(t, errors.Is(err, ErrUnknownCallType))
+	_, err = codec.UnmarshalContractCallInput([]byte(`{"callType":"unknown"}`))
+	assert.True(t, errors.Is(err, ErrUnknownCallType))
+}
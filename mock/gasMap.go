@@ -0,0 +1,54 @@
+package mock
+
+import "github.com/multiversx/mx-chain-core-go/core"
+
+// FillGasMapInternal sets all the base operation and built-in function costs to the provided value
+func FillGasMapInternal(gasMap map[string]map[string]uint64, value uint64) map[string]map[string]uint64 {
+	gasMap[core.BaseOperationCostString] = fillGasMapBaseOperationCosts(value)
+	gasMap[core.BuiltInCostString] = fillGasMapBuiltInCosts(value)
+
+	return gasMap
+}
+
+func fillGasMapBaseOperationCosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["StorePerByte"] = value
+	gasMap["ReleasePerByte"] = value
+	gasMap["DataCopyPerByte"] = value
+	gasMap["PersistPerByte"] = value
+	gasMap["CompilePerByte"] = value
+	gasMap["AoTPreparePerByte"] = value
+
+	return gasMap
+}
+
+func fillGasMapBuiltInCosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["ChangeOwnerAddress"] = value
+	gasMap["ClaimDeveloperRewards"] = value
+	gasMap["SaveUserName"] = value
+	gasMap["SaveKeyValue"] = value
+	gasMap["ESDTTransfer"] = value
+	gasMap["ESDTBurn"] = value
+	gasMap["ESDTLocalMint"] = value
+	gasMap["ESDTLocalBurn"] = value
+	gasMap["ESDTModifyRoyalties"] = value
+	gasMap["ESDTModifyCreator"] = value
+	gasMap["ESDTNFTCreate"] = value
+	gasMap["ESDTNFTRecreate"] = value
+	gasMap["ESDTNFTUpdate"] = value
+	gasMap["ESDTNFTAddQuantity"] = value
+	gasMap["ESDTNFTBurn"] = value
+	gasMap["ESDTNFTTransfer"] = value
+	gasMap["ESDTNFTChangeCreateOwner"] = value
+	gasMap["ESDTNFTMultiTransfer"] = value
+	gasMap["ESDTNFTAddURI"] = value
+	gasMap["ESDTNFTSetNewURIs"] = value
+	gasMap["ESDTNFTUpdateAttributes"] = value
+	gasMap["SetGuardian"] = value
+	gasMap["GuardAccount"] = value
+	gasMap["TrieLoadPerNode"] = value
+	gasMap["TrieStorePerNode"] = value
+
+	return gasMap
+}
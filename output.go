@@ -226,6 +226,114 @@ func (vmOutput *VMOutput) ReindexTransfers(nextIndexProvider NextOutputTransferI
 	return nil
 }
 
+// Merge merges the other VMOutput, resulted from an execution that followed the current one, into the current VMOutput.
+// The fields are merged as follows:
+//   - ReturnCode and ReturnMessage: the first failure is kept; if the current output is Ok, they are taken from other
+//   - ReturnData, Logs: the items from other are appended
+//   - GasRemaining: taken from other, as it is the gas left after the last execution
+//   - GasRefund: summed
+//   - OutputAccounts: accounts from other are merged with OutputAccount.MergeOutputAccounts, except for the output
+//     transfers that are all appended after being renumbered and the storage byte counters that are summed
+//   - DeletedAccounts, TouchedAccounts: the addresses from other are appended, skipping the ones already present
+//
+// The output transfers of other are renumbered in place through the provided NextOutputTransferIndexProvider so that
+// they follow the transfers already present in the current output. Nothing is modified if an error is returned.
+func (vmOutput *VMOutput) Merge(other *VMOutput, nextIndexProvider NextOutputTransferIndexProvider) error {
+	if other == nil {
+		return ErrNilVMOutput
+	}
+	if check.IfNil(nextIndexProvider) {
+		return ErrNilTransferIndexer
+	}
+	if other.hasUnindexedTransfers() {
+		return ErrTransfersNotIndexed
+	}
+
+	nextAvailableIndex := vmOutput.GetNextAvailableOutputTransferIndex()
+	if nextIndexProvider.GetCrtTransferIndex() < nextAvailableIndex {
+		nextIndexProvider.SetCrtTransferIndex(nextAvailableIndex)
+	}
+	err := other.ReindexTransfers(nextIndexProvider)
+	if err != nil {
+		return err
+	}
+
+	if vmOutput.ReturnCode == Ok {
+		vmOutput.ReturnCode = other.ReturnCode
+		vmOutput.ReturnMessage = other.ReturnMessage
+	}
+	vmOutput.ReturnData = append(vmOutput.ReturnData, other.ReturnData...)
+	vmOutput.GasRemaining = other.GasRemaining
+	if other.GasRefund != nil {
+		vmOutput.GasRefund = big.NewInt(0).Add(ZeroValueIfNil(vmOutput.GasRefund), other.GasRefund)
+	}
+
+	if vmOutput.OutputAccounts == nil && len(other.OutputAccounts) > 0 {
+		vmOutput.OutputAccounts = make(map[string]*OutputAccount, len(other.OutputAccounts))
+	}
+	for key, otherAccount := range other.OutputAccounts {
+		vmOutput.mergeOutputAccount(key, otherAccount)
+	}
+
+	vmOutput.DeletedAccounts = appendMissingAddresses(vmOutput.DeletedAccounts, other.DeletedAccounts)
+	vmOutput.TouchedAccounts = appendMissingAddresses(vmOutput.TouchedAccounts, other.TouchedAccounts)
+	vmOutput.Logs = append(vmOutput.Logs, other.Logs...)
+
+	return nil
+}
+
+func (vmOutput *VMOutput) hasUnindexedTransfers() bool {
+	for _, account := range vmOutput.OutputAccounts {
+		for _, transfer := range account.OutputTransfers {
+			if transfer.Index == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (vmOutput *VMOutput) mergeOutputAccount(key string, otherAccount *OutputAccount) {
+	if otherAccount == nil {
+		return
+	}
+
+	account, exists := vmOutput.OutputAccounts[key]
+	if !exists {
+		account = &OutputAccount{}
+		vmOutput.OutputAccounts[key] = account
+	}
+
+	accountWithoutTransfers := *otherAccount
+	accountWithoutTransfers.OutputTransfers = nil
+	account.MergeOutputAccounts(&accountWithoutTransfers)
+
+	account.OutputTransfers = append(account.OutputTransfers, otherAccount.OutputTransfers...)
+	account.BytesAddedToStorage += otherAccount.BytesAddedToStorage
+	account.BytesDeletedFromStorage += otherAccount.BytesDeletedFromStorage
+	account.BytesConsumedByTxAsNetworking += otherAccount.BytesConsumedByTxAsNetworking
+}
+
+func appendMissingAddresses(addresses [][]byte, newAddresses [][]byte) [][]byte {
+	existing := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		existing[string(address)] = struct{}{}
+	}
+
+	for _, address := range newAddresses {
+		_, found := existing[string(address)]
+		if found {
+			continue
+		}
+
+		existing[string(address)] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
 // MergeOutputAccounts merges the given account into the current one
 func (o *OutputAccount) MergeOutputAccounts(outAcc *OutputAccount) {
 	if len(outAcc.Address) != 0 {
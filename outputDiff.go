@@ -0,0 +1,364 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// OutputDifferenceKind specifies which part of the VMOutput differs
+type OutputDifferenceKind string
+
+const (
+	// ReturnCodeDifference signals a different return code
+	ReturnCodeDifference OutputDifferenceKind = "return code"
+	// ReturnMessageDifference signals a different return message
+	ReturnMessageDifference OutputDifferenceKind = "return message"
+	// ReturnDataDifference signals a different return data item
+	ReturnDataDifference OutputDifferenceKind = "return data"
+	// GasRemainingDifference signals a different remaining gas
+	GasRemainingDifference OutputDifferenceKind = "gas remaining"
+	// GasRefundDifference signals a different gas refund
+	GasRefundDifference OutputDifferenceKind = "gas refund"
+	// MissingAccountDifference signals that an output account exists on only one side
+	MissingAccountDifference OutputDifferenceKind = "missing account"
+	// AccountFieldDifference signals a different nonce, code, code metadata, code deployer, gas used or storage and
+	// networking byte counters on an account
+	AccountFieldDifference OutputDifferenceKind = "account field"
+	// BalanceDifference signals a different account balance
+	BalanceDifference OutputDifferenceKind = "balance"
+	// BalanceDeltaDifference signals a different account balance delta
+	BalanceDeltaDifference OutputDifferenceKind = "balance delta"
+	// StorageUpdateDifference signals a different storage update
+	StorageUpdateDifference OutputDifferenceKind = "storage update"
+	// OutputTransferDifference signals a different output transfer
+	OutputTransferDifference OutputDifferenceKind = "output transfer"
+	// LogDifference signals a different log entry
+	LogDifference OutputDifferenceKind = "log"
+	// DeletedAccountsDifference signals different deleted accounts
+	DeletedAccountsDifference OutputDifferenceKind = "deleted accounts"
+	// TouchedAccountsDifference signals different touched accounts
+	TouchedAccountsDifference OutputDifferenceKind = "touched accounts"
+)
+
+const missingValue = "<missing>"
+
+// OutputDifference holds one difference found between two VMOutputs
+type OutputDifference struct {
+	Kind  OutputDifferenceKind
+	Path  string
+	Left  string
+	Right string
+}
+
+// String returns the human-readable form of the difference
+func (diff *OutputDifference) String() string {
+	return fmt.Sprintf("%s at %s: %s != %s", diff.Kind, diff.Path, diff.Left, diff.Right)
+}
+
+// OutputDifferences is the list of all differences between two VMOutputs
+type OutputDifferences []*OutputDifference
+
+// String renders the differences, one per line
+func (diffs OutputDifferences) String() string {
+	lines := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		lines = append(lines, diff.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// DiffVMOutputs compares the two provided VMOutputs field by field and returns all the differences found.
+// Output accounts are normalized with OutputAccount.MergeOutputAccounts before comparison, so an account
+// obtained by merging compares equal to one that was built directly with the same content.
+// Output transfers are matched by their index and, for transfers sharing the same index, by their position, while
+// logs, return data and account lists are compared by position.
+// Nil and empty values are considered equal.
+func DiffVMOutputs(left *VMOutput, right *VMOutput) OutputDifferences {
+	if left == nil {
+		left = &VMOutput{}
+	}
+	if right == nil {
+		right = &VMOutput{}
+	}
+
+	d := &outputDiffer{
+		diffs: make(OutputDifferences, 0),
+	}
+
+	if left.ReturnCode != right.ReturnCode {
+		d.add(ReturnCodeDifference, "ReturnCode", left.ReturnCode.String(), right.ReturnCode.String())
+	}
+	if left.ReturnMessage != right.ReturnMessage {
+		d.add(ReturnMessageDifference, "ReturnMessage", left.ReturnMessage, right.ReturnMessage)
+	}
+	d.diffBytesSlices(ReturnDataDifference, "ReturnData", left.ReturnData, right.ReturnData)
+	if left.GasRemaining != right.GasRemaining {
+		d.add(GasRemainingDifference, "GasRemaining", fmt.Sprintf("%d", left.GasRemaining), fmt.Sprintf("%d", right.GasRemaining))
+	}
+	d.diffBigInts(GasRefundDifference, "GasRefund", left.GasRefund, right.GasRefund)
+	d.diffOutputAccounts(left.OutputAccounts, right.OutputAccounts)
+	d.diffBytesSlices(DeletedAccountsDifference, "DeletedAccounts", left.DeletedAccounts, right.DeletedAccounts)
+	d.diffBytesSlices(TouchedAccountsDifference, "TouchedAccounts", left.TouchedAccounts, right.TouchedAccounts)
+	d.diffLogs(left.Logs, right.Logs)
+
+	return d.diffs
+}
+
+type outputDiffer struct {
+	diffs OutputDifferences
+}
+
+func (d *outputDiffer) add(kind OutputDifferenceKind, path string, left string, right string) {
+	d.diffs = append(d.diffs, &OutputDifference{
+		Kind:  kind,
+		Path:  path,
+		Left:  left,
+		Right: right,
+	})
+}
+
+func (d *outputDiffer) diffBytes(kind OutputDifferenceKind, path string, left []byte, right []byte) {
+	if !bytes.Equal(left, right) {
+		d.add(kind, path, formatDiffBytes(left), formatDiffBytes(right))
+	}
+}
+
+func (d *outputDiffer) diffBigInts(kind OutputDifferenceKind, path string, left *big.Int, right *big.Int) {
+	leftValue := ZeroValueIfNil(left)
+	rightValue := ZeroValueIfNil(right)
+	if leftValue.Cmp(rightValue) != 0 {
+		d.add(kind, path, leftValue.String(), rightValue.String())
+	}
+}
+
+func (d *outputDiffer) diffUint64(kind OutputDifferenceKind, path string, left uint64, right uint64) {
+	if left != right {
+		d.add(kind, path, fmt.Sprintf("%d", left), fmt.Sprintf("%d", right))
+	}
+}
+
+func (d *outputDiffer) diffBytesSlices(kind OutputDifferenceKind, path string, left [][]byte, right [][]byte) {
+	maxLen := len(left)
+	if len(right) > maxLen {
+		maxLen = len(right)
+	}
+
+	for i := 0; i < maxLen; i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(left):
+			d.add(kind, itemPath, missingValue, formatDiffBytes(right[i]))
+		case i >= len(right):
+			d.add(kind, itemPath, formatDiffBytes(left[i]), missingValue)
+		default:
+			d.diffBytes(kind, itemPath, left[i], right[i])
+		}
+	}
+}
+
+func (d *outputDiffer) diffOutputAccounts(left map[string]*OutputAccount, right map[string]*OutputAccount) {
+	for _, key := range unionOfSortedKeys(left, right) {
+		path := fmt.Sprintf("OutputAccounts[%s]", hex.EncodeToString([]byte(key)))
+		leftAccount, leftExists := left[key]
+		rightAccount, rightExists := right[key]
+		switch {
+		case !leftExists:
+			d.add(MissingAccountDifference, path, missingValue, "present")
+		case !rightExists:
+			d.add(MissingAccountDifference, path, "present", missingValue)
+		default:
+			d.diffOutputAccount(path, normalizeOutputAccount(leftAccount), normalizeOutputAccount(rightAccount))
+		}
+	}
+}
+
+func normalizeOutputAccount(account *OutputAccount) *OutputAccount {
+	normalized := &OutputAccount{}
+	if account != nil {
+		normalized.MergeOutputAccounts(account)
+		normalized.BytesAddedToStorage = account.BytesAddedToStorage
+		normalized.BytesDeletedFromStorage = account.BytesDeletedFromStorage
+		normalized.BytesConsumedByTxAsNetworking = account.BytesConsumedByTxAsNetworking
+	}
+
+	return normalized
+}
+
+func (d *outputDiffer) diffOutputAccount(path string, left *OutputAccount, right *OutputAccount) {
+	d.diffBytes(AccountFieldDifference, path+".Address", left.Address, right.Address)
+	d.diffUint64(AccountFieldDifference, path+".Nonce", left.Nonce, right.Nonce)
+	d.diffBigInts(BalanceDifference, path+".Balance", left.Balance, right.Balance)
+	d.diffBigInts(BalanceDeltaDifference, path+".BalanceDelta", left.BalanceDelta, right.BalanceDelta)
+	d.diffBytes(AccountFieldDifference, path+".Code", left.Code, right.Code)
+	d.diffBytes(AccountFieldDifference, path+".CodeMetadata", left.CodeMetadata, right.CodeMetadata)
+	d.diffBytes(AccountFieldDifference, path+".CodeDeployerAddress", left.CodeDeployerAddress, right.CodeDeployerAddress)
+	d.diffUint64(AccountFieldDifference, path+".GasUsed", left.GasUsed, right.GasUsed)
+	d.diffUint64(AccountFieldDifference, path+".BytesAddedToStorage", left.BytesAddedToStorage, right.BytesAddedToStorage)
+	d.diffUint64(AccountFieldDifference, path+".BytesDeletedFromStorage", left.BytesDeletedFromStorage, right.BytesDeletedFromStorage)
+	d.diffUint64(AccountFieldDifference, path+".BytesConsumedByTxAsNetworking", left.BytesConsumedByTxAsNetworking, right.BytesConsumedByTxAsNetworking)
+	d.diffStorageUpdates(path+".StorageUpdates", left.StorageUpdates, right.StorageUpdates)
+	d.diffOutputTransfers(path+".OutputTransfers", left.OutputTransfers, right.OutputTransfers)
+}
+
+func (d *outputDiffer) diffStorageUpdates(path string, left map[string]*StorageUpdate, right map[string]*StorageUpdate) {
+	for _, key := range unionOfSortedKeys(left, right) {
+		keyPath := fmt.Sprintf("%s[%s]", path, hex.EncodeToString([]byte(key)))
+		leftUpdate, leftExists := left[key]
+		rightUpdate, rightExists := right[key]
+		switch {
+		case !leftExists:
+			d.add(StorageUpdateDifference, keyPath, missingValue, formatStorageUpdate(rightUpdate))
+		case !rightExists:
+			d.add(StorageUpdateDifference, keyPath, formatStorageUpdate(leftUpdate), missingValue)
+		default:
+			leftFormatted := formatStorageUpdate(leftUpdate)
+			rightFormatted := formatStorageUpdate(rightUpdate)
+			if leftFormatted != rightFormatted {
+				d.add(StorageUpdateDifference, keyPath, leftFormatted, rightFormatted)
+			}
+		}
+	}
+}
+
+func (d *outputDiffer) diffOutputTransfers(path string, left []OutputTransfer, right []OutputTransfer) {
+	d.diffUint64(OutputTransferDifference, path+".length", uint64(len(left)), uint64(len(right)))
+
+	leftByIndex := outputTransfersByIndex(left)
+	rightByIndex := outputTransfersByIndex(right)
+
+	indexes := make([]uint32, 0, len(leftByIndex)+len(rightByIndex))
+	for index := range leftByIndex {
+		indexes = append(indexes, index)
+	}
+	for index := range rightByIndex {
+		_, found := leftByIndex[index]
+		if !found {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	for _, index := range indexes {
+		d.diffOutputTransfersWithIndex(fmt.Sprintf("%s[index %d]", path, index), leftByIndex[index], rightByIndex[index])
+	}
+}
+
+// diffOutputTransfersWithIndex compares by position the transfers sharing the same index, so that unindexed
+// transfers, which all have index 0, are not collapsed into one
+func (d *outputDiffer) diffOutputTransfersWithIndex(path string, left []*OutputTransfer, right []*OutputTransfer) {
+	maxLen := len(left)
+	if len(right) > maxLen {
+		maxLen = len(right)
+	}
+
+	for i := 0; i < maxLen; i++ {
+		transferPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(left):
+			d.add(OutputTransferDifference, transferPath, missingValue, formatOutputTransfer(right[i]))
+		case i >= len(right):
+			d.add(OutputTransferDifference, transferPath, formatOutputTransfer(left[i]), missingValue)
+		default:
+			if !bytes.Equal(left[i].CanonicalBytes(), right[i].CanonicalBytes()) {
+				d.add(OutputTransferDifference, transferPath, formatOutputTransfer(left[i]), formatOutputTransfer(right[i]))
+			}
+		}
+	}
+}
+
+func outputTransfersByIndex(transfers []OutputTransfer) map[uint32][]*OutputTransfer {
+	byIndex := make(map[uint32][]*OutputTransfer, len(transfers))
+	for i := range transfers {
+		byIndex[transfers[i].Index] = append(byIndex[transfers[i].Index], &transfers[i])
+	}
+
+	return byIndex
+}
+
+func (d *outputDiffer) diffLogs(left []*LogEntry, right []*LogEntry) {
+	maxLen := len(left)
+	if len(right) > maxLen {
+		maxLen = len(right)
+	}
+
+	for i := 0; i < maxLen; i++ {
+		path := fmt.Sprintf("Logs[%d]", i)
+		switch {
+		case i >= len(left):
+			d.add(LogDifference, path, missingValue, formatLogEntry(right[i]))
+		case i >= len(right):
+			d.add(LogDifference, path, formatLogEntry(left[i]), missingValue)
+		default:
+			if !bytes.Equal(left[i].CanonicalBytes(), right[i].CanonicalBytes()) {
+				d.add(LogDifference, path, formatLogEntry(left[i]), formatLogEntry(right[i]))
+			}
+		}
+	}
+}
+
+func unionOfSortedKeys[T any](left map[string]T, right map[string]T) []string {
+	keys := sortedKeys(left)
+	for key := range right {
+		_, found := left[key]
+		if !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func formatDiffBytes(value []byte) string {
+	return "0x" + hex.EncodeToString(value)
+}
+
+func formatDiffBytesSlice(values [][]byte) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, formatDiffBytes(value))
+	}
+
+	return "[" + strings.Join(formatted, " ") + "]"
+}
+
+func formatStorageUpdate(update *StorageUpdate) string {
+	if update == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("key=%s data=%s written=%v", formatDiffBytes(update.Offset), formatDiffBytes(update.Data), update.Written)
+}
+
+func formatOutputTransfer(transfer *OutputTransfer) string {
+	return fmt.Sprintf("index=%d value=%s gasLimit=%d gasLocked=%d data=%s asyncData=%s callType=%d sender=%s",
+		transfer.Index,
+		ZeroValueIfNil(transfer.Value).String(),
+		transfer.GasLimit,
+		transfer.GasLocked,
+		formatDiffBytes(transfer.Data),
+		formatDiffBytes(transfer.AsyncData),
+		transfer.CallType,
+		formatDiffBytes(transfer.SenderAddress),
+	)
+}
+
+func formatLogEntry(logEntry *LogEntry) string {
+	if logEntry == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("identifier=%s address=%s topics=%s data=%s",
+		string(logEntry.Identifier),
+		formatDiffBytes(logEntry.Address),
+		formatDiffBytesSlice(logEntry.Topics),
+		formatDiffBytesSlice(logEntry.Data),
+	)
+}
@@ -0,0 +1,182 @@
+package vmcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffVMOutputs_EqualOutputsShouldReturnEmpty(t *testing.T) {
+	t.Parallel()
+
+	diffs := DiffVMOutputs(createVMOutputForEncoding(5), createVMOutputForEncoding(5))
+	assert.Empty(t, diffs)
+	assert.Equal(t, "", diffs.String())
+
+	assert.Empty(t, DiffVMOutputs(nil, &VMOutput{}))
+}
+
+func TestDiffVMOutputs_ShouldReportEveryDifference(t *testing.T) {
+	t.Parallel()
+
+	left := createVMOutputForEncoding(2)
+	right := createVMOutputForEncoding(2)
+
+	right.ReturnCode = Ok
+	right.ReturnData = right.ReturnData[:1]
+	right.GasRemaining = 99
+	right.OutputAccounts["address0"].BalanceDelta = big.NewInt(5)
+	right.OutputAccounts["address1"].StorageUpdates["key0"].Data = []byte("other")
+	delete(right.OutputAccounts["address1"].StorageUpdates, "key1")
+	right.OutputAccounts["address1"].OutputTransfers[0].GasLimit = 1
+	right.OutputAccounts["address2"] = &OutputAccount{Address: []byte("address2")}
+	right.Logs[0].Identifier = []byte("other")
+
+	diffs := DiffVMOutputs(left, right)
+	kinds := make(map[OutputDifferenceKind]int)
+	for _, diff := range diffs {
+		kinds[diff.Kind]++
+	}
+
+	expectedKinds := map[OutputDifferenceKind]int{
+		ReturnCodeDifference:     1,
+		ReturnDataDifference:     1,
+		GasRemainingDifference:   1,
+		BalanceDeltaDifference:   1,
+		StorageUpdateDifference:  2,
+		OutputTransferDifference: 1,
+		MissingAccountDifference: 1,
+		LogDifference:            1,
+	}
+	assert.Equal(t, expectedKinds, kinds)
+
+	rendered := diffs.String()
+	assert.Contains(t, rendered, "return code at ReturnCode: user error != ok")
+	assert.Contains(t, rendered, "ReturnData[1]: 0x72657432 != <missing>")
+	assert.Contains(t, rendered, "balance delta at OutputAccounts[6164647265737330].BalanceDelta: 0 != 5")
+}
+
+func TestDiffVMOutputs_MergedAndUnmergedAccountsShouldCompareEqual(t *testing.T) {
+	t.Parallel()
+
+	transfer := OutputTransfer{Index: 1, Value: big.NewInt(10), Data: []byte("data")}
+	unmerged := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {
+				Address:         []byte("addr"),
+				OutputTransfers: []OutputTransfer{transfer},
+			},
+		},
+	}
+
+	merged := &OutputAccount{}
+	merged.MergeOutputAccounts(&OutputAccount{
+		Address:         []byte("addr"),
+		StorageUpdates:  map[string]*StorageUpdate{},
+		OutputTransfers: []OutputTransfer{transfer},
+	})
+	mergedOutput := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": merged,
+		},
+	}
+
+	assert.Empty(t, DiffVMOutputs(unmerged, mergedOutput))
+	require.Nil(t, unmerged.OutputAccounts["addr"].BalanceDelta)
+}
+
+func TestDiffVMOutputs_OutputTransfersShouldBeMatchedByIndex(t *testing.T) {
+	t.Parallel()
+
+	first := OutputTransfer{Index: 1, Value: big.NewInt(1)}
+	second := OutputTransfer{Index: 2, Value: big.NewInt(2)}
+	left := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {OutputTransfers: []OutputTransfer{first, second}},
+		},
+	}
+	right := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {OutputTransfers: []OutputTransfer{second, first}},
+		},
+	}
+	assert.Empty(t, DiffVMOutputs(left, right))
+
+	right.OutputAccounts["addr"].OutputTransfers = []OutputTransfer{second}
+	diffs := DiffVMOutputs(left, right)
+	require.Len(t, diffs, 2)
+	assert.Equal(t, OutputTransferDifference, diffs[0].Kind)
+	assert.Equal(t, "OutputAccounts[61646472].OutputTransfers.length", diffs[0].Path)
+	assert.Equal(t, "2", diffs[0].Left)
+	assert.Equal(t, "1", diffs[0].Right)
+	assert.Equal(t, OutputTransferDifference, diffs[1].Kind)
+	assert.Equal(t, "OutputAccounts[61646472].OutputTransfers[index 1][0]", diffs[1].Path)
+	assert.Equal(t, missingValue, diffs[1].Right)
+}
+
+func TestDiffVMOutputs_UnindexedOutputTransfersShouldBeComparedByPosition(t *testing.T) {
+	t.Parallel()
+
+	left := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {OutputTransfers: []OutputTransfer{{Value: big.NewInt(1)}, {Value: big.NewInt(2)}}},
+		},
+	}
+	right := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {OutputTransfers: []OutputTransfer{{Value: big.NewInt(1)}, {Value: big.NewInt(3)}}},
+		},
+	}
+
+	diffs := DiffVMOutputs(left, right)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "OutputAccounts[61646472].OutputTransfers[index 0][1]", diffs[0].Path)
+	assert.Contains(t, diffs[0].Left, "value=2")
+	assert.Contains(t, diffs[0].Right, "value=3")
+}
+
+func TestDiffVMOutputs_StorageAndNetworkingBytesShouldBeCompared(t *testing.T) {
+	t.Parallel()
+
+	left := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {BytesAddedToStorage: 1, BytesDeletedFromStorage: 2, BytesConsumedByTxAsNetworking: 3},
+		},
+	}
+	right := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {BytesAddedToStorage: 4, BytesDeletedFromStorage: 5, BytesConsumedByTxAsNetworking: 6},
+		},
+	}
+
+	diffs := DiffVMOutputs(left, right)
+	require.Len(t, diffs, 3)
+	assert.Equal(t, "OutputAccounts[61646472].BytesAddedToStorage", diffs[0].Path)
+	assert.Equal(t, "OutputAccounts[61646472].BytesDeletedFromStorage", diffs[1].Path)
+	assert.Equal(t, "OutputAccounts[61646472].BytesConsumedByTxAsNetworking", diffs[2].Path)
+	for _, diff := range diffs {
+		assert.Equal(t, AccountFieldDifference, diff.Kind)
+	}
+}
+
+func TestDiffVMOutputs_StorageUpdateShouldContainTheKey(t *testing.T) {
+	t.Parallel()
+
+	left := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {StorageUpdates: map[string]*StorageUpdate{"key": {Offset: []byte("key"), Data: []byte("a")}}},
+		},
+	}
+	right := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {StorageUpdates: map[string]*StorageUpdate{"key": {Offset: []byte("key"), Data: []byte("b")}}},
+		},
+	}
+
+	diffs := DiffVMOutputs(left, right)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "key=0x6b6579 data=0x61 written=false", diffs[0].Left)
+	assert.Equal(t, "key=0x6b6579 data=0x62 written=false", diffs[0].Right)
+}
@@ -0,0 +1,227 @@
+package vmcommon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"sort"
+)
+
+const (
+	bigIntZero     byte = 0
+	bigIntPositive byte = 1
+	bigIntNegative byte = 2
+)
+
+// canonicalEncoder writes values in a fixed, length-prefixed binary layout. Every value
+// written is self-delimiting so that the concatenation of several fields can not be ambiguous.
+// Nil and empty byte slices are encoded identically, and a nil *big.Int is encoded as zero. The structs are prefixed
+// by a presence flag, so a nil pointer is encoded as a single zero byte.
+type canonicalEncoder struct {
+	buff *bytes.Buffer
+}
+
+func newCanonicalEncoder() *canonicalEncoder {
+	return &canonicalEncoder{
+		buff: &bytes.Buffer{},
+	}
+}
+
+func (enc *canonicalEncoder) writeUint64(value uint64) {
+	var encoded [8]byte
+	binary.BigEndian.PutUint64(encoded[:], value)
+	enc.buff.Write(encoded[:])
+}
+
+func (enc *canonicalEncoder) writeUint32(value uint32) {
+	var encoded [4]byte
+	binary.BigEndian.PutUint32(encoded[:], value)
+	enc.buff.Write(encoded[:])
+}
+
+func (enc *canonicalEncoder) writeBool(value bool) {
+	if value {
+		enc.buff.WriteByte(1)
+		return
+	}
+	enc.buff.WriteByte(0)
+}
+
+func (enc *canonicalEncoder) writeBytes(value []byte) {
+	enc.writeUint32(uint32(len(value)))
+	enc.buff.Write(value)
+}
+
+func (enc *canonicalEncoder) writeBytesSlice(values [][]byte) {
+	enc.writeUint32(uint32(len(values)))
+	for _, value := range values {
+		enc.writeBytes(value)
+	}
+}
+
+func (enc *canonicalEncoder) writeBigInt(value *big.Int) {
+	switch {
+	case value == nil || value.Sign() == 0:
+		enc.buff.WriteByte(bigIntZero)
+		return
+	case value.Sign() > 0:
+		enc.buff.WriteByte(bigIntPositive)
+	default:
+		enc.buff.WriteByte(bigIntNegative)
+	}
+	enc.writeBytes(value.Bytes())
+}
+
+func (enc *canonicalEncoder) writeOutputAccount(account *OutputAccount) {
+	if account == nil {
+		enc.writeBool(false)
+		return
+	}
+	enc.writeBool(true)
+
+	enc.writeBytes(account.Address)
+	enc.writeUint64(account.Nonce)
+	enc.writeBigInt(account.Balance)
+	enc.writeStorageUpdates(account.StorageUpdates)
+	enc.writeBytes(account.Code)
+	enc.writeBytes(account.CodeMetadata)
+	enc.writeBytes(account.CodeDeployerAddress)
+	enc.writeBigInt(account.BalanceDelta)
+	enc.writeUint32(uint32(len(account.OutputTransfers)))
+	for i := range account.OutputTransfers {
+		enc.writeOutputTransfer(&account.OutputTransfers[i])
+	}
+	enc.writeUint64(account.GasUsed)
+	enc.writeUint64(account.BytesAddedToStorage)
+	enc.writeUint64(account.BytesDeletedFromStorage)
+	enc.writeUint64(account.BytesConsumedByTxAsNetworking)
+}
+
+func (enc *canonicalEncoder) writeStorageUpdates(updates map[string]*StorageUpdate) {
+	keys := sortedKeys(updates)
+	enc.writeUint32(uint32(len(keys)))
+	for _, key := range keys {
+		enc.writeBytes([]byte(key))
+
+		update := updates[key]
+		if update == nil {
+			enc.writeBool(false)
+			continue
+		}
+		enc.writeBool(true)
+		enc.writeBytes(update.Offset)
+		enc.writeBytes(update.Data)
+		enc.writeBool(update.Written)
+	}
+}
+
+func (enc *canonicalEncoder) writeOutputTransfer(transfer *OutputTransfer) {
+	if transfer == nil {
+		enc.writeBool(false)
+		return
+	}
+	enc.writeBool(true)
+
+	enc.writeUint32(transfer.Index)
+	enc.writeBigInt(transfer.Value)
+	enc.writeUint64(transfer.GasLimit)
+	enc.writeUint64(transfer.GasLocked)
+	enc.writeBytes(transfer.AsyncData)
+	enc.writeBytes(transfer.Data)
+	enc.writeUint32(uint32(transfer.CallType))
+	enc.writeBytes(transfer.SenderAddress)
+}
+
+func (enc *canonicalEncoder) writeLogEntry(logEntry *LogEntry) {
+	if logEntry == nil {
+		enc.writeBool(false)
+		return
+	}
+	enc.writeBool(true)
+
+	enc.writeBytes(logEntry.Identifier)
+	enc.writeBytes(logEntry.Address)
+	enc.writeBytesSlice(logEntry.Topics)
+	enc.writeBytesSlice(logEntry.Data)
+}
+
+func (enc *canonicalEncoder) writeVMOutput(vmOutput *VMOutput) {
+	if vmOutput == nil {
+		enc.writeBool(false)
+		return
+	}
+	enc.writeBool(true)
+
+	enc.writeBytesSlice(vmOutput.ReturnData)
+	enc.writeUint64(uint64(vmOutput.ReturnCode))
+	enc.writeBytes([]byte(vmOutput.ReturnMessage))
+	enc.writeUint64(vmOutput.GasRemaining)
+	enc.writeBigInt(vmOutput.GasRefund)
+
+	keys := sortedKeys(vmOutput.OutputAccounts)
+	enc.writeUint32(uint32(len(keys)))
+	for _, key := range keys {
+		enc.writeBytes([]byte(key))
+		enc.writeOutputAccount(vmOutput.OutputAccounts[key])
+	}
+
+	enc.writeBytesSlice(vmOutput.DeletedAccounts)
+	enc.writeBytesSlice(vmOutput.TouchedAccounts)
+
+	enc.writeUint32(uint32(len(vmOutput.Logs)))
+	for _, logEntry := range vmOutput.Logs {
+		enc.writeLogEntry(logEntry)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// CanonicalBytes returns a deterministic binary encoding of the VMOutput. Map entries (output accounts and
+// their storage updates) are written in ascending key order and all the other fields are written in a fixed order,
+// so two identical outputs will always produce the same bytes, regardless of the map iteration order.
+func (vmOutput *VMOutput) CanonicalBytes() []byte {
+	enc := newCanonicalEncoder()
+	enc.writeVMOutput(vmOutput)
+
+	return enc.buff.Bytes()
+}
+
+// Hash returns the sha256 hash of the canonical encoding of the VMOutput
+func (vmOutput *VMOutput) Hash() []byte {
+	hash := sha256.Sum256(vmOutput.CanonicalBytes())
+	return hash[:]
+}
+
+// CanonicalBytes returns a deterministic binary encoding of the output account, storage updates being written
+// in ascending key order
+func (o *OutputAccount) CanonicalBytes() []byte {
+	enc := newCanonicalEncoder()
+	enc.writeOutputAccount(o)
+
+	return enc.buff.Bytes()
+}
+
+// CanonicalBytes returns a deterministic binary encoding of the output transfer
+func (transfer *OutputTransfer) CanonicalBytes() []byte {
+	enc := newCanonicalEncoder()
+	enc.writeOutputTransfer(transfer)
+
+	return enc.buff.Bytes()
+}
+
+// CanonicalBytes returns a deterministic binary encoding of the log entry
+func (logEntry *LogEntry) CanonicalBytes() []byte {
+	enc := newCanonicalEncoder()
+	enc.writeLogEntry(logEntry)
+
+	return enc.buff.Bytes()
+}
@@ -0,0 +1,188 @@
+package vmcommon
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createVMOutputForEncoding(numAccounts int) *VMOutput {
+	vmOutput := &VMOutput{
+		ReturnData:     [][]byte{[]byte("ret1"), []byte("ret2")},
+		ReturnCode:     UserError,
+		ReturnMessage:  "message",
+		GasRemaining:   100,
+		GasRefund:      big.NewInt(10),
+		OutputAccounts: make(map[string]*OutputAccount),
+		DeletedAccounts: [][]byte{
+			[]byte("deleted"),
+		},
+		TouchedAccounts: [][]byte{
+			[]byte("touched"),
+		},
+		Logs: []*LogEntry{
+			{
+				Identifier: []byte("identifier"),
+				Address:    []byte("address"),
+				Topics:     [][]byte{[]byte("topic")},
+				Data:       [][]byte{[]byte("data")},
+			},
+		},
+	}
+
+	for i := 0; i < numAccounts; i++ {
+		address := []byte(fmt.Sprintf("address%d", i))
+		account := &OutputAccount{
+			Address:        address,
+			Nonce:          uint64(i),
+			BalanceDelta:   big.NewInt(int64(-i)),
+			StorageUpdates: make(map[string]*StorageUpdate),
+			OutputTransfers: []OutputTransfer{
+				{
+					Index:    uint32(i + 1),
+					Value:    big.NewInt(int64(i)),
+					GasLimit: 1000,
+					Data:     []byte("transfer data"),
+					CallType: vm.AsynchronousCall,
+				},
+			},
+		}
+		for j := 0; j < numAccounts; j++ {
+			key := []byte(fmt.Sprintf("key%d", j))
+			account.StorageUpdates[string(key)] = &StorageUpdate{
+				Offset:  key,
+				Data:    []byte(fmt.Sprintf("value%d", j)),
+				Written: j%2 == 0,
+			}
+		}
+		vmOutput.OutputAccounts[string(address)] = account
+	}
+
+	return vmOutput
+}
+
+func TestVMOutput_CanonicalBytesShouldBeDeterministic(t *testing.T) {
+	t.Parallel()
+
+	first := createVMOutputForEncoding(20)
+	expectedBytes := first.CanonicalBytes()
+	expectedHash := first.Hash()
+	require.Len(t, expectedHash, 32)
+
+	for i := 0; i < 10; i++ {
+		second := createVMOutputForEncoding(20)
+		assert.Equal(t, expectedBytes, second.CanonicalBytes())
+		assert.Equal(t, expectedHash, second.Hash())
+	}
+}
+
+func TestVMOutput_HashShouldChangeOnAnyField(t *testing.T) {
+	t.Parallel()
+
+	initialHash := createVMOutputForEncoding(3).Hash()
+
+	modifiers := map[string]func(vmOutput *VMOutput){
+		"return data": func(vmOutput *VMOutput) {
+			vmOutput.ReturnData = [][]byte{[]byte("ret1ret2")}
+		},
+		"return code": func(vmOutput *VMOutput) {
+			vmOutput.ReturnCode = Ok
+		},
+		"gas refund": func(vmOutput *VMOutput) {
+			vmOutput.GasRefund = big.NewInt(-10)
+		},
+		"balance delta": func(vmOutput *VMOutput) {
+			vmOutput.OutputAccounts["address1"].BalanceDelta = big.NewInt(1)
+		},
+		"storage written flag": func(vmOutput *VMOutput) {
+			vmOutput.OutputAccounts["address1"].StorageUpdates["key1"].Written = true
+		},
+		"transfer index": func(vmOutput *VMOutput) {
+			vmOutput.OutputAccounts["address1"].OutputTransfers[0].Index = 7
+		},
+		"log topic": func(vmOutput *VMOutput) {
+			vmOutput.Logs[0].Topics = append(vmOutput.Logs[0].Topics, nil)
+		},
+		"touched accounts": func(vmOutput *VMOutput) {
+			vmOutput.TouchedAccounts = nil
+		},
+	}
+
+	for name, modifier := range modifiers {
+		vmOutput := createVMOutputForEncoding(3)
+		modifier(vmOutput)
+		assert.NotEqual(t, initialHash, vmOutput.Hash(), name)
+	}
+}
+
+func TestVMOutput_CanonicalBytesNilAndEmptyValuesShouldBeEquivalent(t *testing.T) {
+	t.Parallel()
+
+	withNils := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {
+				Address: []byte("addr"),
+			},
+		},
+	}
+	withEmpty := &VMOutput{
+		ReturnData: make([][]byte, 0),
+		GasRefund:  big.NewInt(0),
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {
+				Address:         []byte("addr"),
+				Balance:         big.NewInt(0),
+				BalanceDelta:    big.NewInt(0),
+				StorageUpdates:  make(map[string]*StorageUpdate),
+				Code:            make([]byte, 0),
+				OutputTransfers: make([]OutputTransfer, 0),
+			},
+		},
+		Logs: make([]*LogEntry, 0),
+	}
+
+	assert.Equal(t, withNils.CanonicalBytes(), withEmpty.CanonicalBytes())
+	assert.Equal(t, withNils.Hash(), withEmpty.Hash())
+}
+
+func TestOutputAccount_CanonicalBytes(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := createVMOutputForEncoding(2)
+	account := vmOutput.OutputAccounts["address1"]
+	encoded := account.CanonicalBytes()
+	assert.Contains(t, string(vmOutput.CanonicalBytes()), string(encoded))
+
+	var nilAccount *OutputAccount
+	assert.Equal(t, []byte{0}, nilAccount.CanonicalBytes())
+}
+
+func TestOutputTransferAndLogEntry_CanonicalBytes(t *testing.T) {
+	t.Parallel()
+
+	transfer := &OutputTransfer{Index: 1, Value: big.NewInt(5)}
+	otherTransfer := &OutputTransfer{Index: 1, Value: big.NewInt(-5)}
+	assert.NotEqual(t, transfer.CanonicalBytes(), otherTransfer.CanonicalBytes())
+
+	logEntry := &LogEntry{Topics: [][]byte{[]byte("ab")}}
+	otherLogEntry := &LogEntry{Topics: [][]byte{[]byte("a"), []byte("b")}}
+	assert.NotEqual(t, logEntry.CanonicalBytes(), otherLogEntry.CanonicalBytes())
+
+	var nilTransfer *OutputTransfer
+	assert.Equal(t, []byte{0}, nilTransfer.CanonicalBytes())
+	var nilLogEntry *LogEntry
+	assert.Equal(t, []byte{0}, nilLogEntry.CanonicalBytes())
+}
+
+func TestVMOutput_NilShouldEncodeAsMarker(t *testing.T) {
+	t.Parallel()
+
+	var nilVMOutput *VMOutput
+	assert.Equal(t, []byte{0}, nilVMOutput.CanonicalBytes())
+	assert.Len(t, nilVMOutput.Hash(), 32)
+	assert.NotEqual(t, nilVMOutput.Hash(), (&VMOutput{}).Hash())
+}
@@ -0,0 +1,127 @@
+package vmcommon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// OutputViolationKind specifies which invariant of the VMOutput is broken
+type OutputViolationKind string
+
+const (
+	// NilOutputAccountViolation signals a nil entry in the output accounts map
+	NilOutputAccountViolation OutputViolationKind = "nil output account"
+	// AddressMismatchViolation signals an output account whose address does not match its map key
+	AddressMismatchViolation OutputViolationKind = "address mismatch"
+	// NegativeBalanceViolation signals an output account with a negative balance
+	NegativeBalanceViolation OutputViolationKind = "negative balance"
+	// UnindexedTransferViolation signals an output transfer with index 0
+	UnindexedTransferViolation OutputViolationKind = "unindexed transfer"
+	// DuplicateTransferIndexViolation signals an output transfer index used more than once
+	DuplicateTransferIndexViolation OutputViolationKind = "duplicate transfer index"
+	// NilTransferValueViolation signals an output transfer with a nil value
+	NilTransferValueViolation OutputViolationKind = "nil transfer value"
+	// GasRemainingViolation signals that the remaining gas is greater than the provided gas
+	GasRemainingViolation OutputViolationKind = "gas remaining above gas provided"
+)
+
+// OutputViolation holds one broken invariant found on a VMOutput
+type OutputViolation struct {
+	Kind    OutputViolationKind
+	Path    string
+	Message string
+}
+
+// String returns the human-readable form of the violation
+func (violation *OutputViolation) String() string {
+	return fmt.Sprintf("%s at %s: %s", violation.Kind, violation.Path, violation.Message)
+}
+
+// OutputViolations is the list of all broken invariants of a VMOutput
+type OutputViolations []*OutputViolation
+
+// String renders the violations, one per line
+func (violations OutputViolations) String() string {
+	lines := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		lines = append(lines, violation.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Err returns nil if there are no violations, otherwise an error wrapping ErrInvalidVMOutput and describing
+// all the violations
+func (violations OutputViolations) Err() error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidVMOutput, strings.Join(messages, "; "))
+}
+
+// ValidateVMOutput checks the VMOutput for internal consistency and returns all the violations found.
+// The provided VMInput is optional: when it is nil, the checks that need the input are skipped.
+// The accounts are visited in ascending key order so the result is deterministic.
+func ValidateVMOutput(vmOutput *VMOutput, vmInput *VMInput) OutputViolations {
+	violations := make(OutputViolations, 0)
+	if vmOutput == nil {
+		return violations
+	}
+
+	add := func(kind OutputViolationKind, path string, message string) {
+		violations = append(violations, &OutputViolation{
+			Kind:    kind,
+			Path:    path,
+			Message: message,
+		})
+	}
+
+	if vmInput != nil && vmOutput.GasRemaining > vmInput.GasProvided {
+		add(GasRemainingViolation, "GasRemaining", fmt.Sprintf("remaining %d, provided %d", vmOutput.GasRemaining, vmInput.GasProvided))
+	}
+
+	transferIndexes := make(map[uint32]string)
+	for _, key := range sortedKeys(vmOutput.OutputAccounts) {
+		path := fmt.Sprintf("OutputAccounts[%s]", hex.EncodeToString([]byte(key)))
+		account := vmOutput.OutputAccounts[key]
+		if account == nil {
+			add(NilOutputAccountViolation, path, "account is nil")
+			continue
+		}
+
+		if !bytes.Equal(account.Address, []byte(key)) {
+			add(AddressMismatchViolation, path+".Address", fmt.Sprintf("address %s", hex.EncodeToString(account.Address)))
+		}
+		if account.Balance != nil && account.Balance.Sign() < 0 {
+			add(NegativeBalanceViolation, path+".Balance", fmt.Sprintf("balance %s", account.Balance.String()))
+		}
+
+		for i, transfer := range account.OutputTransfers {
+			transferPath := fmt.Sprintf("%s.OutputTransfers[%d]", path, i)
+			if transfer.Value == nil {
+				add(NilTransferValueViolation, transferPath+".Value", "value is nil")
+			}
+			if transfer.Index == 0 {
+				add(UnindexedTransferViolation, transferPath+".Index", "index is 0")
+				continue
+			}
+
+			previousPath, found := transferIndexes[transfer.Index]
+			if found {
+				add(DuplicateTransferIndexViolation, transferPath+".Index", fmt.Sprintf("index %d already used at %s", transfer.Index, previousPath))
+				continue
+			}
+			transferIndexes[transfer.Index] = transferPath
+		}
+	}
+
+	return violations
+}
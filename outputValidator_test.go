@@ -0,0 +1,76 @@
+package vmcommon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVMOutput_ValidOutputShouldReturnEmpty(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := createVMOutputForEncoding(5)
+	violations := ValidateVMOutput(vmOutput, &VMInput{GasProvided: 100})
+	assert.Empty(t, violations)
+	assert.Nil(t, violations.Err())
+
+	assert.Empty(t, ValidateVMOutput(nil, nil))
+}
+
+func TestValidateVMOutput_ShouldReturnAllViolations(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{
+		GasRemaining: 101,
+		OutputAccounts: map[string]*OutputAccount{
+			"addr1": {
+				Address: []byte("addr1"),
+				Balance: big.NewInt(-1),
+				OutputTransfers: []OutputTransfer{
+					{Index: 1, Value: big.NewInt(0)},
+					{Index: 0, Value: big.NewInt(0)},
+				},
+			},
+			"addr2": {
+				Address: []byte("other"),
+				OutputTransfers: []OutputTransfer{
+					{Index: 1, Value: nil},
+				},
+			},
+			"addr3": nil,
+		},
+	}
+
+	violations := ValidateVMOutput(vmOutput, &VMInput{GasProvided: 100})
+	kinds := make([]OutputViolationKind, 0, len(violations))
+	for _, violation := range violations {
+		kinds = append(kinds, violation.Kind)
+	}
+
+	expectedKinds := []OutputViolationKind{
+		GasRemainingViolation,
+		NegativeBalanceViolation,
+		UnindexedTransferViolation,
+		AddressMismatchViolation,
+		NilTransferValueViolation,
+		DuplicateTransferIndexViolation,
+		NilOutputAccountViolation,
+	}
+	assert.Equal(t, expectedKinds, kinds)
+
+	err := violations.Err()
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidVMOutput))
+	assert.Contains(t, err.Error(), "index 1 already used at OutputAccounts[6164647231].OutputTransfers[0]")
+}
+
+func TestValidateVMOutput_NilInputShouldSkipGasCheck(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{GasRemaining: 1000}
+	assert.Empty(t, ValidateVMOutput(vmOutput, nil))
+	assert.Len(t, ValidateVMOutput(vmOutput, &VMInput{GasProvided: 999}), 1)
+}
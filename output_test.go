@@ -87,3 +87,146 @@ func TestOutputContext_MergeCompleteAccounts(t *testing.T) {
 	left.MergeOutputAccounts(right)
 	require.Equal(t, expected, left)
 }
+
+type transferIndexProviderStub struct {
+	crtIndex uint32
+}
+
+func (stub *transferIndexProviderStub) NextOutputTransferIndex() uint32 {
+	index := stub.crtIndex
+	stub.crtIndex++
+	return index
+}
+
+func (stub *transferIndexProviderStub) GetCrtTransferIndex() uint32 {
+	return stub.crtIndex
+}
+
+func (stub *transferIndexProviderStub) SetCrtTransferIndex(index uint32) {
+	stub.crtIndex = index
+}
+
+func (stub *transferIndexProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func TestVMOutput_MergeNilArgumentsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{}
+	err := vmOutput.Merge(nil, &transferIndexProviderStub{crtIndex: 1})
+	require.Equal(t, ErrNilVMOutput, err)
+
+	err = vmOutput.Merge(&VMOutput{}, nil)
+	require.Equal(t, ErrNilTransferIndexer, err)
+}
+
+func TestVMOutput_MergeUnindexedTransfersShouldErrWithoutChanges(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{ReturnData: [][]byte{[]byte("a")}}
+	other := &VMOutput{
+		ReturnData: [][]byte{[]byte("b")},
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {
+				OutputTransfers: []OutputTransfer{{Index: 1}, {Index: 0}},
+			},
+		},
+	}
+
+	err := vmOutput.Merge(other, &transferIndexProviderStub{crtIndex: 1})
+	require.Equal(t, ErrTransfersNotIndexed, err)
+	require.Equal(t, [][]byte{[]byte("a")}, vmOutput.ReturnData)
+	require.Equal(t, uint32(1), other.OutputAccounts["addr"].OutputTransfers[0].Index)
+}
+
+func TestVMOutput_Merge(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{
+		ReturnData:   [][]byte{[]byte("first")},
+		ReturnCode:   Ok,
+		GasRemaining: 1000,
+		GasRefund:    big.NewInt(5),
+		OutputAccounts: map[string]*OutputAccount{
+			"addr1": {
+				Address:             []byte("addr1"),
+				BalanceDelta:        big.NewInt(10),
+				StorageUpdates:      map[string]*StorageUpdate{"key1": {Offset: []byte("key1"), Data: []byte("v1")}},
+				OutputTransfers:     []OutputTransfer{{Index: 1, Value: big.NewInt(1)}},
+				BytesAddedToStorage: 10,
+			},
+		},
+		DeletedAccounts: [][]byte{[]byte("deleted1")},
+		TouchedAccounts: [][]byte{[]byte("addr1")},
+		Logs:            []*LogEntry{{Identifier: []byte("log1")}},
+	}
+	other := &VMOutput{
+		ReturnData:    [][]byte{[]byte("second")},
+		ReturnCode:    UserError,
+		ReturnMessage: "failed",
+		GasRemaining:  400,
+		GasRefund:     big.NewInt(3),
+		OutputAccounts: map[string]*OutputAccount{
+			"addr1": {
+				Address:             []byte("addr1"),
+				BalanceDelta:        big.NewInt(-4),
+				StorageUpdates:      map[string]*StorageUpdate{"key2": {Offset: []byte("key2"), Data: []byte("v2")}},
+				OutputTransfers:     []OutputTransfer{{Index: 1, Value: big.NewInt(2)}},
+				BytesAddedToStorage: 5,
+			},
+			"addr2": {
+				Address:         []byte("addr2"),
+				BalanceDelta:    big.NewInt(4),
+				OutputTransfers: []OutputTransfer{{Index: 2, Value: big.NewInt(3)}},
+			},
+		},
+		DeletedAccounts: [][]byte{[]byte("deleted1"), []byte("deleted2")},
+		TouchedAccounts: [][]byte{[]byte("addr1"), []byte("addr2")},
+		Logs:            []*LogEntry{{Identifier: []byte("log2")}},
+	}
+
+	indexProvider := &transferIndexProviderStub{crtIndex: 1}
+	err := vmOutput.Merge(other, indexProvider)
+	require.Nil(t, err)
+
+	assert.Equal(t, UserError, vmOutput.ReturnCode)
+	assert.Equal(t, "failed", vmOutput.ReturnMessage)
+	assert.Equal(t, [][]byte{[]byte("first"), []byte("second")}, vmOutput.ReturnData)
+	assert.Equal(t, uint64(400), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(8), vmOutput.GasRefund)
+	assert.Equal(t, [][]byte{[]byte("deleted1"), []byte("deleted2")}, vmOutput.DeletedAccounts)
+	assert.Equal(t, [][]byte{[]byte("addr1"), []byte("addr2")}, vmOutput.TouchedAccounts)
+	require.Len(t, vmOutput.Logs, 2)
+	assert.Equal(t, []byte("log2"), vmOutput.Logs[1].Identifier)
+
+	account1 := vmOutput.OutputAccounts["addr1"]
+	assert.Equal(t, big.NewInt(6), account1.BalanceDelta)
+	assert.Len(t, account1.StorageUpdates, 2)
+	assert.Equal(t, uint64(15), account1.BytesAddedToStorage)
+	require.Len(t, account1.OutputTransfers, 2)
+	assert.Equal(t, uint32(1), account1.OutputTransfers[0].Index)
+	assert.Equal(t, uint32(2), account1.OutputTransfers[1].Index)
+	assert.Equal(t, big.NewInt(2), account1.OutputTransfers[1].Value)
+
+	account2 := vmOutput.OutputAccounts["addr2"]
+	assert.Equal(t, []byte("addr2"), account2.Address)
+	assert.Equal(t, big.NewInt(4), account2.BalanceDelta)
+	require.Len(t, account2.OutputTransfers, 1)
+	assert.Equal(t, uint32(3), account2.OutputTransfers[0].Index)
+
+	assert.Equal(t, uint32(4), indexProvider.GetCrtTransferIndex())
+	assert.Equal(t, uint32(4), vmOutput.GetNextAvailableOutputTransferIndex())
+}
+
+func TestVMOutput_MergeShouldKeepFirstFailure(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{ReturnCode: OutOfGas, ReturnMessage: "out of gas"}
+	other := &VMOutput{ReturnCode: UserError, ReturnMessage: "user error"}
+
+	err := vmOutput.Merge(other, &transferIndexProviderStub{crtIndex: 1})
+	require.Nil(t, err)
+	assert.Equal(t, OutOfGas, vmOutput.ReturnCode)
+	assert.Equal(t, "out of gas", vmOutput.ReturnMessage)
+}
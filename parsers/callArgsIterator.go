@@ -0,0 +1,142 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CallArgsLimits holds the limits checked while parsing a data field. The data and function lengths are checked first,
+// before the data is split. The arguments limits apply to the hex encoded arguments, with their decoded lengths. A
+// zero limit is not checked.
+type CallArgsLimits struct {
+	MaxDataLength           uint64
+	MaxFunctionLength       uint64
+	MaxNumArguments         uint64
+	MaxArgumentLength       uint64
+	MaxTotalArgumentsLength uint64
+}
+
+func (limits *CallArgsLimits) isSet() bool {
+	return limits.MaxDataLength > 0 || limits.MaxFunctionLength > 0 || limits.MaxNumArguments > 0 ||
+		limits.MaxArgumentLength > 0 || limits.MaxTotalArgumentsLength > 0
+}
+
+// checkData checks the length of the data and of its first token, reading at most MaxFunctionLength + 1 bytes
+func (limits *CallArgsLimits) checkData(data string) error {
+	if limits.MaxDataLength > 0 && uint64(len(data)) > limits.MaxDataLength {
+		return fmt.Errorf("%w, %d bytes, maximum %d", ErrDataTooLong, len(data), limits.MaxDataLength)
+	}
+	if limits.MaxFunctionLength == 0 || uint64(len(data)) <= limits.MaxFunctionLength {
+		return nil
+	}
+
+	functionEnd := strings.IndexByte(data[:limits.MaxFunctionLength+1], atSeparatorChar)
+	if functionEnd < 0 {
+		return fmt.Errorf("%w, maximum %d bytes", ErrFunctionTooLong, limits.MaxFunctionLength)
+	}
+
+	return nil
+}
+
+// callArgsIterator walks through the arguments of a data field without splitting it, decoding only the arguments
+// that are requested
+type callArgsIterator struct {
+	data        string
+	next        int
+	current     string
+	index       int
+	totalLength uint64
+	limits      CallArgsLimits
+	err         error
+}
+
+func newCallArgsIterator(data string, limits CallArgsLimits) *callArgsIterator {
+	next := strings.IndexByte(data, atSeparatorChar)
+	if next >= 0 {
+		next++
+	}
+
+	return &callArgsIterator{
+		data:   data,
+		next:   next,
+		index:  -1,
+		limits: limits,
+	}
+}
+
+// Next moves to the next argument. It returns false at the end of the data or when a limit is exceeded
+func (iterator *callArgsIterator) Next() bool {
+	if iterator.err != nil || iterator.next < 0 {
+		return false
+	}
+
+	rest := iterator.data[iterator.next:]
+	end := strings.IndexByte(rest, atSeparatorChar)
+	if end < 0 {
+		iterator.current = rest
+		iterator.next = -1
+	} else {
+		iterator.current = rest[:end]
+		iterator.next += end + 1
+	}
+	iterator.index++
+
+	iterator.err = iterator.checkLimits()
+
+	return iterator.err == nil
+}
+
+func (iterator *callArgsIterator) checkLimits() error {
+	numArguments := uint64(iterator.index) + 1
+	if iterator.limits.MaxNumArguments > 0 && numArguments > iterator.limits.MaxNumArguments {
+		return fmt.Errorf("%w, maximum %d", ErrTooManyArguments, iterator.limits.MaxNumArguments)
+	}
+
+	length := uint64(len(iterator.current)+1) / 2
+	if iterator.limits.MaxArgumentLength > 0 && length > iterator.limits.MaxArgumentLength {
+		return fmt.Errorf("%w, argument %d has %d bytes, maximum %d", ErrArgumentTooLong, iterator.index, length, iterator.limits.MaxArgumentLength)
+	}
+
+	iterator.totalLength += length
+	if iterator.limits.MaxTotalArgumentsLength > 0 && iterator.totalLength > iterator.limits.MaxTotalArgumentsLength {
+		return fmt.Errorf("%w, maximum %d bytes", ErrArgumentsTooLong, iterator.limits.MaxTotalArgumentsLength)
+	}
+
+	return nil
+}
+
+// Index returns the index of the current argument
+func (iterator *callArgsIterator) Index() int {
+	return iterator.index
+}
+
+// RawArgument returns the hex encoded current argument, without copying it
+func (iterator *callArgsIterator) RawArgument() string {
+	return iterator.current
+}
+
+// Argument decodes the current argument
+func (iterator *callArgsIterator) Argument() ([]byte, error) {
+	return decodeToken(iterator.current)
+}
+
+// Err returns the limit exceeded while moving through the arguments, if any
+func (iterator *callArgsIterator) Err() error {
+	return iterator.err
+}
+
+func isHexToken(token string) bool {
+	if len(token)%2 != 0 {
+		return false
+	}
+
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		isHexChar := (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+		if !isHexChar {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package parsers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallArgsParser_IterateData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid data should error", func(t *testing.T) {
+		t.Parallel()
+
+		parser := NewCallArgsParser()
+		_, iterator, err := parser.IterateData("")
+		assert.Equal(t, ErrTokenizeFailed, err)
+		assert.Nil(t, iterator)
+
+		_, _, err = parser.IterateData("@0a")
+		assert.Equal(t, ErrTokenizeFailed, err)
+	})
+	t.Run("no arguments", func(t *testing.T) {
+		t.Parallel()
+
+		function, iterator, err := NewCallArgsParser().IterateData("fooBar")
+		require.Nil(t, err)
+		assert.Equal(t, "fooBar", function)
+		assert.False(t, iterator.Next())
+		assert.Nil(t, iterator.Err())
+	})
+	t.Run("arguments are decoded on demand", func(t *testing.T) {
+		t.Parallel()
+
+		function, iterator, err := NewCallArgsParser().IterateData("fooBar@0a0a@@zz")
+		require.Nil(t, err)
+		assert.Equal(t, "fooBar", function)
+
+		require.True(t, iterator.Next())
+		assert.Equal(t, 0, iterator.Index())
+		assert.Equal(t, "0a0a", iterator.RawArgument())
+		argument, err := iterator.Argument()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{10, 10}, argument)
+
+		require.True(t, iterator.Next())
+		argument, err = iterator.Argument()
+		assert.Nil(t, err)
+		assert.Empty(t, argument)
+
+		require.True(t, iterator.Next())
+		assert.Equal(t, 2, iterator.Index())
+		_, err = iterator.Argument()
+		assert.Equal(t, ErrTokenizeFailed, err)
+
+		assert.False(t, iterator.Next())
+		assert.Nil(t, iterator.Err())
+	})
+	t.Run("limits are checked while moving", func(t *testing.T) {
+		t.Parallel()
+
+		parser := NewCallArgsParserWithLimits(CallArgsLimits{MaxNumArguments: 2})
+		_, iterator, _ := parser.IterateData("fooBar@01@02@03@04")
+		assert.True(t, iterator.Next())
+		assert.True(t, iterator.Next())
+		assert.False(t, iterator.Next())
+		assert.True(t, errors.Is(iterator.Err(), ErrTooManyArguments))
+		assert.False(t, iterator.Next())
+	})
+}
+
+func TestCallArgsParser_Limits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		limits      CallArgsLimits
+		data        string
+		expectedErr error
+	}{
+		{name: "within limits", limits: CallArgsLimits{MaxNumArguments: 2, MaxArgumentLength: 2, MaxTotalArgumentsLength: 3}, data: "f@0102@03"},
+		{name: "too many arguments", limits: CallArgsLimits{MaxNumArguments: 2}, data: "f@01@02@03", expectedErr: ErrTooManyArguments},
+		{name: "argument too long", limits: CallArgsLimits{MaxArgumentLength: 2}, data: "f@01@010203", expectedErr: ErrArgumentTooLong},
+		{name: "odd argument too long", limits: CallArgsLimits{MaxArgumentLength: 2}, data: "f@01020", expectedErr: ErrArgumentTooLong},
+		{name: "arguments too long", limits: CallArgsLimits{MaxTotalArgumentsLength: 3}, data: "f@0102@0304", expectedErr: ErrArgumentsTooLong},
+		{name: "function is not limited", limits: CallArgsLimits{MaxArgumentLength: 1}, data: "longFunctionName@01"},
+		{name: "data within limit", limits: CallArgsLimits{MaxDataLength: 7}, data: "f@01@02"},
+		{name: "data too long", limits: CallArgsLimits{MaxDataLength: 6}, data: "f@01@02", expectedErr: ErrDataTooLong},
+		{name: "function within limit", limits: CallArgsLimits{MaxFunctionLength: 4}, data: "func@0102"},
+		{name: "function without arguments within limit", limits: CallArgsLimits{MaxFunctionLength: 4}, data: "func"},
+		{name: "function too long", limits: CallArgsLimits{MaxFunctionLength: 3}, data: "func@01", expectedErr: ErrFunctionTooLong},
+		{name: "function without arguments too long", limits: CallArgsLimits{MaxFunctionLength: 3}, data: "func", expectedErr: ErrFunctionTooLong},
+		{name: "data checked before the arguments", limits: CallArgsLimits{MaxDataLength: 4, MaxNumArguments: 1}, data: "f@01@02", expectedErr: ErrDataTooLong},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			parser := NewCallArgsParserWithLimits(testCase.limits)
+
+			_, _, err := parser.ParseData(testCase.data)
+			assert.True(t, errors.Is(err, testCase.expectedErr), err)
+			_, err = parser.ParseArguments(testCase.data)
+			assert.True(t, errors.Is(err, testCase.expectedErr), err)
+			err = parser.ValidateData(testCase.data)
+			assert.True(t, errors.Is(err, testCase.expectedErr), err)
+		})
+	}
+}
+
+func TestCallArgsParser_ValidateData(t *testing.T) {
+	t.Parallel()
+
+	parser := NewCallArgsParser()
+	assert.Nil(t, parser.ValidateData("fooBar"))
+	assert.Nil(t, parser.ValidateData("fooBar@0A0b@"))
+	assert.Equal(t, ErrTokenizeFailed, parser.ValidateData(""))
+	assert.Equal(t, ErrTokenizeFailed, parser.ValidateData("fooBar@0"))
+	assert.Equal(t, ErrTokenizeFailed, parser.ValidateData("fooBar@0g"))
+
+	limitedParser := NewCallArgsParserWithLimits(CallArgsLimits{MaxNumArguments: 10})
+	assert.True(t, errors.Is(limitedParser.ValidateData("fooBar"+strings.Repeat("@0a0b", 10000)), ErrTooManyArguments))
+
+	limitedParser = NewCallArgsParserWithLimits(CallArgsLimits{MaxFunctionLength: 32})
+	assert.True(t, errors.Is(limitedParser.ValidateData(strings.Repeat("f", 1<<20)), ErrFunctionTooLong))
+	_, _, err := limitedParser.IterateData(strings.Repeat("f", 1<<20) + "@0a")
+	assert.True(t, errors.Is(err, ErrFunctionTooLong))
+}
+
+// AllocsPerRun can not be called from a parallel test
+func TestCallArgsParser_ValidateDataDoesNotAllocatePerArgument(t *testing.T) {
+	parser := NewCallArgsParser()
+	small := "fooBar" + strings.Repeat("@0a0b", 2)
+	large := "fooBar" + strings.Repeat("@0a0b", 10000)
+
+	allocationsSmall := testing.AllocsPerRun(10, func() { _ = parser.ValidateData(small) })
+	allocationsLarge := testing.AllocsPerRun(10, func() { _ = parser.ValidateData(large) })
+	assert.Equal(t, allocationsSmall, allocationsLarge)
+}
@@ -1,8 +1,13 @@
 package parsers
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-common-go/codec"
+)
 
 type callArgsParser struct {
+	limits CallArgsLimits
 }
 
 // NewCallArgsParser creates a new parser
@@ -10,12 +15,25 @@ func NewCallArgsParser() *callArgsParser {
 	return &callArgsParser{}
 }
 
+// NewCallArgsParserWithLimits creates a new parser that rejects the data exceeding the limits before splitting it.
+// The data and function limits are checked first, the arguments limits apply to the tokens after the first one.
+func NewCallArgsParserWithLimits(limits CallArgsLimits) *callArgsParser {
+	return &callArgsParser{
+		limits: limits,
+	}
+}
+
 // ParseData parses strings of the following format:
 // functionRaw@argFooHex@argBarHex...
 func (parser *callArgsParser) ParseData(data string) (string, [][]byte, error) {
 	var function string
 	var arguments [][]byte
 
+	err := parser.checkLimits(data)
+	if err != nil {
+		return "", nil, err
+	}
+
 	tokens, err := tokenize(data)
 	if err != nil {
 		return "", nil, err
@@ -34,9 +52,31 @@ func (parser *callArgsParser) ParseData(data string) (string, [][]byte, error) {
 	return function, arguments, nil
 }
 
+// ParseTypedData parses strings of the following format:
+// functionRaw@argFooHex@argBarHex...
+// and decodes each argument, with the top level encoding, into the value at the same position
+func (parser *callArgsParser) ParseTypedData(data string, values ...codec.Value) (string, error) {
+	function, arguments, err := parser.ParseData(data)
+	if err != nil {
+		return "", err
+	}
+
+	err = codec.DecodeArguments(arguments, values...)
+	if err != nil {
+		return "", err
+	}
+
+	return function, nil
+}
+
 // ParseArguments parses strings of the following format:
 // argFoo@hex(argBarHex)...
 func (parser *callArgsParser) ParseArguments(data string) ([][]byte, error) {
+	err := parser.checkLimits(data)
+	if err != nil {
+		return nil, err
+	}
+
 	tokens := strings.Split(data, atSeparator)
 	arguments := make([][]byte, 0, len(tokens))
 	arguments = append(arguments, []byte(tokens[0]))
@@ -49,6 +89,60 @@ func (parser *callArgsParser) ParseArguments(data string) ([][]byte, error) {
 	return arguments, nil
 }
 
+// IterateData returns the function of strings of the following format:
+// functionRaw@argFooHex@argBarHex...
+// and an iterator over the arguments, that decodes them on demand and checks the limits as it moves
+func (parser *callArgsParser) IterateData(data string) (string, *callArgsIterator, error) {
+	err := parser.limits.checkData(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	function, _, _ := strings.Cut(data, atSeparator)
+	if len(function) == 0 {
+		return "", nil, ErrTokenizeFailed
+	}
+
+	return function, newCallArgsIterator(data, parser.limits), nil
+}
+
+// ValidateData checks that strings of the following format:
+// functionRaw@argFooHex@argBarHex...
+// can be parsed within the limits, without allocating the arguments
+func (parser *callArgsParser) ValidateData(data string) error {
+	_, iterator, err := parser.IterateData(data)
+	if err != nil {
+		return err
+	}
+
+	for iterator.Next() {
+		if !isHexToken(iterator.RawArgument()) {
+			return ErrTokenizeFailed
+		}
+	}
+
+	return iterator.Err()
+}
+
+// checkLimits checks the data and walks through the hex encoded arguments, the ones after the first token, when
+// limits are set
+func (parser *callArgsParser) checkLimits(data string) error {
+	if !parser.limits.isSet() {
+		return nil
+	}
+
+	err := parser.limits.checkData(data)
+	if err != nil {
+		return err
+	}
+
+	iterator := newCallArgsIterator(data, parser.limits)
+	for iterator.Next() {
+	}
+
+	return iterator.Err()
+}
+
 func (parser *callArgsParser) parseFunction(tokens []string) (string, error) {
 	if len(tokens) < minNumCallArguments {
 		return "", ErrNilFunction
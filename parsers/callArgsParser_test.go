@@ -1,8 +1,11 @@
 package parsers
 
 import (
+	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-vm-common-go/codec"
 	"github.com/stretchr/testify/require"
 )
 
@@ -74,3 +77,27 @@ func TestCallArgsParser_ParseArgsWhenErrorneousInput(t *testing.T) {
 	require.Equal(t, ErrTokenizeFailed, err)
 	require.Nil(t, arguments)
 }
+
+func TestCallArgsParser_ParseTypedData(t *testing.T) {
+	t.Parallel()
+
+	parser := NewCallArgsParser()
+
+	token, value, flag := &codec.StringValue{}, &codec.BigUintValue{}, &codec.OptionValue{Value: &codec.BoolValue{}}
+	function, err := parser.ParseTypedData("fooBar@544b4e@0a@0101", token, value, flag)
+	require.Nil(t, err)
+	require.Equal(t, "fooBar", function)
+	require.Equal(t, "TKN", token.Value)
+	require.Equal(t, big.NewInt(10), value.Value)
+	require.True(t, flag.IsSet)
+	require.True(t, flag.Value.(*codec.BoolValue).Value)
+
+	_, err = parser.ParseTypedData("fooBar@0a", token, value)
+	require.True(t, errors.Is(err, codec.ErrInvalidNumberOfArguments))
+
+	_, err = parser.ParseTypedData("fooBar@02", &codec.BoolValue{})
+	require.True(t, errors.Is(err, codec.ErrInvalidBool))
+
+	_, err = parser.ParseTypedData("", token)
+	require.Equal(t, ErrTokenizeFailed, err)
+}
@@ -12,6 +12,20 @@ type ResponseParseData struct {
 	Receivers        [][]byte
 	ReceiversShardID []uint32
 	IsRelayed        bool
+	// Guardian and GuardianServiceUID are set by SetGuardian
+	Guardian           []byte
+	GuardianServiceUID []byte
+	// NewOwner is set by ChangeOwnerAddress
+	NewOwner []byte
+	// UserName is set by SetUserName
+	UserName []byte
+	// StorageKeys holds the keys written by SaveKeyValue
+	StorageKeys [][]byte
+	// TokenType is set by ESDTSetTokenType, the token being stored in Tokens
+	TokenType string
+	// TransferRoleAddresses holds the addresses added or deleted by ESDTTransferRoleAddAddress and
+	// ESDTTransferRoleDeleteAddress, the token being stored in Tokens
+	TransferRoleAddresses [][]byte
 }
 
 // NewResponseParseDataAsRelayed returns an empty ResponseParseData with IsRelayed field set to true
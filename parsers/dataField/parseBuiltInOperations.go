@@ -0,0 +1,129 @@
+package datafield
+
+import (
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+// getSchemaArguments returns the schema of the function, when all the arguments match it
+func (odp *operationDataFieldParser) getSchemaArguments(args [][]byte, funcName string) (*builtInFunctions.FunctionSchema, bool) {
+	schema, ok := builtInFunctions.GetFunctionSchema(funcName)
+	if !ok {
+		return nil, false
+	}
+
+	err := builtInFunctions.ValidateArguments(schema.Arguments, args, odp.addressLength)
+	return schema, err == nil
+}
+
+func getArgument(schema *builtInFunctions.FunctionSchema, args [][]byte, name string) []byte {
+	index, _ := schema.ArgumentIndex(name)
+	return args[index]
+}
+
+func (odp *operationDataFieldParser) parseSetGuardian(args [][]byte, funcName string) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation: funcName,
+	}
+
+	schema, ok := odp.getSchemaArguments(args, funcName)
+	if !ok {
+		return responseData
+	}
+
+	responseData.Guardian = getArgument(schema, args, "guardian")
+	responseData.GuardianServiceUID = getArgument(schema, args, "guardianServiceUID")
+
+	return responseData
+}
+
+func (odp *operationDataFieldParser) parseChangeOwnerAddress(args [][]byte, funcName string) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation: funcName,
+	}
+
+	schema, ok := odp.getSchemaArguments(args, funcName)
+	if !ok {
+		return responseData
+	}
+
+	responseData.NewOwner = getArgument(schema, args, "newOwner")
+
+	return responseData
+}
+
+func (odp *operationDataFieldParser) parseSetUserName(args [][]byte, funcName string) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation: funcName,
+	}
+
+	schema, ok := odp.getSchemaArguments(args, funcName)
+	if !ok {
+		return responseData
+	}
+
+	responseData.UserName = getArgument(schema, args, "userName")
+
+	return responseData
+}
+
+func (odp *operationDataFieldParser) parseSaveKeyValue(args [][]byte, funcName string) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation: funcName,
+	}
+
+	_, ok := odp.getSchemaArguments(args, funcName)
+	if !ok {
+		return responseData
+	}
+
+	// the arguments are key value pairs
+	for i := 0; i < len(args); i += 2 {
+		responseData.StorageKeys = append(responseData.StorageKeys, args[i])
+	}
+
+	return responseData
+}
+
+func (odp *operationDataFieldParser) parseSetTokenType(args [][]byte, funcName string) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation: funcName,
+	}
+
+	schema, ok := odp.getSchemaArguments(args, funcName)
+	if !ok {
+		return responseData
+	}
+
+	token := string(getArgument(schema, args, "tokenIdentifier"))
+	tokenType := string(getArgument(schema, args, "tokenType"))
+	if !isASCIIString(token) || !isASCIIString(tokenType) {
+		return responseData
+	}
+
+	responseData.Tokens = append(responseData.Tokens, token)
+	responseData.TokenType = tokenType
+
+	return responseData
+}
+
+func (odp *operationDataFieldParser) parseTransferRoleAddresses(args [][]byte, funcName string) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation: funcName,
+	}
+
+	schema, ok := odp.getSchemaArguments(args, funcName)
+	if !ok {
+		return responseData
+	}
+
+	token := string(getArgument(schema, args, "tokenIdentifier"))
+	if !isASCIIString(token) {
+		return responseData
+	}
+
+	addressesIndex, _ := schema.ArgumentIndex("addresses")
+	responseData.Tokens = append(responseData.Tokens, token)
+	responseData.TransferRoleAddresses = args[addressesIndex:]
+
+	return responseData
+}
@@ -0,0 +1,172 @@
+package datafield
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBuiltInOperations(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgumentsOperationParser()
+	parser, _ := NewOperationDataFieldParser(args)
+
+	t.Run("SetGuardian", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("SetGuardian@" + hex.EncodeToString(receiver) + "@" + hex.EncodeToString([]byte("serviceID")))
+		res := parser.Parse(dataField, sender, sender, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation:          core.BuiltInFunctionSetGuardian,
+			Guardian:           receiver,
+			GuardianServiceUID: []byte("serviceID"),
+		}, res)
+	})
+
+	t.Run("SetGuardianInvalidGuardian", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("SetGuardian@0102@" + hex.EncodeToString([]byte("serviceID")))
+		res := parser.Parse(dataField, sender, sender, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionSetGuardian,
+		}, res)
+	})
+
+	t.Run("ChangeOwnerAddress", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("ChangeOwnerAddress@" + hex.EncodeToString(receiver))
+		res := parser.Parse(dataField, sender, receiverSC, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionChangeOwnerAddress,
+			Function:  core.BuiltInFunctionChangeOwnerAddress,
+			NewOwner:  receiver,
+		}, res)
+	})
+
+	t.Run("ChangeOwnerAddressInvalidOwner", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("ChangeOwnerAddress@0102")
+		res := parser.Parse(dataField, sender, receiverSC, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionChangeOwnerAddress,
+			Function:  core.BuiltInFunctionChangeOwnerAddress,
+		}, res)
+	})
+
+	t.Run("SetUserName", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("SetUserName@" + hex.EncodeToString([]byte("alice.elrond")))
+		res := parser.Parse(dataField, receiverSC, sender, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionSetUserName,
+			UserName:  []byte("alice.elrond"),
+		}, res)
+	})
+
+	t.Run("DeleteUserName", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DeleteUserName")
+		res := parser.Parse(dataField, receiverSC, sender, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: "DeleteUserName",
+		}, res)
+	})
+
+	t.Run("SaveKeyValue", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("SaveKeyValue@6b6579@76616c7565@6b657932@")
+		res := parser.Parse(dataField, sender, sender, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation:   core.BuiltInFunctionSaveKeyValue,
+			StorageKeys: [][]byte{[]byte("key"), []byte("key2")},
+		}, res)
+	})
+
+	t.Run("SaveKeyValueOddNumberOfArguments", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("SaveKeyValue@6b6579@76616c7565@6b657932")
+		res := parser.Parse(dataField, sender, sender, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionSaveKeyValue,
+		}, res)
+	})
+
+	t.Run("ESDTSetTokenType", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("ESDTSetTokenType@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@" + hex.EncodeToString([]byte(core.NonFungibleESDTv2)))
+		res := parser.Parse(dataField, sender, receiver, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.ESDTSetTokenType,
+			Tokens:    []string{"TKN-abcdef"},
+			TokenType: core.NonFungibleESDTv2,
+		}, res)
+	})
+
+	t.Run("ESDTSetTokenTypeTooManyArguments", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("ESDTSetTokenType@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@" + hex.EncodeToString([]byte(core.NonFungibleESDTv2)) + "@01")
+		res := parser.Parse(dataField, sender, receiver, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.ESDTSetTokenType,
+		}, res)
+	})
+
+	t.Run("ESDTTransferRoleAddAddress", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("ESDTTransferRoleAddAddress@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@" +
+			hex.EncodeToString(sender) + "@" + hex.EncodeToString(receiver))
+		res := parser.Parse(dataField, sender, receiver, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation:             vmcommon.BuiltInFunctionESDTTransferRoleAddAddress,
+			Tokens:                []string{"TKN-abcdef"},
+			TransferRoleAddresses: [][]byte{sender, receiver},
+		}, res)
+	})
+
+	t.Run("ESDTTransferRoleDeleteAddressInvalidAddress", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("ESDTTransferRoleDeleteAddress@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@" +
+			hex.EncodeToString(sender) + "@0102")
+		res := parser.Parse(dataField, sender, receiver, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			Operation: vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress,
+		}, res)
+	})
+
+	t.Run("RelayedSaveKeyValue", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte(core.RelayedTransactionV2 +
+			"@" +
+			hex.EncodeToString(receiver) +
+			"@" +
+			"0A" +
+			"@" +
+			hex.EncodeToString([]byte("SaveKeyValue@6b6579@76616c7565")) +
+			"@" +
+			"01a2")
+		res := parser.Parse(dataField, sender, receiver, 3, 0)
+		require.Equal(t, &ResponseParseData{
+			IsRelayed:        true,
+			Operation:        core.BuiltInFunctionSaveKeyValue,
+			Receivers:        [][]byte{receiver},
+			ReceiversShardID: []uint32{0},
+			StorageKeys:      [][]byte{[]byte("key")},
+		}, res)
+	})
+}
@@ -28,6 +28,8 @@ const (
 	argsNoncePosition                   = 1
 	argsValuePositionNonAndSemiFungible = 2
 	argsValuePositionFungible           = 1
+
+	builtInFunctionDeleteUserName = "DeleteUserName"
 )
 
 var errInvalidAddressLength = errors.New("invalid address length")
@@ -107,6 +109,18 @@ func (odp *operationDataFieldParser) parse(
 		return parseQuantityOperationNFT(args, function)
 	case core.ESDTMetaDataRecreate, core.ESDTMetaDataUpdate, core.ESDTSetNewURIs, core.ESDTModifyCreator, core.ESDTModifyRoyalties, core.BuiltInFunctionESDTNFTAddURI, core.BuiltInFunctionESDTNFTUpdateAttributes:
 		return parseModifyOperationNFT(args, function)
+	case core.BuiltInFunctionSetGuardian:
+		responseParse = odp.parseSetGuardian(args, function)
+	case core.BuiltInFunctionChangeOwnerAddress:
+		responseParse = odp.parseChangeOwnerAddress(args, function)
+	case core.BuiltInFunctionSetUserName:
+		responseParse = odp.parseSetUserName(args, function)
+	case core.BuiltInFunctionSaveKeyValue:
+		responseParse = odp.parseSaveKeyValue(args, function)
+	case core.ESDTSetTokenType:
+		responseParse = odp.parseSetTokenType(args, function)
+	case vmcommon.BuiltInFunctionESDTTransferRoleAddAddress, vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress:
+		responseParse = odp.parseTransferRoleAddresses(args, function)
 	case core.RelayedTransaction, core.RelayedTransactionV2:
 		if ignoreRelayed {
 			return NewResponseParseDataAsRelayed()
@@ -171,6 +185,14 @@ func (odp *operationDataFieldParser) parseRelayed(
 		Receivers:        receivers,
 		ReceiversShardID: receiversShardID,
 		IsRelayed:        true,
+
+		Guardian:              res.Guardian,
+		GuardianServiceUID:    res.GuardianServiceUID,
+		NewOwner:              res.NewOwner,
+		UserName:              res.UserName,
+		StorageKeys:           res.StorageKeys,
+		TokenType:             res.TokenType,
+		TransferRoleAddresses: res.TransferRoleAddresses,
 	}
 }
 
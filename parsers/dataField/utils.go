@@ -2,17 +2,11 @@ package datafield
 
 import (
 	"bytes"
-	"encoding/hex"
-	"fmt"
-	"math/big"
 	"unicode"
 
 	"github.com/multiversx/mx-chain-core-go/core"
-)
-
-const (
-	esdtIdentifierSeparator  = "-"
-	esdtRandomSequenceLength = 6
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/tokenIdentifier"
 )
 
 // TODO refactor this part to use the built-in container for the list of all the built-in functions
@@ -62,6 +56,9 @@ func getAllBuiltInFunctions() []string {
 		core.ESDTModifyCreator,
 		core.ESDTModifyRoyalties,
 		core.ESDTSetTokenType,
+		builtInFunctionDeleteUserName,
+		vmcommon.BuiltInFunctionESDTTransferRoleAddAddress,
+		vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress,
 	}
 }
 
@@ -80,25 +77,12 @@ func computeTokenIdentifier(token string, nonce uint64) string {
 		return ""
 	}
 
-	nonceBig := big.NewInt(0).SetUint64(nonce)
-	hexEncodedNonce := hex.EncodeToString(nonceBig.Bytes())
-	return fmt.Sprintf("%s-%s", token, hexEncodedNonce)
+	return tokenIdentifier.Format([]byte(token), nonce)
 }
 
 func extractTokenAndNonce(arg []byte) (string, uint64) {
-	argsSplit := bytes.Split(arg, []byte(esdtIdentifierSeparator))
-	if len(argsSplit) < 2 {
-		return string(arg), 0
-	}
-
-	if len(argsSplit[1]) <= esdtRandomSequenceLength {
-		return string(arg), 0
-	}
-
-	identifier := []byte(fmt.Sprintf("%s-%s", argsSplit[0], argsSplit[1][:esdtRandomSequenceLength]))
-	nonce := big.NewInt(0).SetBytes(argsSplit[1][esdtRandomSequenceLength:])
-
-	return string(identifier), nonce.Uint64()
+	token, nonce := tokenIdentifier.SplitTokenIDAndNonce(arg)
+	return string(token), nonce
 }
 
 func isEmptyAddr(addrLength int, address []byte) bool {
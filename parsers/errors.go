@@ -36,3 +36,18 @@ var ErrNilMarshalizer = errors.New("nil marshaller")
 
 // ErrTooManyTransfers signals that too many transfers are in the data
 var ErrTooManyTransfers = errors.New("too many transfers")
+
+// ErrTooManyArguments signals that the data holds more arguments than allowed
+var ErrTooManyArguments = errors.New("too many arguments")
+
+// ErrArgumentTooLong signals that an argument is longer than allowed
+var ErrArgumentTooLong = errors.New("argument too long")
+
+// ErrArgumentsTooLong signals that the total length of the arguments is more than allowed
+var ErrArgumentsTooLong = errors.New("arguments too long")
+
+// ErrDataTooLong signals that the data is longer than allowed
+var ErrDataTooLong = errors.New("data too long")
+
+// ErrFunctionTooLong signals that the function name is longer than allowed
+var ErrFunctionTooLong = errors.New("function too long")
@@ -2,6 +2,9 @@ package parsers
 
 import (
 	"encoding/hex"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/multiversx/mx-chain-vm-common-go"
 )
@@ -46,20 +49,92 @@ func (parser *storageUpdatesParser) GetStorageUpdates(data string) ([]*vmcommon.
 	return storageUpdates, nil
 }
 
+// GetWrittenStorageUpdates parses data created by CreateDataFromStorageUpdate into storage updates marked as
+// written, as the data holds the updates to be persisted. Empty data holds no storage updates.
+func (parser *storageUpdatesParser) GetWrittenStorageUpdates(data string) ([]*vmcommon.StorageUpdate, error) {
+	if len(data) == 0 {
+		return make([]*vmcommon.StorageUpdate, 0), nil
+	}
+
+	storageUpdates, err := parser.GetStorageUpdates(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storageUpdate := range storageUpdates {
+		storageUpdate.Written = true
+	}
+
+	return storageUpdates, nil
+}
+
 // CreateDataFromStorageUpdate creates storage update from data
 func (parser *storageUpdatesParser) CreateDataFromStorageUpdate(storageUpdates []*vmcommon.StorageUpdate) string {
-	data := ""
-	for i := 0; i < len(storageUpdates); i++ {
-		storageUpdate := storageUpdates[i]
-		data = data + hex.EncodeToString(storageUpdate.Offset)
-		data = data + atSeparator
-		data = data + hex.EncodeToString(storageUpdate.Data)
-
-		if i < len(storageUpdates)-1 {
-			data = data + atSeparator
+	builder := &strings.Builder{}
+	builder.Grow(encodedStorageUpdatesLength(storageUpdates))
+	_ = parser.EncodeStorageUpdates(builder, storageUpdates)
+
+	return builder.String()
+}
+
+// CreateDataFromStorageUpdatesMap creates the data of the written storage updates of the map, such as the storage
+// updates of an output account, sorted by key
+func (parser *storageUpdatesParser) CreateDataFromStorageUpdatesMap(storageUpdates map[string]*vmcommon.StorageUpdate) string {
+	keys := make([]string, 0, len(storageUpdates))
+	for key, storageUpdate := range storageUpdates {
+		if storageUpdate != nil && storageUpdate.Written {
+			keys = append(keys, key)
 		}
 	}
-	return data
+	sort.Strings(keys)
+
+	sortedUpdates := make([]*vmcommon.StorageUpdate, 0, len(keys))
+	for _, key := range keys {
+		sortedUpdates = append(sortedUpdates, storageUpdates[key])
+	}
+
+	return parser.CreateDataFromStorageUpdate(sortedUpdates)
+}
+
+// EncodeStorageUpdates writes the storage updates to the writer, in the format of CreateDataFromStorageUpdate
+func (parser *storageUpdatesParser) EncodeStorageUpdates(writer io.Writer, storageUpdates []*vmcommon.StorageUpdate) error {
+	encoder := hex.NewEncoder(writer)
+	for i, storageUpdate := range storageUpdates {
+		if i > 0 {
+			_, err := io.WriteString(writer, atSeparator)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := encoder.Write(storageUpdate.Offset)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(writer, atSeparator)
+		if err != nil {
+			return err
+		}
+		_, err = encoder.Write(storageUpdate.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodedStorageUpdatesLength(storageUpdates []*vmcommon.StorageUpdate) int {
+	if len(storageUpdates) == 0 {
+		return 0
+	}
+
+	length := 2*len(storageUpdates) - 1
+	for _, storageUpdate := range storageUpdates {
+		length += hex.EncodedLen(len(storageUpdate.Offset)) + hex.EncodedLen(len(storageUpdate.Data))
+	}
+
+	return length
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
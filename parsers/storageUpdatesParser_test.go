@@ -1,7 +1,9 @@
 package parsers
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/multiversx/mx-chain-vm-common-go"
@@ -105,3 +107,74 @@ func TestStorageUpdatesParser_GetStorageUpdates(t *testing.T) {
 		require.Equal(t, test, hex.EncodeToString(stUpdates[i].Offset))
 	}
 }
+
+func TestStorageUpdatesParser_CreateDataFromStorageUpdatesMap(t *testing.T) {
+	t.Parallel()
+
+	parser := NewStorageUpdatesParser()
+	require.Equal(t, "", parser.CreateDataFromStorageUpdatesMap(nil))
+
+	storageUpdates := map[string]*vmcommon.StorageUpdate{
+		"c":    {Offset: []byte("c"), Data: []byte{3}, Written: true},
+		"a":    {Offset: []byte("a"), Data: []byte{1}, Written: true},
+		"read": {Offset: []byte("read"), Data: []byte{9}},
+		"nil":  nil,
+		"b":    {Offset: []byte("b"), Data: nil, Written: true},
+	}
+	for i := 0; i < 10; i++ {
+		require.Equal(t, "61@01@62@@63@03", parser.CreateDataFromStorageUpdatesMap(storageUpdates))
+	}
+}
+
+func TestStorageUpdatesParser_GetWrittenStorageUpdates(t *testing.T) {
+	t.Parallel()
+
+	parser := NewStorageUpdatesParser()
+
+	decoded, err := parser.GetWrittenStorageUpdates("")
+	require.Nil(t, err)
+	require.Empty(t, decoded)
+
+	storageUpdates := []*vmcommon.StorageUpdate{
+		{Offset: []byte("key1"), Data: []byte("value1"), Written: true},
+		{Offset: []byte("key2"), Data: []byte{}, Written: true},
+	}
+	decoded, err = parser.GetWrittenStorageUpdates(parser.CreateDataFromStorageUpdate(storageUpdates))
+	require.Nil(t, err)
+	require.Equal(t, storageUpdates, decoded)
+
+	_, err = parser.GetWrittenStorageUpdates("aa@bb@cc")
+	require.Equal(t, ErrInvalidDataString, err)
+}
+
+func TestStorageUpdatesParser_EncodeStorageUpdates(t *testing.T) {
+	t.Parallel()
+
+	parser := NewStorageUpdatesParser()
+	storageUpdates := make([]*vmcommon.StorageUpdate, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		storageUpdates = append(storageUpdates, &vmcommon.StorageUpdate{Offset: []byte{byte(i)}, Data: []byte{byte(i), 1}})
+	}
+
+	buffer := &bytes.Buffer{}
+	require.Nil(t, parser.EncodeStorageUpdates(buffer, storageUpdates))
+	data := parser.CreateDataFromStorageUpdate(storageUpdates)
+	require.Equal(t, data, buffer.String())
+	require.Equal(t, encodedStorageUpdatesLength(storageUpdates), len(data))
+
+	decoded, err := parser.GetStorageUpdates(data)
+	require.Nil(t, err)
+	require.Equal(t, storageUpdates, decoded)
+
+	expectedErr := errors.New("expected error")
+	err = parser.EncodeStorageUpdates(&failingWriter{err: expectedErr}, storageUpdates)
+	require.Equal(t, expectedErr, err)
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (writer *failingWriter) Write(_ []byte) (int, error) {
+	return 0, writer.err
+}
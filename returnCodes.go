@@ -1,6 +1,12 @@
 package vmcommon
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const unknownReturnCodePrefix = "unknown error, code: "
 
 // ReturnCode is an enum with the possible error codes returned by the VM
 type ReturnCode int
@@ -29,9 +35,32 @@ func (rc ReturnCode) String() string {
 		return "contract invalid"
 	case ExecutionFailed:
 		return "execution failed"
+	case UpgradeFailed:
+		return "upgrade failed"
+	case SimulateFailed:
+		return "simulate failed"
 	default:
-		return fmt.Sprintf("unknown error, code: %d", rc)
+		return unknownReturnCodePrefix + strconv.Itoa(int(rc))
+	}
+}
+
+// ReturnCodeFromString returns the ReturnCode having the provided name, as produced by ReturnCode.String.
+// Unknown return codes are parsed back from their "unknown error, code: N" form.
+func ReturnCodeFromString(name string) (ReturnCode, error) {
+	for rc := Ok; rc <= SimulateFailed; rc++ {
+		if rc.String() == name {
+			return rc, nil
+		}
+	}
+
+	if strings.HasPrefix(name, unknownReturnCodePrefix) {
+		code, err := strconv.Atoi(strings.TrimPrefix(name, unknownReturnCodePrefix))
+		if err == nil && ReturnCode(code).String() == name {
+			return ReturnCode(code), nil
+		}
 	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnknownReturnCode, name)
 }
 
 const (
@@ -0,0 +1,81 @@
+package scenarios
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	// UserAddressPrefix is the prefix of the readable user addresses: address:alice
+	UserAddressPrefix = "address:"
+	// SmartContractAddressPrefix is the prefix of the readable smart contract addresses: sc:adder
+	SmartContractAddressPrefix = "sc:"
+	// AddressLength is the length of the addresses built from readable names
+	AddressLength = 32
+)
+
+const addressPadding = byte('_')
+
+var wasmVMType = []byte{5, 0}
+
+type addressEncoder struct {
+}
+
+// NewAddressEncoder creates an address encoder that accepts readable addresses besides the hex ones.
+// address:name is the name right padded with underscores up to 32 bytes. sc:name is a smart contract address:
+// 8 zero bytes, the WASM VM type and the name right padded with underscores up to 32 bytes.
+func NewAddressEncoder() *addressEncoder {
+	return &addressEncoder{}
+}
+
+// Encode returns the readable form of the address, if it has one, or its hex representation otherwise
+func (encoder *addressEncoder) Encode(address []byte) (string, error) {
+	candidates := []string{
+		SmartContractAddressPrefix + string(bytes.TrimRight(address[min(len(address), vmcommon.NumInitCharactersForScAddress):], string(addressPadding))),
+		UserAddressPrefix + string(bytes.TrimRight(address, string(addressPadding))),
+	}
+	for _, candidate := range candidates {
+		decoded, err := encoder.Decode(candidate)
+		if err == nil && bytes.Equal(decoded, address) {
+			return candidate, nil
+		}
+	}
+
+	return hex.EncodeToString(address), nil
+}
+
+// Decode returns the address from its readable or hex form
+func (encoder *addressEncoder) Decode(encoded string) ([]byte, error) {
+	if strings.HasPrefix(encoded, UserAddressPrefix) {
+		return padName(nil, strings.TrimPrefix(encoded, UserAddressPrefix))
+	}
+	if strings.HasPrefix(encoded, SmartContractAddressPrefix) {
+		prefix := make([]byte, vmcommon.NumInitCharactersForScAddress-vmcommon.VMTypeLen, vmcommon.NumInitCharactersForScAddress)
+		return padName(append(prefix, wasmVMType...), strings.TrimPrefix(encoded, SmartContractAddressPrefix))
+	}
+
+	address, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidAddress, encoded, err.Error())
+	}
+
+	return address, nil
+}
+
+func padName(prefix []byte, name string) ([]byte, error) {
+	if len(name) == 0 || len(prefix)+len(name) > AddressLength {
+		return nil, fmt.Errorf("%w: name %q must have between 1 and %d characters", ErrInvalidAddress, name, AddressLength-len(prefix))
+	}
+
+	address := append(prefix, name...)
+	return append(address, bytes.Repeat([]byte{addressPadding}, AddressLength-len(address))...), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (encoder *addressEncoder) IsInterfaceNil() bool {
+	return encoder == nil
+}
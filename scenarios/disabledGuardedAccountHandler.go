@@ -0,0 +1,26 @@
+package scenarios
+
+import vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+
+// disabledGuardedAccountHandler is used because the scenarios do not support guardians
+type disabledGuardedAccountHandler struct {
+}
+
+// GetActiveGuardian returns ErrGuardiansNotSupported
+func (handler *disabledGuardedAccountHandler) GetActiveGuardian(_ vmcommon.UserAccountHandler) ([]byte, error) {
+	return nil, ErrGuardiansNotSupported
+}
+
+// SetGuardian returns ErrGuardiansNotSupported
+func (handler *disabledGuardedAccountHandler) SetGuardian(_ vmcommon.UserAccountHandler, _ []byte, _ []byte, _ []byte) error {
+	return ErrGuardiansNotSupported
+}
+
+// CleanOtherThanActive does nothing
+func (handler *disabledGuardedAccountHandler) CleanOtherThanActive(_ vmcommon.UserAccountHandler) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *disabledGuardedAccountHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
@@ -0,0 +1,63 @@
+package scenarios
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+// enableEpochsHandler enables, from epoch 0, the provided set of flags
+type enableEpochsHandler struct {
+	enabledFlags map[core.EnableEpochFlag]struct{}
+}
+
+// NewEnableEpochsHandler creates an enable epochs handler that has only the provided flags enabled, from epoch 0.
+// All the flags must be used by the built-in functions.
+func NewEnableEpochsHandler(enabledFlags []string) (*enableEpochsHandler, error) {
+	knownFlags := make(map[core.EnableEpochFlag]struct{})
+	for _, flag := range builtInFunctions.AllFlags() {
+		knownFlags[flag] = struct{}{}
+	}
+
+	handler := &enableEpochsHandler{
+		enabledFlags: make(map[core.EnableEpochFlag]struct{}),
+	}
+	for _, name := range enabledFlags {
+		flag := core.EnableEpochFlag(name)
+		_, found := knownFlags[flag]
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+		}
+
+		handler.enabledFlags[flag] = struct{}{}
+	}
+
+	return handler, nil
+}
+
+// IsFlagDefined returns true, as all the flags used by the built-in functions are defined
+func (handler *enableEpochsHandler) IsFlagDefined(_ core.EnableEpochFlag) bool {
+	return true
+}
+
+// IsFlagEnabled returns true if the flag is enabled
+func (handler *enableEpochsHandler) IsFlagEnabled(flag core.EnableEpochFlag) bool {
+	_, found := handler.enabledFlags[flag]
+	return found
+}
+
+// IsFlagEnabledInEpoch returns true if the flag is enabled, in any epoch
+func (handler *enableEpochsHandler) IsFlagEnabledInEpoch(flag core.EnableEpochFlag, _ uint32) bool {
+	return handler.IsFlagEnabled(flag)
+}
+
+// GetActivationEpoch returns 0
+func (handler *enableEpochsHandler) GetActivationEpoch(_ core.EnableEpochFlag) uint32 {
+	return 0
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *enableEpochsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
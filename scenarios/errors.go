@@ -0,0 +1,33 @@
+package scenarios
+
+import "errors"
+
+// ErrScenarioFailed signals that the scenario did not meet its expectations
+var ErrScenarioFailed = errors.New("scenario failed")
+
+// ErrNilScenario signals that a nil scenario was provided
+var ErrNilScenario = errors.New("nil scenario")
+
+// ErrNilGasMap signals that a nil gas map was provided
+var ErrNilGasMap = errors.New("nil gas map")
+
+// ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
+var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrUnknownFlag signals that the scenario enables a flag that is not used by the built-in functions
+var ErrUnknownFlag = errors.New("unknown flag")
+
+// ErrInvalidAddress signals that an address could not be decoded
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ErrInvalidValue signals that a scenario value could not be decoded
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrUnknownESDTType signals that the ESDT type name is not known
+var ErrUnknownESDTType = errors.New("unknown ESDT type")
+
+// ErrInvalidShardConfiguration signals that the self shard or the shard of an address is not lower than the number of shards
+var ErrInvalidShardConfiguration = errors.New("invalid shard configuration")
+
+// ErrGuardiansNotSupported signals that the scenarios can not set or use guardians
+var ErrGuardiansNotSupported = errors.New("guardians are not supported by the scenarios")
@@ -0,0 +1,10 @@
+package scenarios
+
+import vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+
+// jsonCodec decodes the inputs and outputs written in the scenarios
+type jsonCodec interface {
+	UnmarshalContractCallInput(data []byte) (*vmcommon.ContractCallInput, error)
+	UnmarshalVMOutput(data []byte) (*vmcommon.VMOutput, error)
+	IsInterfaceNil() bool
+}
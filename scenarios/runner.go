@@ -0,0 +1,730 @@
+package scenarios
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/inMemory"
+)
+
+const maxNumOfAddressesForTransferRole = 100
+
+var esdtKeyPrefix = core.ProtectedKeyPrefix + core.ESDTKeyIdentifier
+var esdtRoleKeyPrefix = core.ProtectedKeyPrefix + core.ESDTRoleIdentifier + core.ESDTKeyIdentifier
+
+// ArgsRunner is the DTO used to create a new scenario runner
+type ArgsRunner struct {
+	GasMap map[string]map[string]uint64
+	// EnableEpochsHandler is used by the scenarios that do not list their enabled flags
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// ScenarioError holds all the expectations that were not met by a scenario
+type ScenarioError struct {
+	Scenario string
+	Problems []string
+}
+
+// Error returns the scenario name followed by all the problems, one per line
+func (e *ScenarioError) Error() string {
+	return fmt.Sprintf("%s %q:\n%s", ErrScenarioFailed.Error(), e.Scenario, strings.Join(e.Problems, "\n"))
+}
+
+// Unwrap returns ErrScenarioFailed
+func (e *ScenarioError) Unwrap() error {
+	return ErrScenarioFailed
+}
+
+type runner struct {
+	gasMap              map[string]map[string]uint64
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewRunner creates a runner that executes every scenario against a new built-in function container, created by
+// builtInFunctions.NewBuiltInFunctionsCreator on top of a new in-memory state
+func NewRunner(args ArgsRunner) (*runner, error) {
+	if args.GasMap == nil {
+		return nil, ErrNilGasMap
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &runner{
+		gasMap:              args.GasMap,
+		enableEpochsHandler: args.EnableEpochsHandler,
+	}, nil
+}
+
+// RunFile loads and runs the scenario from the provided JSON file
+func (r *runner) RunFile(path string) error {
+	scenario, err := LoadScenarioFile(path)
+	if err != nil {
+		return err
+	}
+
+	return r.Run(scenario)
+}
+
+// Run sets the initial state, executes all the steps and checks the final state. A scenario that can not be set
+// up returns the setup error, while a scenario that does not meet its expectations returns a *ScenarioError that
+// lists all the problems found.
+func (r *runner) Run(scenario *Scenario) error {
+	if scenario == nil {
+		return ErrNilScenario
+	}
+
+	env, err := r.createEnvironment(scenario)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range scenario.Accounts {
+		err = env.setAccount(account)
+		if err != nil {
+			return fmt.Errorf("%w for account %s", err, account.Address)
+		}
+	}
+
+	err = env.setGlobalSettings(scenario.GlobalSettings)
+	if err != nil {
+		return err
+	}
+
+	problems := make([]string, 0)
+	for i, step := range scenario.Steps {
+		stepName := fmt.Sprintf("step %d", i)
+		if len(step.Name) > 0 {
+			stepName = fmt.Sprintf("step %d (%s)", i, step.Name)
+		}
+
+		stepProblems, errStep := env.runStep(step)
+		if errStep != nil {
+			return fmt.Errorf("%w for %s", errStep, stepName)
+		}
+		for _, problem := range stepProblems {
+			problems = append(problems, fmt.Sprintf("%s: %s", stepName, problem))
+		}
+	}
+
+	for _, account := range scenario.ExpectedAccounts {
+		accountProblems, errCheck := env.checkAccount(account)
+		if errCheck != nil {
+			return fmt.Errorf("%w for expected account %s", errCheck, account.Address)
+		}
+		for _, problem := range accountProblems {
+			problems = append(problems, fmt.Sprintf("account %s: %s", account.Address, problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ScenarioError{Scenario: scenario.Name, Problems: problems}
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *runner) IsInterfaceNil() bool {
+	return r == nil
+}
+
+type environment struct {
+	addressEncoder        vmcommon.AddressEncoder
+	codec                 jsonCodec
+	marshaller            vmcommon.Marshalizer
+	accounts              vmcommon.AccountsAdapter
+	storageHandler        vmcommon.ESDTNFTStorageHandler
+	globalMetadataHandler vmcommon.GlobalMetadataHandler
+	hook                  vmcommon.BlockchainHook
+}
+
+func (r *runner) createEnvironment(scenario *Scenario) (*environment, error) {
+	addressEncoder := NewAddressEncoder()
+	codec, err := vmcommon.NewJSONCodec(addressEncoder)
+	if err != nil {
+		return nil, err
+	}
+
+	enableEpochsHandler := r.enableEpochsHandler
+	if scenario.EnabledFlags != nil {
+		enableEpochsHandler, err = NewEnableEpochsHandler(scenario.EnabledFlags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	addressShards := make(map[string]uint32, len(scenario.AddressShards))
+	for encoded, shardID := range scenario.AddressShards {
+		address, errDecode := addressEncoder.Decode(encoded)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		addressShards[string(address)] = shardID
+	}
+	coordinator, err := newShardCoordinator(scenario.NumShards, scenario.SelfShard, addressShards)
+	if err != nil {
+		return nil, err
+	}
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+	accounts := inMemory.NewAccountsAdapter()
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           r.gasMap,
+		MapDNSAddresses:                  make(map[string]struct{}),
+		MapDNSV2Addresses:                make(map[string]struct{}),
+		Marshalizer:                      marshaller,
+		Accounts:                         accounts,
+		ShardCoordinator:                 coordinator,
+		EnableEpochsHandler:              enableEpochsHandler,
+		GuardedAccountHandler:            &disabledGuardedAccountHandler{},
+		MaxNumOfAddressesForTransferRole: maxNumOfAddressesForTransferRole,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = creator.CreateBuiltInFunctionContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	storageHandler, ok := creator.NFTStorageHandler().(vmcommon.ESDTNFTStorageHandler)
+	if !ok {
+		return nil, builtInFunctions.ErrWrongTypeAssertion
+	}
+
+	globalMetadataHandler, ok := creator.ESDTGlobalSettingsHandler().(vmcommon.GlobalMetadataHandler)
+	if !ok {
+		return nil, builtInFunctions.ErrWrongTypeAssertion
+	}
+
+	hook, err := inMemory.NewBlockchainHook(inMemory.ArgsBlockchainHook{
+		Accounts:              accounts,
+		BuiltInFunctions:      creator.BuiltInFunctionContainer(),
+		ShardCoordinator:      coordinator,
+		Marshaller:            marshaller,
+		NFTStorageHandler:     storageHandler,
+		GlobalSettingsHandler: globalMetadataHandler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = creator.SetPayableHandler(hook)
+	if err != nil {
+		return nil, err
+	}
+	err = creator.SetBlockchainHook(hook)
+	if err != nil {
+		return nil, err
+	}
+
+	return &environment{
+		addressEncoder:        addressEncoder,
+		codec:                 codec,
+		marshaller:            marshaller,
+		accounts:              accounts,
+		storageHandler:        storageHandler,
+		globalMetadataHandler: globalMetadataHandler,
+		hook:                  hook,
+	}, nil
+}
+
+func (env *environment) loadUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := env.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, builtInFunctions.ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+func (env *environment) setAccount(account *Account) error {
+	address, err := env.addressEncoder.Decode(account.Address)
+	if err != nil {
+		return err
+	}
+	userAccount, err := env.loadUserAccount(address)
+	if err != nil {
+		return err
+	}
+
+	if account.Nonce != nil {
+		userAccount.IncreaseNonce(*account.Nonce)
+	}
+	balance, err := decodeBigInt(account.Balance)
+	if err != nil {
+		return err
+	}
+	err = userAccount.AddToBalance(balance)
+	if err != nil {
+		return err
+	}
+	if len(account.Owner) > 0 {
+		owner, errDecode := env.addressEncoder.Decode(account.Owner)
+		if errDecode != nil {
+			return errDecode
+		}
+		userAccount.SetOwnerAddress(owner)
+	}
+	codeMetadata, err := decodeHex(account.CodeMetadata)
+	if err != nil {
+		return err
+	}
+	userAccount.SetCodeMetadata(codeMetadata)
+
+	for key, value := range account.Storage {
+		err = env.saveHexKeyValue(userAccount, key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for tokenID, roles := range account.Roles {
+		err = env.saveRoles(userAccount, tokenID, roles)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, esdtData := range account.ESDT {
+		err = env.saveESDT(userAccount, esdtData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return env.accounts.SaveAccount(userAccount)
+}
+
+func (env *environment) saveHexKeyValue(account vmcommon.UserAccountHandler, hexKey string, hexValue string) error {
+	key, err := decodeHex(hexKey)
+	if err != nil {
+		return err
+	}
+	value, err := decodeHex(hexValue)
+	if err != nil {
+		return err
+	}
+
+	return account.AccountDataHandler().SaveKeyValue(key, value)
+}
+
+func (env *environment) saveRoles(account vmcommon.UserAccountHandler, tokenID string, roles []string) error {
+	esdtRoles := &esdt.ESDTRoles{}
+	for _, role := range roles {
+		esdtRoles.Roles = append(esdtRoles.Roles, []byte(role))
+	}
+
+	marshalledRoles, err := env.marshaller.Marshal(esdtRoles)
+	if err != nil {
+		return err
+	}
+
+	return account.AccountDataHandler().SaveKeyValue([]byte(esdtRoleKeyPrefix+tokenID), marshalledRoles)
+}
+
+// the tokens are saved the way ESDTNFTCreate does, so that the metadata and the liquidity end up on the system
+// account when the flags require it, while the frozen and paused checks are skipped. An explicit type is also saved
+// in the global settings, as done when the token is issued.
+func (env *environment) saveESDT(account vmcommon.UserAccountHandler, esdtData *ESDTData) error {
+	token, err := env.toESDigitalToken(esdtData, uint32(core.Fungible))
+	if err != nil {
+		return err
+	}
+
+	esdtTokenKey := []byte(esdtKeyPrefix + esdtData.TokenIdentifier)
+	if len(esdtData.Type) > 0 {
+		err = env.globalMetadataHandler.SetTokenType(esdtTokenKey, token.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = env.storageHandler.SaveESDTNFTToken(account.AddressBytes(), account, esdtTokenKey, esdtData.Nonce, token, vmcommon.NftSaveArgs{
+		MustUpdateAllFields: true,
+		IsReturnWithError:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return env.storageHandler.AddToLiquiditySystemAcc(esdtTokenKey, token.Type, esdtData.Nonce, token.Value, false)
+}
+
+func (env *environment) toESDigitalToken(esdtData *ESDTData, defaultType uint32) (*esdt.ESDigitalToken, error) {
+	value, err := decodeBigInt(esdtData.Value)
+	if err != nil {
+		return nil, err
+	}
+	esdtType, err := decodeESDTType(esdtData.Type, defaultType)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &esdt.ESDigitalToken{
+		Type:  esdtType,
+		Value: value,
+	}
+	if esdtData.Frozen {
+		userMetadata := builtInFunctions.ESDTUserMetadata{Frozen: true}
+		token.Properties = userMetadata.ToBytes()
+	}
+	if esdtData.MetaData == nil {
+		return token, nil
+	}
+
+	token.TokenMetaData, err = env.toMetaData(esdtData.Nonce, esdtData.MetaData)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (env *environment) toMetaData(nonce uint64, metaData *ESDTMetaData) (*esdt.MetaData, error) {
+	var err error
+	result := &esdt.MetaData{
+		Nonce:     nonce,
+		Royalties: metaData.Royalties,
+	}
+	if len(metaData.Creator) > 0 {
+		result.Creator, err = env.addressEncoder.Decode(metaData.Creator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := []struct {
+		encoded string
+		decoded *[]byte
+	}{
+		{metaData.Name, &result.Name},
+		{metaData.Hash, &result.Hash},
+		{metaData.Attributes, &result.Attributes},
+	}
+	for _, field := range fields {
+		*field.decoded, err = decodeHex(field.encoded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, encodedURI := range metaData.URIs {
+		uri, errDecode := decodeHex(encodedURI)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		result.URIs = append(result.URIs, uri)
+	}
+
+	return result, nil
+}
+
+func (env *environment) setGlobalSettings(globalSettings map[string]*GlobalSettings) error {
+	if len(globalSettings) == 0 {
+		return nil
+	}
+
+	systemAccount, err := env.loadUserAccount(vmcommon.SystemAccountAddress)
+	if err != nil {
+		return err
+	}
+
+	for tokenID, settings := range globalSettings {
+		esdtTokenKey := []byte(esdtKeyPrefix + tokenID)
+		value, _, errRetrieve := systemAccount.AccountDataHandler().RetrieveValue(esdtTokenKey)
+		if errRetrieve != nil {
+			return errRetrieve
+		}
+
+		metadata := builtInFunctions.ESDTGlobalMetadataFromBytes(value)
+		metadata.Paused = settings.Paused
+		metadata.LimitedTransfer = settings.LimitedTransfer
+		metadata.BurnRoleForAll = settings.BurnRoleForAll
+		err = systemAccount.AccountDataHandler().SaveKeyValue(esdtTokenKey, metadata.ToBytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return env.accounts.SaveAccount(systemAccount)
+}
+
+// runStep returns the unmet expectations of the step, or an error if the step itself is not valid
+func (env *environment) runStep(step *Step) ([]string, error) {
+	input, err := env.codec.UnmarshalContractCallInput(step.Input)
+	if err != nil {
+		return nil, err
+	}
+	if input.CallValue == nil {
+		// the node always provides the call value
+		input.CallValue = big.NewInt(0)
+	}
+
+	var expectedOutput *vmcommon.VMOutput
+	if len(step.ExpectedOutput) > 0 {
+		expectedOutput, err = env.codec.UnmarshalVMOutput(step.ExpectedOutput)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var expectedLogs *vmcommon.VMOutput
+	if len(step.ExpectedLogs) > 0 {
+		expectedLogs, err = env.codec.UnmarshalVMOutput([]byte(fmt.Sprintf(`{"logs": %s}`, step.ExpectedLogs)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := env.hook.GetSnapshot()
+	vmOutput, errProcess := env.hook.ProcessBuiltInFunction(input)
+	if errProcess != nil {
+		err = env.hook.RevertToSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(step.ExpectedError) > 0 {
+		if errProcess == nil {
+			return []string{fmt.Sprintf("expected error %q, got none", step.ExpectedError)}, nil
+		}
+		if !strings.Contains(errProcess.Error(), step.ExpectedError) {
+			return []string{fmt.Sprintf("expected error %q, got %q", step.ExpectedError, errProcess.Error())}, nil
+		}
+
+		return nil, nil
+	}
+	if errProcess != nil {
+		return []string{fmt.Sprintf("unexpected error %q", errProcess.Error())}, nil
+	}
+
+	problems := make([]string, 0)
+	if expectedOutput != nil {
+		for _, diff := range vmcommon.DiffVMOutputs(expectedOutput, vmOutput) {
+			problems = append(problems, fmt.Sprintf("output: %s", diff.String()))
+		}
+	}
+	if expectedLogs != nil {
+		for _, diff := range vmcommon.DiffVMOutputs(expectedLogs, &vmcommon.VMOutput{Logs: vmOutput.Logs}) {
+			problems = append(problems, diff.String())
+		}
+	}
+
+	return problems, nil
+}
+
+// checkAccount returns the differences between the expected and the actual account, or an error if the expected
+// account is not valid
+func (env *environment) checkAccount(expected *Account) ([]string, error) {
+	address, err := env.addressEncoder.Decode(expected.Address)
+	if err != nil {
+		return nil, err
+	}
+	account, err := env.loadUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]string, 0)
+	addProblem := func(field string, expectedValue interface{}, actualValue interface{}) {
+		problems = append(problems, fmt.Sprintf("%s: expected %v, got %v", field, expectedValue, actualValue))
+	}
+
+	if expected.Nonce != nil && *expected.Nonce != account.GetNonce() {
+		addProblem("nonce", *expected.Nonce, account.GetNonce())
+	}
+	if len(expected.Balance) > 0 {
+		balance, errDecode := decodeBigInt(expected.Balance)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		if balance.Cmp(account.GetBalance()) != 0 {
+			addProblem("balance", balance, account.GetBalance())
+		}
+	}
+	if len(expected.Owner) > 0 {
+		owner, errDecode := env.addressEncoder.Decode(expected.Owner)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		if !bytes.Equal(owner, account.GetOwnerAddress()) {
+			addProblem("owner", expected.Owner, env.encodeAddress(account.GetOwnerAddress()))
+		}
+	}
+	if len(expected.CodeMetadata) > 0 && expected.CodeMetadata != hex.EncodeToString(account.GetCodeMetadata()) {
+		addProblem("code metadata", expected.CodeMetadata, hex.EncodeToString(account.GetCodeMetadata()))
+	}
+
+	for _, key := range sortedKeys(expected.Storage) {
+		decodedKey, errDecode := decodeHex(key)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		value, _, errRetrieve := account.AccountDataHandler().RetrieveValue(decodedKey)
+		if errRetrieve != nil {
+			return nil, errRetrieve
+		}
+		if expected.Storage[key] != hex.EncodeToString(value) {
+			addProblem(fmt.Sprintf("storage %s", key), expected.Storage[key], hex.EncodeToString(value))
+		}
+	}
+
+	for _, tokenID := range sortedKeys(expected.Roles) {
+		roles, errRoles := env.getRoles(account, tokenID)
+		if errRoles != nil {
+			return nil, errRoles
+		}
+		expectedRoles := append([]string{}, expected.Roles[tokenID]...)
+		sort.Strings(expectedRoles)
+		if strings.Join(expectedRoles, ",") != strings.Join(roles, ",") {
+			addProblem(fmt.Sprintf("roles of %s", tokenID), expectedRoles, roles)
+		}
+	}
+
+	for _, esdtData := range expected.ESDT {
+		esdtProblems, errCheck := env.checkESDT(account, esdtData)
+		if errCheck != nil {
+			return nil, errCheck
+		}
+		problems = append(problems, esdtProblems...)
+	}
+
+	return problems, nil
+}
+
+func (env *environment) getRoles(account vmcommon.UserAccountHandler, tokenID string) ([]string, error) {
+	marshalledRoles, _, err := account.AccountDataHandler().RetrieveValue([]byte(esdtRoleKeyPrefix + tokenID))
+	if err != nil {
+		return nil, err
+	}
+
+	esdtRoles := &esdt.ESDTRoles{}
+	if len(marshalledRoles) > 0 {
+		err = env.marshaller.Unmarshal(esdtRoles, marshalledRoles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roles := make([]string, 0, len(esdtRoles.Roles))
+	for _, role := range esdtRoles.Roles {
+		roles = append(roles, string(role))
+	}
+	sort.Strings(roles)
+
+	return roles, nil
+}
+
+func (env *environment) checkESDT(account vmcommon.UserAccountHandler, expected *ESDTData) ([]string, error) {
+	expectedToken, err := env.toESDigitalToken(expected, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	esdtTokenKey := []byte(esdtKeyPrefix + expected.TokenIdentifier)
+	actualToken, _, err := env.storageHandler.GetESDTNFTTokenOnDestination(account, esdtTokenKey, expected.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]string, 0)
+	name := tokenIdentifierForDisplay(expected)
+	if expectedToken.Value.Cmp(actualToken.Value) != 0 {
+		problems = append(problems, fmt.Sprintf("%s value: expected %s, got %s", name, expectedToken.Value, actualToken.Value))
+	}
+	if actualToken.Value.Sign() == 0 {
+		return problems, nil
+	}
+
+	if len(expected.Type) > 0 && expectedToken.Type != actualToken.Type {
+		problems = append(problems, fmt.Sprintf("%s type: expected %s, got %s", name, core.ESDTType(expectedToken.Type), core.ESDTType(actualToken.Type)))
+	}
+	actualFrozen := builtInFunctions.ESDTUserMetadataFromBytes(actualToken.Properties).Frozen
+	if expected.Frozen != actualFrozen {
+		problems = append(problems, fmt.Sprintf("%s frozen: expected %v, got %v", name, expected.Frozen, actualFrozen))
+	}
+	if expectedToken.TokenMetaData != nil && !expectedToken.TokenMetaData.Equal(actualToken.TokenMetaData) {
+		problems = append(problems, fmt.Sprintf("%s metadata: expected %v, got %v", name, expectedToken.TokenMetaData, actualToken.TokenMetaData))
+	}
+
+	return problems, nil
+}
+
+func (env *environment) encodeAddress(address []byte) string {
+	encoded, err := env.addressEncoder.Encode(address)
+	if err != nil {
+		return hex.EncodeToString(address)
+	}
+
+	return encoded
+}
+
+func tokenIdentifierForDisplay(esdtData *ESDTData) string {
+	if esdtData.Nonce == 0 {
+		return esdtData.TokenIdentifier
+	}
+
+	return fmt.Sprintf("%s nonce %d", esdtData.TokenIdentifier, esdtData.Nonce)
+}
+
+func decodeHex(encoded string) ([]byte, error) {
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidValue, encoded, err.Error())
+	}
+
+	return decoded, nil
+}
+
+func decodeBigInt(encoded string) (*big.Int, error) {
+	if len(encoded) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	value, ok := big.NewInt(0).SetString(encoded, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w %q: not a decimal number", ErrInvalidValue, encoded)
+	}
+
+	return value, nil
+}
+
+func decodeESDTType(name string, defaultType uint32) (uint32, error) {
+	if len(name) == 0 {
+		return defaultType, nil
+	}
+
+	for esdtType := core.Fungible; esdtType <= core.DynamicMeta; esdtType++ {
+		if esdtType.String() == name {
+			return uint32(esdtType), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnknownESDTType, name)
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
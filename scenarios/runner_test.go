@@ -0,0 +1,147 @@
+package scenarios
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRunner(t *testing.T) *runner {
+	allFlags := make([]string, 0)
+	for _, flag := range builtInFunctions.AllFlags() {
+		allFlags = append(allFlags, string(flag))
+	}
+	enableEpochsHandler, err := NewEnableEpochsHandler(allFlags)
+	require.Nil(t, err)
+
+	scenarioRunner, err := NewRunner(ArgsRunner{
+		GasMap:              mock.FillGasMapInternal(make(map[string]map[string]uint64), 1),
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+	require.Nil(t, err)
+
+	return scenarioRunner
+}
+
+func TestNewRunner(t *testing.T) {
+	t.Parallel()
+
+	scenarioRunner, err := NewRunner(ArgsRunner{EnableEpochsHandler: &enableEpochsHandler{}})
+	assert.Nil(t, scenarioRunner)
+	assert.Equal(t, ErrNilGasMap, err)
+
+	scenarioRunner, err = NewRunner(ArgsRunner{GasMap: mock.FillGasMapInternal(make(map[string]map[string]uint64), 1)})
+	assert.Nil(t, scenarioRunner)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	scenarioRunner, err = NewRunner(ArgsRunner{GasMap: mock.FillGasMapInternal(make(map[string]map[string]uint64), 1), EnableEpochsHandler: &enableEpochsHandler{}})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(scenarioRunner))
+}
+
+func TestRunner_TestdataScenarios(t *testing.T) {
+	t.Parallel()
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.Nil(t, err)
+	require.NotEmpty(t, paths)
+
+	scenarioRunner := createRunner(t)
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Nil(t, scenarioRunner.RunFile(path))
+		})
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "esdtTransfer.json"))
+	require.Nil(t, err)
+
+	t.Run("nil scenario should error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrNilScenario, createRunner(t).Run(nil))
+	})
+	t.Run("unknown scenario fields should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, errLoad := LoadScenario([]byte(`{"name": "typo", "expectedAcounts": []}`))
+		assert.NotNil(t, errLoad)
+	})
+	t.Run("unknown flag should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, _ := LoadScenario(data)
+		scenario.EnabledFlags = []string{"NotAFlag"}
+		errRun := createRunner(t).Run(scenario)
+		assert.True(t, errors.Is(errRun, ErrUnknownFlag))
+	})
+	t.Run("unmet expectations should be all reported", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, _ := LoadScenario(data)
+		scenario.Steps[1].ExpectedError = ""
+		scenario.ExpectedAccounts[0].ESDT[0].Value = "71"
+		scenario.ExpectedAccounts[1].Storage = map[string]string{"6b6579": "76616c7565"}
+
+		errRun := createRunner(t).Run(scenario)
+		scenarioErr := &ScenarioError{}
+		require.True(t, errors.As(errRun, &scenarioErr))
+		assert.True(t, errors.Is(errRun, ErrScenarioFailed))
+		require.Len(t, scenarioErr.Problems, 3)
+		assert.True(t, strings.HasPrefix(scenarioErr.Problems[0], "step 1 (paused token can not be transferred): unexpected error"))
+		assert.Equal(t, "account address:alice: TOKEN-abcdef value: expected 71, got 70", scenarioErr.Problems[1])
+		assert.Equal(t, "account address:bob: storage 6b6579: expected 76616c7565, got ", scenarioErr.Problems[2])
+	})
+	t.Run("different logs should be reported", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, _ := LoadScenario(data)
+		scenario.Steps[0].ExpectedLogs = []byte(`[]`)
+
+		errRun := createRunner(t).Run(scenario)
+		scenarioErr := &ScenarioError{}
+		require.True(t, errors.As(errRun, &scenarioErr))
+		require.Len(t, scenarioErr.Problems, 1)
+		assert.Contains(t, scenarioErr.Problems[0], "step 0 (transfer 30 TOKEN to bob): log")
+	})
+}
+
+func TestAddressEncoder(t *testing.T) {
+	t.Parallel()
+
+	encoder := NewAddressEncoder()
+	for _, readable := range []string{"address:alice", "sc:adder", "0102"} {
+		address, err := encoder.Decode(readable)
+		require.Nil(t, err)
+		encoded, err := encoder.Encode(address)
+		require.Nil(t, err)
+		assert.Equal(t, readable, encoded)
+	}
+
+	address, _ := encoder.Decode("sc:adder")
+	assert.Len(t, address, AddressLength)
+	assert.True(t, vmcommon.IsSmartContractAddress(address))
+
+	_, err := encoder.Decode("address:" + strings.Repeat("a", AddressLength+1))
+	assert.True(t, errors.Is(err, ErrInvalidAddress))
+	_, err = encoder.Decode("address:")
+	assert.True(t, errors.Is(err, ErrInvalidAddress))
+	_, err = encoder.Decode("not hex")
+	assert.True(t, errors.Is(err, ErrInvalidAddress))
+}
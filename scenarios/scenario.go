@@ -0,0 +1,100 @@
+package scenarios
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+)
+
+// Scenario describes a built-in function test as data: the initial state, the calls to execute and the expected
+// results. Addresses are written as accepted by NewAddressEncoder, all the other byte slices are hex encoded and
+// big integers are decimal strings. The inputs, outputs and logs use the format of vmcommon.NewJSONCodec.
+type Scenario struct {
+	Name string `json:"name"`
+	// EnabledFlags, when set, replaces the enable epochs handler of the runner: only these flags are enabled
+	EnabledFlags     []string                   `json:"enabledFlags,omitempty"`
+	NumShards        uint32                     `json:"numShards,omitempty"`
+	SelfShard        uint32                     `json:"selfShard,omitempty"`
+	AddressShards    map[string]uint32          `json:"addressShards,omitempty"`
+	GlobalSettings   map[string]*GlobalSettings `json:"globalSettings,omitempty"`
+	Accounts         []*Account                 `json:"accounts"`
+	Steps            []*Step                    `json:"steps"`
+	ExpectedAccounts []*Account                 `json:"expectedAccounts,omitempty"`
+}
+
+// GlobalSettings holds the global settings of a token, saved on the system account
+type GlobalSettings struct {
+	Paused          bool `json:"paused,omitempty"`
+	LimitedTransfer bool `json:"limitedTransfer,omitempty"`
+	BurnRoleForAll  bool `json:"burnRoleForAll,omitempty"`
+}
+
+// Account describes an account of the initial state or the expected final state. For the expected state, only the
+// fields that are set are checked: the listed storage keys, where an empty value means that the key is not set, the
+// listed tokens, where a 0 value means that the token is not held, and the roles of the listed tokens.
+type Account struct {
+	Address      string              `json:"address"`
+	Nonce        *uint64             `json:"nonce,omitempty"`
+	Balance      string              `json:"balance,omitempty"`
+	Owner        string              `json:"owner,omitempty"`
+	CodeMetadata string              `json:"codeMetadata,omitempty"`
+	Storage      map[string]string   `json:"storage,omitempty"`
+	ESDT         []*ESDTData         `json:"esdt,omitempty"`
+	Roles        map[string][]string `json:"roles,omitempty"`
+}
+
+// ESDTData describes a token held by an account. The type is the name used by core.ESDTType, FungibleESDT by default
+// for the initial state and not checked for the expected state when empty.
+type ESDTData struct {
+	TokenIdentifier string        `json:"tokenIdentifier"`
+	Nonce           uint64        `json:"nonce,omitempty"`
+	Value           string        `json:"value"`
+	Type            string        `json:"type,omitempty"`
+	Frozen          bool          `json:"frozen,omitempty"`
+	MetaData        *ESDTMetaData `json:"metaData,omitempty"`
+}
+
+// ESDTMetaData describes the metadata of an NFT, SFT or meta ESDT
+type ESDTMetaData struct {
+	Name       string   `json:"name,omitempty"`
+	Creator    string   `json:"creator,omitempty"`
+	Royalties  uint32   `json:"royalties,omitempty"`
+	Hash       string   `json:"hash,omitempty"`
+	URIs       []string `json:"uris,omitempty"`
+	Attributes string   `json:"attributes,omitempty"`
+}
+
+// Step is one built-in function call. The step fails if the call does not return the expected error, or if the
+// expected output or logs are set and differ from the actual ones. A failed call does not change the state.
+type Step struct {
+	Name           string          `json:"name,omitempty"`
+	Input          json.RawMessage `json:"input"`
+	ExpectedError  string          `json:"expectedError,omitempty"`
+	ExpectedOutput json.RawMessage `json:"expectedOutput,omitempty"`
+	ExpectedLogs   json.RawMessage `json:"expectedLogs,omitempty"`
+}
+
+// LoadScenario decodes the scenario from its JSON representation. Unknown fields are rejected, so that a typo does
+// not silently remove a check.
+func LoadScenario(data []byte) (*Scenario, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	scenario := &Scenario{}
+	err := decoder.Decode(scenario)
+	if err != nil {
+		return nil, err
+	}
+
+	return scenario, nil
+}
+
+// LoadScenarioFile decodes the scenario from the provided JSON file
+func LoadScenarioFile(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadScenario(data)
+}
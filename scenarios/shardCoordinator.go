@@ -0,0 +1,74 @@
+package scenarios
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// shardCoordinator places the addresses in shards as configured by the scenario. The addresses that are not
+// configured are in the self shard.
+type shardCoordinator struct {
+	numShards     uint32
+	selfID        uint32
+	addressShards map[string]uint32
+}
+
+func newShardCoordinator(numShards uint32, selfID uint32, addressShards map[string]uint32) (*shardCoordinator, error) {
+	if numShards == 0 {
+		numShards = 1
+	}
+	isValidShard := func(shardID uint32) bool {
+		return shardID < numShards || shardID == core.MetachainShardId
+	}
+
+	if !isValidShard(selfID) {
+		return nil, fmt.Errorf("%w: self shard %d with %d shards", ErrInvalidShardConfiguration, selfID, numShards)
+	}
+	for address, shardID := range addressShards {
+		if !isValidShard(shardID) {
+			return nil, fmt.Errorf("%w: shard %d of %x with %d shards", ErrInvalidShardConfiguration, shardID, address, numShards)
+		}
+	}
+
+	return &shardCoordinator{
+		numShards:     numShards,
+		selfID:        selfID,
+		addressShards: addressShards,
+	}, nil
+}
+
+// NumberOfShards returns the number of shards
+func (coordinator *shardCoordinator) NumberOfShards() uint32 {
+	return coordinator.numShards
+}
+
+// ComputeId returns the shard configured for the address, or the self shard
+func (coordinator *shardCoordinator) ComputeId(address []byte) uint32 {
+	shardID, found := coordinator.addressShards[string(address)]
+	if !found {
+		return coordinator.selfID
+	}
+
+	return shardID
+}
+
+// SelfId returns the self shard
+func (coordinator *shardCoordinator) SelfId() uint32 {
+	return coordinator.selfID
+}
+
+// SameShard returns true if the addresses are in the same shard
+func (coordinator *shardCoordinator) SameShard(firstAddress, secondAddress []byte) bool {
+	return coordinator.ComputeId(firstAddress) == coordinator.ComputeId(secondAddress)
+}
+
+// CommunicationIdentifier returns the identifier between the self shard and the destination shard, smaller shard first
+func (coordinator *shardCoordinator) CommunicationIdentifier(destShardID uint32) string {
+	return core.CommunicationIdentifierBetweenShards(coordinator.selfID, destShardID)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (coordinator *shardCoordinator) IsInterfaceNil() bool {
+	return coordinator == nil
+}
@@ -0,0 +1,21 @@
+package tokenIdentifier
+
+import "errors"
+
+// ErrInvalidTokenIDLength signals that the token identifier has an invalid length
+var ErrInvalidTokenIDLength = errors.New("invalid token identifier length")
+
+// ErrMissingSeparator signals that the separator between the ticker and the random sequence is missing
+var ErrMissingSeparator = errors.New("missing separator between ticker and random sequence")
+
+// ErrInvalidTicker signals that the ticker is not made of 3 to 10 uppercase alphanumeric characters
+var ErrInvalidTicker = errors.New("invalid ticker")
+
+// ErrInvalidRandomSequence signals that the random sequence is not made of 6 lowercase hex characters
+var ErrInvalidRandomSequence = errors.New("invalid random sequence")
+
+// ErrInvalidNonce signals that the nonce suffix is not a valid, non-zero, hex encoded number
+var ErrInvalidNonce = errors.New("invalid nonce")
+
+// ErrNonceNotAllowedForEGLD signals that a nonce suffix was provided for the EGLD identifier
+var ErrNonceNotAllowedForEGLD = errors.New("nonce not allowed for the EGLD identifier")
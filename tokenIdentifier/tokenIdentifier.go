@@ -0,0 +1,207 @@
+package tokenIdentifier
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// Separator is the character that separates the ticker, the random sequence and the nonce
+	Separator = "-"
+	// TickerMinLength is the minimum length of a ticker
+	TickerMinLength = 3
+	// TickerMaxLength is the maximum length of a ticker
+	TickerMaxLength = 10
+	// RandomSequenceLength is the length of the random sequence that follows the ticker
+	RandomSequenceLength = 6
+	// EGLDIdentifier represents the identifier for the EGLD in case of a transfer with MultiESDTNFTTransfer built-in function
+	EGLDIdentifier = "EGLD-000000"
+)
+
+const separatorChar = byte('-')
+const tokenIDMinLength = TickerMinLength + len(Separator) + RandomSequenceLength
+const tokenIDMaxLength = TickerMaxLength + len(Separator) + RandomSequenceLength
+const maxNonceBytes = 8
+
+// TokenIdentifier holds the components of an ESDT token identifier such as TICKER-abcdef or TICKER-abcdef-0a
+type TokenIdentifier struct {
+	Ticker         []byte
+	RandomSequence []byte
+	Nonce          uint64
+}
+
+// Parse splits the provided identifier into ticker, random sequence and optional nonce. The identifier is expected
+// in its human-readable form, with the nonce hex encoded after a second separator: TICKER-abcdef or TICKER-abcdef-0a.
+// The returned error tells why the identifier is not valid. EGLD-000000 is accepted, but without a nonce suffix.
+func Parse(identifier []byte) (*TokenIdentifier, error) {
+	tokenID := identifier
+	var nonceSuffix []byte
+	hasNonce := false
+	tickerEnd := bytes.IndexByte(identifier, separatorChar)
+	if tickerEnd >= 0 {
+		randomSequenceEnd := bytes.IndexByte(identifier[tickerEnd+1:], separatorChar)
+		if randomSequenceEnd >= 0 {
+			tokenID = identifier[:tickerEnd+1+randomSequenceEnd]
+			nonceSuffix = identifier[tickerEnd+1+randomSequenceEnd+1:]
+			hasNonce = true
+		}
+	}
+
+	err := ValidateTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	separatorPosition := len(tokenID) - RandomSequenceLength - 1
+	token := &TokenIdentifier{
+		Ticker:         append([]byte{}, tokenID[:separatorPosition]...),
+		RandomSequence: append([]byte{}, tokenID[separatorPosition+1:]...),
+	}
+	if !hasNonce {
+		return token, nil
+	}
+
+	if IsEGLD(tokenID) {
+		return nil, ErrNonceNotAllowedForEGLD
+	}
+
+	token.Nonce, err = parseNonceSuffix(nonceSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// the nonce must be in the same canonical form as the one produced by Format, so parsing and formatting round-trips
+func parseNonceSuffix(nonceSuffix []byte) (uint64, error) {
+	nonceBytes := make([]byte, hex.DecodedLen(len(nonceSuffix)))
+	_, err := hex.Decode(nonceBytes, nonceSuffix)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidNonce, err.Error())
+	}
+	if !bytes.Equal(bytes.ToLower(nonceSuffix), nonceSuffix) {
+		return 0, fmt.Errorf("%w: %q is not lowercase hex", ErrInvalidNonce, nonceSuffix)
+	}
+	if len(nonceBytes) == 0 || len(nonceBytes) > maxNonceBytes || nonceBytes[0] == 0 {
+		return 0, fmt.Errorf("%w: %q is not a non-zero uint64 without leading zeros", ErrInvalidNonce, nonceSuffix)
+	}
+
+	return big.NewInt(0).SetBytes(nonceBytes).Uint64(), nil
+}
+
+// ValidateTokenID checks that the provided token ID, without a nonce, is made of a ticker of 3 to 10 uppercase
+// alphanumeric characters, a separator and a random sequence of 6 lowercase hex characters. The returned error tells
+// why the token ID is not valid.
+func ValidateTokenID(tokenID []byte) error {
+	tokenIDLen := len(tokenID)
+	if tokenIDLen < tokenIDMinLength || tokenIDLen > tokenIDMaxLength {
+		return fmt.Errorf("%w: %d, expected between %d and %d", ErrInvalidTokenIDLength, tokenIDLen, tokenIDMinLength, tokenIDMaxLength)
+	}
+
+	separatorPosition := tokenIDLen - RandomSequenceLength - 1
+	if tokenID[separatorPosition] != separatorChar {
+		return fmt.Errorf("%w in %q", ErrMissingSeparator, tokenID)
+	}
+
+	ticker := tokenID[:separatorPosition]
+	if !isTickerValid(ticker) {
+		return fmt.Errorf("%w: %q", ErrInvalidTicker, ticker)
+	}
+
+	randomSequence := tokenID[separatorPosition+1:]
+	if !isRandomSequenceValid(randomSequence) {
+		return fmt.Errorf("%w: %q", ErrInvalidRandomSequence, randomSequence)
+	}
+
+	return nil
+}
+
+// ticker must be all uppercase alphanumeric
+func isTickerValid(ticker []byte) bool {
+	if len(ticker) < TickerMinLength || len(ticker) > TickerMaxLength {
+		return false
+	}
+	for _, ch := range ticker {
+		isBigCharacter := ch >= 'A' && ch <= 'Z'
+		isNumber := ch >= '0' && ch <= '9'
+		if !isBigCharacter && !isNumber {
+			return false
+		}
+	}
+
+	return true
+}
+
+// random sequence is lowercase hex
+func isRandomSequenceValid(randomSequence []byte) bool {
+	if len(randomSequence) != RandomSequenceLength {
+		return false
+	}
+	for _, ch := range randomSequence {
+		isSmallCharacter := ch >= 'a' && ch <= 'f'
+		isNumber := ch >= '0' && ch <= '9'
+		if !isSmallCharacter && !isNumber {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TokenID returns the identifier without the nonce: TICKER-abcdef
+func (token *TokenIdentifier) TokenID() []byte {
+	tokenID := make([]byte, 0, len(token.Ticker)+len(Separator)+len(token.RandomSequence))
+	tokenID = append(tokenID, token.Ticker...)
+	tokenID = append(tokenID, Separator...)
+	return append(tokenID, token.RandomSequence...)
+}
+
+// String returns the human-readable identifier, including the nonce if it is not 0
+func (token *TokenIdentifier) String() string {
+	return Format(token.TokenID(), token.Nonce)
+}
+
+// IsEGLD returns true if the identifier stands for the EGLD
+func (token *TokenIdentifier) IsEGLD() bool {
+	return token.Nonce == 0 && IsEGLD(token.TokenID())
+}
+
+// IsEGLD returns true if the provided token ID is the EGLD identifier
+func IsEGLD(tokenID []byte) bool {
+	return string(tokenID) == EGLDIdentifier
+}
+
+// Format returns the human-readable identifier of the token ID and nonce. The nonce is appended hex encoded,
+// after a separator, only if it is not 0. The token ID is not validated.
+func Format(tokenID []byte, nonce uint64) string {
+	if nonce == 0 {
+		return string(tokenID)
+	}
+
+	nonceBytes := big.NewInt(0).SetUint64(nonce).Bytes()
+	return fmt.Sprintf("%s%s%s", tokenID, Separator, hex.EncodeToString(nonceBytes))
+}
+
+// SplitTokenIDAndNonce splits the token ID and the nonce out of the key form used by the ESDT storage and by the
+// freeze and wipe arguments: the token ID directly followed by the big endian bytes of the nonce. No validation is
+// done: if the part after the ticker is not longer than the random sequence, the key is returned as it is, with
+// nonce 0. Only the bytes up to the next separator are considered, so an identifier that is already in the
+// human-readable form is returned as it is.
+func SplitTokenIDAndNonce(key []byte) ([]byte, uint64) {
+	keySplit := bytes.Split(key, []byte(Separator))
+	if len(keySplit) < 2 {
+		return key, 0
+	}
+
+	if len(keySplit[1]) <= RandomSequenceLength {
+		return key, 0
+	}
+
+	tokenID := []byte(fmt.Sprintf("%s%s%s", keySplit[0], Separator, keySplit[1][:RandomSequenceLength]))
+	nonce := big.NewInt(0).SetBytes(keySplit[1][RandomSequenceLength:])
+
+	return tokenID, nonce.Uint64()
+}
@@ -0,0 +1,127 @@
+package tokenIdentifier
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_ValidIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	token, err := Parse([]byte("WEGLD-7fbb90"))
+	require.Nil(t, err)
+	assert.Equal(t, &TokenIdentifier{Ticker: []byte("WEGLD"), RandomSequence: []byte("7fbb90")}, token)
+	assert.Equal(t, "WEGLD-7fbb90", token.String())
+	assert.False(t, token.IsEGLD())
+
+	token, err = Parse([]byte("NFT123-abcdef-0a"))
+	require.Nil(t, err)
+	assert.Equal(t, &TokenIdentifier{Ticker: []byte("NFT123"), RandomSequence: []byte("abcdef"), Nonce: 10}, token)
+	assert.Equal(t, []byte("NFT123-abcdef"), token.TokenID())
+	assert.Equal(t, "NFT123-abcdef-0a", token.String())
+
+	token, err = Parse([]byte("ABCDEFGHIJ-012345-ffffffffffffffff"))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0xffffffffffffffff), token.Nonce)
+
+	token, err = Parse([]byte(EGLDIdentifier))
+	require.Nil(t, err)
+	assert.True(t, token.IsEGLD())
+	assert.Equal(t, EGLDIdentifier, token.String())
+}
+
+func TestParse_InvalidIdentifiersShouldReportTheReason(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]error{
+		"":                                ErrInvalidTokenIDLength,
+		"AL-6258d2":                       ErrInvalidTokenIDLength,
+		"ALCCCCCCCCC-6258d2":              ErrInvalidTokenIDLength,
+		"ALC6258d2":                       ErrInvalidTokenIDLength,
+		"AL-C6258d2":                      ErrMissingSeparator,
+		"alc-6258d2":                      ErrInvalidTicker,
+		"EGLDRIDEF*-08d8ef":               ErrInvalidTicker,
+		"ALC-6258D2":                      ErrInvalidRandomSequence,
+		"TOKEN-abcd-01":                   ErrMissingSeparator,
+		"TOKEN-abcdef-":                   ErrInvalidNonce,
+		"TOKEN-abcdef-1":                  ErrInvalidNonce,
+		"TOKEN-abcdef-0A":                 ErrInvalidNonce,
+		"TOKEN-abcdef-00":                 ErrInvalidNonce,
+		"TOKEN-abcdef-000a":               ErrInvalidNonce,
+		"TOKEN-abcdef-zz":                 ErrInvalidNonce,
+		"TOKEN-abcdef-010000000000000000": ErrInvalidNonce,
+		"TOKEN-abcdef-01-02":              ErrInvalidNonce,
+		"EGLD-000000-01":                  ErrNonceNotAllowedForEGLD,
+	}
+
+	for identifier, expectedErr := range testCases {
+		token, err := Parse([]byte(identifier))
+		assert.Nil(t, token, identifier)
+		assert.True(t, errors.Is(err, expectedErr), "%s: %v", identifier, err)
+	}
+}
+
+func TestValidateTokenID(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, ValidateTokenID([]byte("ALC-6258d2")))
+	assert.Nil(t, ValidateTokenID([]byte("12345-6258d2")))
+	assert.Nil(t, ValidateTokenID([]byte(EGLDIdentifier)))
+
+	err := ValidateTokenID([]byte("EGLDRIDEFl-08d8ef"))
+	assert.True(t, errors.Is(err, ErrInvalidTicker))
+	assert.Equal(t, `invalid ticker: "EGLDRIDEFl"`, err.Error())
+
+	err = ValidateTokenID([]byte("NFT-abcdef-01"))
+	assert.True(t, errors.Is(err, ErrMissingSeparator))
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "MYTOKEN-abcd-0a", Format([]byte("MYTOKEN-abcd"), 10))
+	assert.Equal(t, "MYTOKEN-abcdef-0100", Format([]byte("MYTOKEN-abcdef"), 256))
+	assert.Equal(t, "MYTOKEN-abcdef", Format([]byte("MYTOKEN-abcdef"), 0))
+
+	for _, nonce := range []uint64{1, 15, 16, 255, 256, 65535, 1 << 40, 0xffffffffffffffff} {
+		identifier := Format([]byte("NFT-abcdef"), nonce)
+		token, err := Parse([]byte(identifier))
+		require.Nil(t, err, identifier)
+		assert.Equal(t, nonce, token.Nonce)
+		assert.Equal(t, identifier, token.String())
+	}
+}
+
+func TestSplitTokenIDAndNonce(t *testing.T) {
+	t.Parallel()
+
+	key, _ := hex.DecodeString("534b4537592d37336262636404")
+	tokenID, nonce := SplitTokenIDAndNonce(key)
+	require.Equal(t, uint64(4), nonce)
+	require.Equal(t, []byte("SKE7Y-73bbcd"), tokenID)
+
+	key, _ = hex.DecodeString("5745474c442d376662623930")
+	tokenID, nonce = SplitTokenIDAndNonce(key)
+	require.Equal(t, uint64(0), nonce)
+	require.Equal(t, []byte("WEGLD-7fbb90"), tokenID)
+
+	tokenID, nonce = SplitTokenIDAndNonce([]byte("TOKEN-abcd-01"))
+	require.Equal(t, uint64(0), nonce)
+	require.Equal(t, []byte("TOKEN-abcd-01"), tokenID)
+
+	tokenID, nonce = SplitTokenIDAndNonce([]byte("TTTTT"))
+	require.Equal(t, uint64(0), nonce)
+	require.Equal(t, []byte("TTTTT"), tokenID)
+}
+
+func TestIsEGLD(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsEGLD([]byte("EGLD-000000")))
+	assert.False(t, IsEGLD([]byte("EGLD")))
+	assert.False(t, IsEGLD([]byte("WEGLD-7fbb90")))
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-common-go/codec"
 )
 
 // txDataBuilder constructs a string to be used for transaction arguments
@@ -12,6 +13,7 @@ type txDataBuilder struct {
 	function  string
 	elements  []string
 	separator string
+	err       error
 }
 
 // NewBuilder creates a new txDataBuilder instance.
@@ -28,6 +30,7 @@ func NewBuilder() *txDataBuilder {
 func (builder *txDataBuilder) Clear() *txDataBuilder {
 	builder.function = ""
 	builder.elements = make([]string, 0)
+	builder.err = nil
 
 	return builder
 }
@@ -137,22 +140,53 @@ func (builder *txDataBuilder) BigInt(value *big.Int) *txDataBuilder {
 	return builder.Bytes(value.Bytes())
 }
 
+// Value appends the top level encoding of a value to the data string. If the value can not be encoded, nothing is
+// appended and the error is returned by Err.
+func (builder *txDataBuilder) Value(value codec.Value) *txDataBuilder {
+	encoded, err := codec.EncodeTopLevel(value)
+	if err != nil {
+		return builder.setErr(err)
+	}
+
+	return builder.Bytes(encoded)
+}
+
+// setErr keeps the provided error if no other error was encountered since the last Clear
+func (builder *txDataBuilder) setErr(err error) *txDataBuilder {
+	if builder.err == nil {
+		builder.err = err
+	}
+
+	return builder
+}
+
+// Err returns the first error encountered while appending values since the last Clear.
+func (builder *txDataBuilder) Err() error {
+	return builder.err
+}
+
 // IssueESDT appends to the data string all the elements required to request an ESDT issuing.
 func (builder *txDataBuilder) IssueESDT(token string, ticker string, supply int64, numDecimals byte) *txDataBuilder {
 	return builder.Func("issue").Str(token).Str(ticker).Int64(supply).Byte(numDecimals)
 }
 
 // TransferESDT appends to the data string all the elements required to request an ESDT transfer.
+//
+// Deprecated: use ESDTTransfer, which takes the token as bytes and the value as a big.Int.
 func (builder *txDataBuilder) TransferESDT(token string, value int64) *txDataBuilder {
 	return builder.Func(core.BuiltInFunctionESDTTransfer).Str(token).Int64(value)
 }
 
-//TransferESDTNFT appends to the data string all the elements required to request an ESDT NFT transfer.
+// TransferESDTNFT appends to the data string all the elements required to request an ESDT NFT transfer.
+//
+// Deprecated: use ESDTNFTTransfer, which also appends the destination.
 func (builder *txDataBuilder) TransferESDTNFT(token string, nonce int, value int64) *txDataBuilder {
 	return builder.Func(core.BuiltInFunctionESDTNFTTransfer).Str(token).Int(nonce).Int64(value)
 }
 
 // BurnESDT appends to the data string all the elements required to burn ESDT tokens.
+//
+// Deprecated: use ESDTBurn, which takes the token as bytes and the value as a big.Int.
 func (builder *txDataBuilder) BurnESDT(token string, value int64) *txDataBuilder {
 	return builder.Func(core.BuiltInFunctionESDTBurn).Str(token).Int64(value)
 }
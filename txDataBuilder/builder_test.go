@@ -0,0 +1,40 @@
+package txDataBuilder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-common-go/codec"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxDataBuilder_Value(t *testing.T) {
+	t.Parallel()
+
+	list := &codec.ListValue{Items: []codec.Value{&codec.U32Value{Value: 1}, &codec.U32Value{Value: 2}}}
+	builder := NewBuilder().Func("add").
+		Value(&codec.I64Value{Value: -1}).
+		Value(&codec.OptionValue{IsSet: true, Value: &codec.BigUintValue{Value: big.NewInt(5)}}).
+		Value(list)
+	require.Nil(t, builder.Err())
+	assert.Equal(t, "add@ff@010000000105@0000000100000002", builder.ToString())
+
+	signed := &codec.I64Value{}
+	option := &codec.OptionValue{Value: &codec.BigUintValue{}}
+	decodedList := &codec.ListValue{NewItem: func() codec.Value { return &codec.U32Value{} }}
+	function, err := parsers.NewCallArgsParser().ParseTypedData(builder.ToString(), signed, option, decodedList)
+	require.Nil(t, err)
+	assert.Equal(t, "add", function)
+	assert.Equal(t, int64(-1), signed.Value)
+	assert.Equal(t, big.NewInt(5), option.Value.(*codec.BigUintValue).Value)
+	assert.Equal(t, list.Items, decodedList.Items)
+
+	builder.Value(&codec.AddressValue{Value: []byte{1}}).Value(nil)
+	assert.ErrorIs(t, builder.Err(), codec.ErrInvalidAddressLength)
+	assert.Equal(t, "add@ff@010000000105@0000000100000002", builder.ToString())
+
+	builder.Clear()
+	assert.Nil(t, builder.Err())
+}
@@ -0,0 +1,368 @@
+package txDataBuilder
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const deleteUserNameFunction = "DeleteUserName"
+
+// TransferEntry is one of the transfers of a MultiESDTNFTTransfer: EGLD, a fungible ESDT or an NFT, SFT or meta ESDT.
+type TransferEntry struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+	Value           *big.Int
+}
+
+// EGLDTransferEntry returns the entry that transfers EGLD with MultiESDTNFTTransfer.
+func EGLDTransferEntry(value *big.Int) *TransferEntry {
+	return &TransferEntry{
+		TokenIdentifier: []byte(vmcommon.EGLDIdentifier),
+		Value:           value,
+	}
+}
+
+// KeyValue is a storage key with its value, as saved by SaveKeyValue.
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+// NonceInterval is an inclusive interval of token nonces.
+type NonceInterval struct {
+	Start uint64
+	End   uint64
+}
+
+// TokenNonceIntervals holds the nonce intervals of a token, as removed by ESDTDeleteMetadata.
+type TokenNonceIntervals struct {
+	TokenIdentifier []byte
+	Intervals       []NonceInterval
+}
+
+// TokenMetaData holds the marshaled metadata of a token nonce, as added by ESDTAddMetadata.
+type TokenMetaData struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+	MetaData        []byte
+}
+
+// Uint64 appends an uint64 to the data string, such as a nonce.
+func (builder *txDataBuilder) Uint64(value uint64) *txDataBuilder {
+	return builder.Bytes(big.NewInt(0).SetUint64(value).Bytes())
+}
+
+// BigUint appends the bytes of a big.Int to the data string. A nil value is appended as 0. A negative value is not
+// appended and ErrNegativeValue is returned by Err.
+func (builder *txDataBuilder) BigUint(value *big.Int) *txDataBuilder {
+	if value == nil {
+		return builder.Bytes(nil)
+	}
+	if value.Sign() < 0 {
+		return builder.setErr(ErrNegativeValue)
+	}
+
+	return builder.BigInt(value)
+}
+
+// BytesList appends each of the provided slices of bytes to the data string.
+func (builder *txDataBuilder) BytesList(values ...[]byte) *txDataBuilder {
+	for _, value := range values {
+		builder.Bytes(value)
+	}
+
+	return builder
+}
+
+// SCCall appends to the data string the function and the arguments of the smart contract call that follows a transfer.
+func (builder *txDataBuilder) SCCall(function string, arguments ...[]byte) *txDataBuilder {
+	return builder.Str(function).BytesList(arguments...)
+}
+
+// ClaimDeveloperRewards appends to the data string all the elements required to claim the developer rewards.
+func (builder *txDataBuilder) ClaimDeveloperRewards() *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionClaimDeveloperRewards)
+}
+
+// ChangeOwnerAddress appends to the data string all the elements required to change the owner of a contract.
+func (builder *txDataBuilder) ChangeOwnerAddress(newOwner []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionChangeOwnerAddress).Bytes(newOwner)
+}
+
+// SetUserName appends to the data string all the elements required to set the user name of an account.
+func (builder *txDataBuilder) SetUserName(userName []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionSetUserName).Bytes(userName)
+}
+
+// DeleteUserName appends to the data string all the elements required to delete the user name of an account.
+func (builder *txDataBuilder) DeleteUserName() *txDataBuilder {
+	return builder.Func(deleteUserNameFunction)
+}
+
+// SaveKeyValue appends to the data string all the elements required to save key-value pairs in the account storage.
+func (builder *txDataBuilder) SaveKeyValue(pairs ...KeyValue) *txDataBuilder {
+	builder.Func(core.BuiltInFunctionSaveKeyValue)
+	for _, pair := range pairs {
+		builder.Bytes(pair.Key).Bytes(pair.Value)
+	}
+
+	return builder
+}
+
+// ESDTTransfer appends to the data string all the elements required to transfer fungible ESDT tokens. A smart
+// contract call can follow, with SCCall.
+func (builder *txDataBuilder) ESDTTransfer(token []byte, value *big.Int) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTTransfer).Bytes(token).BigUint(value)
+}
+
+// ESDTNFTTransfer appends to the data string all the elements required to transfer an NFT, SFT or meta ESDT. The
+// transaction is sent to the sender itself. A smart contract call can follow, with SCCall.
+func (builder *txDataBuilder) ESDTNFTTransfer(token []byte, nonce uint64, quantity *big.Int, destination []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTTransfer).Bytes(token).Uint64(nonce).BigUint(quantity).Bytes(destination)
+}
+
+// MultiESDTNFTTransfer appends to the data string all the elements required to transfer several tokens at once. The
+// transaction is sent to the sender itself. A smart contract call can follow, with SCCall. A nil transfer is neither
+// appended nor counted and ErrNilTransferEntry is returned by Err.
+func (builder *txDataBuilder) MultiESDTNFTTransfer(destination []byte, transfers ...*TransferEntry) *txDataBuilder {
+	numTransfers := 0
+	for _, transfer := range transfers {
+		if transfer == nil {
+			builder.setErr(ErrNilTransferEntry)
+			continue
+		}
+		numTransfers++
+	}
+
+	builder.Func(core.BuiltInFunctionMultiESDTNFTTransfer).Bytes(destination).Int(numTransfers)
+	for _, transfer := range transfers {
+		if transfer != nil {
+			builder.Bytes(transfer.TokenIdentifier).Uint64(transfer.Nonce).BigUint(transfer.Value)
+		}
+	}
+
+	return builder
+}
+
+// ESDTBurn appends to the data string all the elements required to burn fungible ESDT tokens.
+func (builder *txDataBuilder) ESDTBurn(token []byte, value *big.Int) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTBurn).Bytes(token).BigUint(value)
+}
+
+// ESDTPause appends to the data string all the elements required to pause a token.
+func (builder *txDataBuilder) ESDTPause(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTPause).Bytes(token)
+}
+
+// ESDTUnPause appends to the data string all the elements required to unpause a token.
+func (builder *txDataBuilder) ESDTUnPause(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTUnPause).Bytes(token)
+}
+
+// SetESDTRole appends to the data string all the elements required to set roles of a token.
+func (builder *txDataBuilder) SetESDTRole(token []byte, roles ...[]byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionSetESDTRole).Bytes(token).BytesList(roles...)
+}
+
+// UnSetESDTRole appends to the data string all the elements required to unset roles of a token.
+func (builder *txDataBuilder) UnSetESDTRole(token []byte, roles ...[]byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionUnSetESDTRole).Bytes(token).BytesList(roles...)
+}
+
+// ESDTLocalBurn appends to the data string all the elements required to burn fungible tokens with the local burn role.
+func (builder *txDataBuilder) ESDTLocalBurn(token []byte, value *big.Int) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTLocalBurn).Bytes(token).BigUint(value)
+}
+
+// ESDTLocalMint appends to the data string all the elements required to mint fungible tokens with the local mint role.
+func (builder *txDataBuilder) ESDTLocalMint(token []byte, value *big.Int) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTLocalMint).Bytes(token).BigUint(value)
+}
+
+// ESDTNFTAddQuantity appends to the data string all the elements required to add quantity to an SFT.
+func (builder *txDataBuilder) ESDTNFTAddQuantity(token []byte, nonce uint64, quantity *big.Int) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTAddQuantity).Bytes(token).Uint64(nonce).BigUint(quantity)
+}
+
+// ESDTNFTBurn appends to the data string all the elements required to burn an NFT, SFT or meta ESDT.
+func (builder *txDataBuilder) ESDTNFTBurn(token []byte, nonce uint64, quantity *big.Int) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTBurn).Bytes(token).Uint64(nonce).BigUint(quantity)
+}
+
+// ESDTNFTCreate appends to the data string all the elements required to create an NFT, SFT or meta ESDT. The nonce
+// and the creator of the metadata are not used. A nil metadata is not appended and ErrNilMetaData is returned by Err.
+func (builder *txDataBuilder) ESDTNFTCreate(token []byte, quantity *big.Int, metaData *esdt.MetaData) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTCreate).Bytes(token).BigUint(quantity).metaDataFields(metaData)
+}
+
+// ESDTFreeze appends to the data string all the elements required to freeze a token. For the NFTs, the token is
+// the identifier followed by the nonce.
+func (builder *txDataBuilder) ESDTFreeze(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTFreeze).Bytes(token)
+}
+
+// ESDTUnFreeze appends to the data string all the elements required to unfreeze a token. For the NFTs, the token is
+// the identifier followed by the nonce.
+func (builder *txDataBuilder) ESDTUnFreeze(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTUnFreeze).Bytes(token)
+}
+
+// ESDTWipe appends to the data string all the elements required to wipe a token. For the NFTs, the token is the
+// identifier followed by the nonce.
+func (builder *txDataBuilder) ESDTWipe(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTWipe).Bytes(token)
+}
+
+// ESDTNFTCreateRoleTransfer appends to the data string all the elements required to transfer the NFT create role.
+func (builder *txDataBuilder) ESDTNFTCreateRoleTransfer(token []byte, destination []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTCreateRoleTransfer).Bytes(token).Bytes(destination)
+}
+
+// ESDTNFTUpdateAttributes appends to the data string all the elements required to update the attributes of an NFT.
+func (builder *txDataBuilder) ESDTNFTUpdateAttributes(token []byte, nonce uint64, attributes []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTUpdateAttributes).Bytes(token).Uint64(nonce).Bytes(attributes)
+}
+
+// ESDTNFTAddURI appends to the data string all the elements required to add URIs to an NFT.
+func (builder *txDataBuilder) ESDTNFTAddURI(token []byte, nonce uint64, uris ...[]byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTNFTAddURI).Bytes(token).Uint64(nonce).BytesList(uris...)
+}
+
+// ESDTSetLimitedTransfer appends to the data string all the elements required to limit the transfers of a token.
+func (builder *txDataBuilder) ESDTSetLimitedTransfer(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTSetLimitedTransfer).Bytes(token)
+}
+
+// ESDTUnSetLimitedTransfer appends to the data string all the elements required to remove the transfer limit of a token.
+func (builder *txDataBuilder) ESDTUnSetLimitedTransfer(token []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionESDTUnSetLimitedTransfer).Bytes(token)
+}
+
+// ESDTDeleteMetadata appends to the data string all the elements required to delete the metadata of nonce intervals.
+// A nil token is not appended and ErrNilTokenEntry is returned by Err.
+func (builder *txDataBuilder) ESDTDeleteMetadata(tokens ...*TokenNonceIntervals) *txDataBuilder {
+	builder.Func(vmcommon.ESDTDeleteMetadata)
+	for _, token := range tokens {
+		if token == nil {
+			builder.setErr(ErrNilTokenEntry)
+			continue
+		}
+		builder.Bytes(token.TokenIdentifier).Int(len(token.Intervals))
+		for _, interval := range token.Intervals {
+			builder.Uint64(interval.Start).Uint64(interval.End)
+		}
+	}
+
+	return builder
+}
+
+// ESDTAddMetadata appends to the data string all the elements required to add the metadata of token nonces. A nil
+// token is not appended and ErrNilTokenEntry is returned by Err.
+func (builder *txDataBuilder) ESDTAddMetadata(tokens ...*TokenMetaData) *txDataBuilder {
+	builder.Func(vmcommon.ESDTAddMetadata)
+	for _, token := range tokens {
+		if token == nil {
+			builder.setErr(ErrNilTokenEntry)
+			continue
+		}
+		builder.Bytes(token.TokenIdentifier).Uint64(token.Nonce).Bytes(token.MetaData)
+	}
+
+	return builder
+}
+
+// ESDTSetBurnRoleForAll appends to the data string all the elements required to let every account burn a token.
+func (builder *txDataBuilder) ESDTSetBurnRoleForAll(token []byte) *txDataBuilder {
+	return builder.Func(vmcommon.BuiltInFunctionESDTSetBurnRoleForAll).Bytes(token)
+}
+
+// ESDTUnSetBurnRoleForAll appends to the data string all the elements required to stop every account from burning a token.
+func (builder *txDataBuilder) ESDTUnSetBurnRoleForAll(token []byte) *txDataBuilder {
+	return builder.Func(vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll).Bytes(token)
+}
+
+// ESDTTransferRoleAddAddress appends to the data string all the elements required to add addresses with the transfer role.
+func (builder *txDataBuilder) ESDTTransferRoleAddAddress(token []byte, addresses ...[]byte) *txDataBuilder {
+	return builder.Func(vmcommon.BuiltInFunctionESDTTransferRoleAddAddress).Bytes(token).BytesList(addresses...)
+}
+
+// ESDTTransferRoleDeleteAddress appends to the data string all the elements required to remove addresses with the transfer role.
+func (builder *txDataBuilder) ESDTTransferRoleDeleteAddress(token []byte, addresses ...[]byte) *txDataBuilder {
+	return builder.Func(vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress).Bytes(token).BytesList(addresses...)
+}
+
+// SetGuardian appends to the data string all the elements required to set the guardian of an account.
+func (builder *txDataBuilder) SetGuardian(guardian []byte, serviceUID []byte) *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionSetGuardian).Bytes(guardian).Bytes(serviceUID)
+}
+
+// GuardAccount appends to the data string all the elements required to guard an account.
+func (builder *txDataBuilder) GuardAccount() *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionGuardAccount)
+}
+
+// UnGuardAccount appends to the data string all the elements required to unguard an account.
+func (builder *txDataBuilder) UnGuardAccount() *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionUnGuardAccount)
+}
+
+// MigrateDataTrie appends to the data string all the elements required to migrate the data trie of an account.
+func (builder *txDataBuilder) MigrateDataTrie() *txDataBuilder {
+	return builder.Func(core.BuiltInFunctionMigrateDataTrie)
+}
+
+// ESDTSetTokenType appends to the data string all the elements required to set the type of a token.
+func (builder *txDataBuilder) ESDTSetTokenType(token []byte, tokenType []byte) *txDataBuilder {
+	return builder.Func(core.ESDTSetTokenType).Bytes(token).Bytes(tokenType)
+}
+
+// ESDTMetaDataRecreate appends to the data string all the elements required to recreate the metadata of a token
+// nonce. The creator of the metadata is not used. A nil metadata is not appended and ErrNilMetaData is returned by Err.
+func (builder *txDataBuilder) ESDTMetaDataRecreate(token []byte, metaData *esdt.MetaData) *txDataBuilder {
+	return builder.Func(core.ESDTMetaDataRecreate).Bytes(token).nonceAndMetaDataFields(metaData)
+}
+
+// ESDTMetaDataUpdate appends to the data string all the elements required to update the metadata of a token nonce.
+// The creator of the metadata is not used. A nil metadata is not appended and ErrNilMetaData is returned by Err.
+func (builder *txDataBuilder) ESDTMetaDataUpdate(token []byte, metaData *esdt.MetaData) *txDataBuilder {
+	return builder.Func(core.ESDTMetaDataUpdate).Bytes(token).nonceAndMetaDataFields(metaData)
+}
+
+// ESDTSetNewURIs appends to the data string all the elements required to replace the URIs of a token nonce.
+func (builder *txDataBuilder) ESDTSetNewURIs(token []byte, nonce uint64, uris ...[]byte) *txDataBuilder {
+	return builder.Func(core.ESDTSetNewURIs).Bytes(token).Uint64(nonce).BytesList(uris...)
+}
+
+// ESDTModifyRoyalties appends to the data string all the elements required to change the royalties of a token nonce.
+func (builder *txDataBuilder) ESDTModifyRoyalties(token []byte, nonce uint64, royalties uint32) *txDataBuilder {
+	return builder.Func(core.ESDTModifyRoyalties).Bytes(token).Uint64(nonce).Uint64(uint64(royalties))
+}
+
+// ESDTModifyCreator appends to the data string all the elements required to make the caller the creator of a token nonce.
+func (builder *txDataBuilder) ESDTModifyCreator(token []byte, nonce uint64) *txDataBuilder {
+	return builder.Func(core.ESDTModifyCreator).Bytes(token).Uint64(nonce)
+}
+
+func (builder *txDataBuilder) nonceAndMetaDataFields(metaData *esdt.MetaData) *txDataBuilder {
+	if metaData == nil {
+		return builder.setErr(ErrNilMetaData)
+	}
+
+	return builder.Uint64(metaData.Nonce).metaDataFields(metaData)
+}
+
+func (builder *txDataBuilder) metaDataFields(metaData *esdt.MetaData) *txDataBuilder {
+	if metaData == nil {
+		return builder.setErr(ErrNilMetaData)
+	}
+
+	return builder.
+		Bytes(metaData.Name).
+		Uint64(uint64(metaData.Royalties)).
+		Bytes(metaData.Hash).
+		Bytes(metaData.Attributes).
+		BytesList(metaData.URIs...)
+}
@@ -0,0 +1,325 @@
+package txDataBuilder
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender      = bytes.Repeat([]byte{1}, 32)
+	destination = bytes.Repeat([]byte{2}, 32)
+	token       = []byte("TKN-abcdef")
+	nft         = []byte("NFT-abcdef")
+)
+
+type builderTestCase struct {
+	name       string
+	builder    *txDataBuilder
+	function   string
+	arguments  [][]byte
+	execOnDest bool
+}
+
+func createMetaData() *esdt.MetaData {
+	return &esdt.MetaData{
+		Nonce:      7,
+		Name:       []byte("name"),
+		Royalties:  1000,
+		Hash:       []byte("hash"),
+		Attributes: []byte("attributes"),
+		URIs:       [][]byte{[]byte("uri1"), []byte("uri2")},
+	}
+}
+
+func createBuilderTestCases() []builderTestCase {
+	metaDataArguments := [][]byte{[]byte("name"), {0x03, 0xe8}, []byte("hash"), []byte("attributes"), []byte("uri1"), []byte("uri2")}
+
+	return []builderTestCase{
+		{name: "ClaimDeveloperRewards", builder: NewBuilder().ClaimDeveloperRewards(), function: core.BuiltInFunctionClaimDeveloperRewards, arguments: [][]byte{}},
+		{name: "ChangeOwnerAddress", builder: NewBuilder().ChangeOwnerAddress(destination), function: core.BuiltInFunctionChangeOwnerAddress, arguments: [][]byte{destination}},
+		{name: "SetUserName", builder: NewBuilder().SetUserName([]byte("alice.elrond")), function: core.BuiltInFunctionSetUserName, arguments: [][]byte{[]byte("alice.elrond")}},
+		{name: "DeleteUserName", builder: NewBuilder().DeleteUserName(), function: deleteUserNameFunction, arguments: [][]byte{}},
+		{
+			name:      "SaveKeyValue",
+			builder:   NewBuilder().SaveKeyValue(KeyValue{Key: []byte("k1"), Value: []byte("v1")}, KeyValue{Key: []byte("k2")}),
+			function:  core.BuiltInFunctionSaveKeyValue,
+			arguments: [][]byte{[]byte("k1"), []byte("v1"), []byte("k2"), {}},
+		},
+		{name: "ESDTTransfer", builder: NewBuilder().ESDTTransfer(token, big.NewInt(100)), function: core.BuiltInFunctionESDTTransfer, arguments: [][]byte{token, {100}}},
+		{name: "ESDTBurn", builder: NewBuilder().ESDTBurn(token, big.NewInt(5)), function: core.BuiltInFunctionESDTBurn, arguments: [][]byte{token, {5}}},
+		{name: "ESDTPause", builder: NewBuilder().ESDTPause(token), function: core.BuiltInFunctionESDTPause, arguments: [][]byte{token}},
+		{name: "ESDTUnPause", builder: NewBuilder().ESDTUnPause(token), function: core.BuiltInFunctionESDTUnPause, arguments: [][]byte{token}},
+		{
+			name:      "SetESDTRole",
+			builder:   NewBuilder().SetESDTRole(token, []byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleLocalBurn)),
+			function:  core.BuiltInFunctionSetESDTRole,
+			arguments: [][]byte{token, []byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleLocalBurn)},
+		},
+		{
+			name:      "UnSetESDTRole",
+			builder:   NewBuilder().UnSetESDTRole(token, []byte(core.ESDTRoleLocalMint)),
+			function:  core.BuiltInFunctionUnSetESDTRole,
+			arguments: [][]byte{token, []byte(core.ESDTRoleLocalMint)},
+		},
+		{name: "ESDTLocalBurn", builder: NewBuilder().ESDTLocalBurn(token, big.NewInt(1)), function: core.BuiltInFunctionESDTLocalBurn, arguments: [][]byte{token, {1}}},
+		{name: "ESDTLocalMint", builder: NewBuilder().ESDTLocalMint(token, nil), function: core.BuiltInFunctionESDTLocalMint, arguments: [][]byte{token, {}}},
+		{name: "ESDTNFTAddQuantity", builder: NewBuilder().ESDTNFTAddQuantity(nft, 2, big.NewInt(3)), function: core.BuiltInFunctionESDTNFTAddQuantity, arguments: [][]byte{nft, {2}, {3}}},
+		{name: "ESDTNFTBurn", builder: NewBuilder().ESDTNFTBurn(nft, 256, big.NewInt(1)), function: core.BuiltInFunctionESDTNFTBurn, arguments: [][]byte{nft, {1, 0}, {1}}},
+		{
+			name:      "ESDTNFTCreate",
+			builder:   NewBuilder().ESDTNFTCreate(nft, big.NewInt(1), createMetaData()),
+			function:  core.BuiltInFunctionESDTNFTCreate,
+			arguments: append([][]byte{nft, {1}}, metaDataArguments...),
+		},
+		{
+			name:       "ESDTNFTCreate on behalf of a contract",
+			builder:    NewBuilder().ESDTNFTCreate(nft, big.NewInt(1), createMetaData()).Bytes(destination),
+			function:   core.BuiltInFunctionESDTNFTCreate,
+			arguments:  append(append([][]byte{nft, {1}}, metaDataArguments...), destination),
+			execOnDest: true,
+		},
+		{name: "ESDTFreeze", builder: NewBuilder().ESDTFreeze(token), function: core.BuiltInFunctionESDTFreeze, arguments: [][]byte{token}},
+		{name: "ESDTUnFreeze", builder: NewBuilder().ESDTUnFreeze(token), function: core.BuiltInFunctionESDTUnFreeze, arguments: [][]byte{token}},
+		{name: "ESDTWipe", builder: NewBuilder().ESDTWipe(token), function: core.BuiltInFunctionESDTWipe, arguments: [][]byte{token}},
+		{
+			name:      "ESDTNFTTransfer",
+			builder:   NewBuilder().ESDTNFTTransfer(nft, 1, big.NewInt(1), destination),
+			function:  core.BuiltInFunctionESDTNFTTransfer,
+			arguments: [][]byte{nft, {1}, {1}, destination},
+		},
+		{
+			name:      "ESDTNFTCreateRoleTransfer",
+			builder:   NewBuilder().ESDTNFTCreateRoleTransfer(nft, destination),
+			function:  core.BuiltInFunctionESDTNFTCreateRoleTransfer,
+			arguments: [][]byte{nft, destination},
+		},
+		{
+			name:      "ESDTNFTUpdateAttributes",
+			builder:   NewBuilder().ESDTNFTUpdateAttributes(nft, 1, []byte("attributes")),
+			function:  core.BuiltInFunctionESDTNFTUpdateAttributes,
+			arguments: [][]byte{nft, {1}, []byte("attributes")},
+		},
+		{
+			name:      "ESDTNFTAddURI",
+			builder:   NewBuilder().ESDTNFTAddURI(nft, 1, []byte("uri")),
+			function:  core.BuiltInFunctionESDTNFTAddURI,
+			arguments: [][]byte{nft, {1}, []byte("uri")},
+		},
+		{
+			name: "MultiESDTNFTTransfer",
+			builder: NewBuilder().MultiESDTNFTTransfer(
+				destination,
+				EGLDTransferEntry(big.NewInt(10)),
+				&TransferEntry{TokenIdentifier: token, Value: big.NewInt(20)},
+				&TransferEntry{TokenIdentifier: nft, Nonce: 3, Value: big.NewInt(1)},
+			).SCCall("add", []byte{1}),
+			function: core.BuiltInFunctionMultiESDTNFTTransfer,
+			arguments: [][]byte{
+				destination, {3},
+				[]byte(vmcommon.EGLDIdentifier), {}, {10},
+				token, {}, {20},
+				nft, {3}, {1},
+				[]byte("add"), {1},
+			},
+		},
+		{name: "ESDTSetLimitedTransfer", builder: NewBuilder().ESDTSetLimitedTransfer(token), function: core.BuiltInFunctionESDTSetLimitedTransfer, arguments: [][]byte{token}},
+		{name: "ESDTUnSetLimitedTransfer", builder: NewBuilder().ESDTUnSetLimitedTransfer(token), function: core.BuiltInFunctionESDTUnSetLimitedTransfer, arguments: [][]byte{token}},
+		{
+			name: "ESDTDeleteMetadata",
+			builder: NewBuilder().ESDTDeleteMetadata(
+				&TokenNonceIntervals{TokenIdentifier: nft, Intervals: []NonceInterval{{Start: 1, End: 2}, {Start: 5, End: 9}}},
+				&TokenNonceIntervals{TokenIdentifier: token, Intervals: []NonceInterval{{Start: 1, End: 1}}},
+			),
+			function:  vmcommon.ESDTDeleteMetadata,
+			arguments: [][]byte{nft, {2}, {1}, {2}, {5}, {9}, token, {1}, {1}, {1}},
+		},
+		{
+			name:      "ESDTAddMetadata",
+			builder:   NewBuilder().ESDTAddMetadata(&TokenMetaData{TokenIdentifier: nft, Nonce: 1, MetaData: []byte("marshaled")}),
+			function:  vmcommon.ESDTAddMetadata,
+			arguments: [][]byte{nft, {1}, []byte("marshaled")},
+		},
+		{name: "ESDTSetBurnRoleForAll", builder: NewBuilder().ESDTSetBurnRoleForAll(token), function: vmcommon.BuiltInFunctionESDTSetBurnRoleForAll, arguments: [][]byte{token}},
+		{name: "ESDTUnSetBurnRoleForAll", builder: NewBuilder().ESDTUnSetBurnRoleForAll(token), function: vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll, arguments: [][]byte{token}},
+		{
+			name:      "ESDTTransferRoleAddAddress",
+			builder:   NewBuilder().ESDTTransferRoleAddAddress(token, sender, destination),
+			function:  vmcommon.BuiltInFunctionESDTTransferRoleAddAddress,
+			arguments: [][]byte{token, sender, destination},
+		},
+		{
+			name:      "ESDTTransferRoleDeleteAddress",
+			builder:   NewBuilder().ESDTTransferRoleDeleteAddress(token, destination),
+			function:  vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress,
+			arguments: [][]byte{token, destination},
+		},
+		{
+			name:      "SetGuardian",
+			builder:   NewBuilder().SetGuardian(destination, []byte("serviceUID")),
+			function:  core.BuiltInFunctionSetGuardian,
+			arguments: [][]byte{destination, []byte("serviceUID")},
+		},
+		{name: "GuardAccount", builder: NewBuilder().GuardAccount(), function: core.BuiltInFunctionGuardAccount, arguments: [][]byte{}},
+		{name: "UnGuardAccount", builder: NewBuilder().UnGuardAccount(), function: core.BuiltInFunctionUnGuardAccount, arguments: [][]byte{}},
+		{name: "MigrateDataTrie", builder: NewBuilder().MigrateDataTrie(), function: core.BuiltInFunctionMigrateDataTrie, arguments: [][]byte{}},
+		{
+			name:      "ESDTSetTokenType",
+			builder:   NewBuilder().ESDTSetTokenType(nft, []byte(core.DynamicNFTESDT)),
+			function:  core.ESDTSetTokenType,
+			arguments: [][]byte{nft, []byte(core.DynamicNFTESDT)},
+		},
+		{
+			name:      "ESDTMetaDataRecreate",
+			builder:   NewBuilder().ESDTMetaDataRecreate(nft, createMetaData()),
+			function:  core.ESDTMetaDataRecreate,
+			arguments: append([][]byte{nft, {7}}, metaDataArguments...),
+		},
+		{
+			name:      "ESDTMetaDataUpdate",
+			builder:   NewBuilder().ESDTMetaDataUpdate(nft, createMetaData()),
+			function:  core.ESDTMetaDataUpdate,
+			arguments: append([][]byte{nft, {7}}, metaDataArguments...),
+		},
+		{
+			name:      "ESDTSetNewURIs",
+			builder:   NewBuilder().ESDTSetNewURIs(nft, 7, []byte("uri")),
+			function:  core.ESDTSetNewURIs,
+			arguments: [][]byte{nft, {7}, []byte("uri")},
+		},
+		{
+			name:      "ESDTModifyRoyalties",
+			builder:   NewBuilder().ESDTModifyRoyalties(nft, 7, 500),
+			function:  core.ESDTModifyRoyalties,
+			arguments: [][]byte{nft, {7}, {0x01, 0xf4}},
+		},
+		{name: "ESDTModifyCreator", builder: NewBuilder().ESDTModifyCreator(nft, 7), function: core.ESDTModifyCreator, arguments: [][]byte{nft, {7}}},
+	}
+}
+
+func TestTxDataBuilder_BuiltInFunctionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	parser := parsers.NewCallArgsParser()
+	for _, testCase := range createBuilderTestCases() {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Nil(t, testCase.builder.Err())
+			function, arguments, err := parser.ParseData(testCase.builder.ToString())
+			require.Nil(t, err)
+			assert.Equal(t, testCase.function, function)
+			assert.Equal(t, testCase.arguments, arguments)
+
+			schema, ok := builtInFunctions.GetFunctionSchema(function)
+			require.True(t, ok)
+			input := &vmcommon.ContractCallInput{
+				VMInput:  vmcommon.VMInput{CallerAddr: sender, Arguments: arguments},
+				Function: function,
+			}
+			if testCase.execOnDest {
+				input.CallType = vm.ExecOnDestByCaller
+			}
+			assert.Nil(t, schema.Validate(input))
+		})
+	}
+}
+
+func TestTxDataBuilder_TransfersRoundTripThroughESDTTransferParser(t *testing.T) {
+	t.Parallel()
+
+	callArgsParser := parsers.NewCallArgsParser()
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(&mock.MarshalizerMock{})
+
+	parse := func(builder *txDataBuilder) *vmcommon.ParsedESDTTransfers {
+		function, arguments, err := callArgsParser.ParseData(builder.ToString())
+		require.Nil(t, err)
+		parsed, err := esdtTransferParser.ParseESDTTransfers(sender, sender, function, arguments)
+		require.Nil(t, err)
+		return parsed
+	}
+
+	parsed := parse(NewBuilder().ESDTTransfer(token, big.NewInt(100)).SCCall("deposit", []byte("arg")))
+	assert.Equal(t, sender, parsed.RcvAddr)
+	assert.Equal(t, "deposit", parsed.CallFunction)
+	assert.Equal(t, [][]byte{[]byte("arg")}, parsed.CallArgs)
+	require.Len(t, parsed.ESDTTransfers, 1)
+	assert.Equal(t, token, parsed.ESDTTransfers[0].ESDTTokenName)
+	assert.Equal(t, big.NewInt(100), parsed.ESDTTransfers[0].ESDTValue)
+
+	parsed = parse(NewBuilder().ESDTNFTTransfer(nft, 5, big.NewInt(2), destination))
+	assert.Equal(t, destination, parsed.RcvAddr)
+	assert.Empty(t, parsed.CallFunction)
+	require.Len(t, parsed.ESDTTransfers, 1)
+	assert.Equal(t, nft, parsed.ESDTTransfers[0].ESDTTokenName)
+	assert.Equal(t, uint64(5), parsed.ESDTTransfers[0].ESDTTokenNonce)
+	assert.Equal(t, big.NewInt(2), parsed.ESDTTransfers[0].ESDTValue)
+
+	transfers := []*TransferEntry{
+		EGLDTransferEntry(big.NewInt(10)),
+		{TokenIdentifier: token, Value: big.NewInt(20)},
+		{TokenIdentifier: nft, Nonce: 3, Value: big.NewInt(1)},
+	}
+	parsed = parse(NewBuilder().MultiESDTNFTTransfer(destination, transfers...).SCCall("add", []byte{1}, []byte{2}))
+	assert.Equal(t, destination, parsed.RcvAddr)
+	assert.Equal(t, "add", parsed.CallFunction)
+	assert.Equal(t, [][]byte{{1}, {2}}, parsed.CallArgs)
+	require.Len(t, parsed.ESDTTransfers, len(transfers))
+	for i, transfer := range transfers {
+		assert.Equal(t, transfer.TokenIdentifier, parsed.ESDTTransfers[i].ESDTTokenName)
+		assert.Equal(t, transfer.Nonce, parsed.ESDTTransfers[i].ESDTTokenNonce)
+		assert.Equal(t, transfer.Value, parsed.ESDTTransfers[i].ESDTValue)
+	}
+}
+
+func TestTxDataBuilder_BuiltInFunctionsInvalidArgumentsShouldSetErr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		builder     *txDataBuilder
+		expectedErr error
+	}{
+		{name: "ESDTNFTCreate nil metadata", builder: NewBuilder().ESDTNFTCreate(nft, big.NewInt(1), nil), expectedErr: ErrNilMetaData},
+		{name: "ESDTMetaDataRecreate nil metadata", builder: NewBuilder().ESDTMetaDataRecreate(nft, nil), expectedErr: ErrNilMetaData},
+		{name: "ESDTMetaDataUpdate nil metadata", builder: NewBuilder().ESDTMetaDataUpdate(nft, nil), expectedErr: ErrNilMetaData},
+		{name: "MultiESDTNFTTransfer nil transfer", builder: NewBuilder().MultiESDTNFTTransfer(destination, nil), expectedErr: ErrNilTransferEntry},
+		{name: "ESDTDeleteMetadata nil token", builder: NewBuilder().ESDTDeleteMetadata(nil), expectedErr: ErrNilTokenEntry},
+		{name: "ESDTAddMetadata nil token", builder: NewBuilder().ESDTAddMetadata(nil), expectedErr: ErrNilTokenEntry},
+		{name: "ESDTTransfer negative value", builder: NewBuilder().ESDTTransfer(token, big.NewInt(-1)), expectedErr: ErrNegativeValue},
+		{
+			name:        "MultiESDTNFTTransfer negative value",
+			builder:     NewBuilder().MultiESDTNFTTransfer(destination, &TransferEntry{TokenIdentifier: token, Value: big.NewInt(-5)}),
+			expectedErr: ErrNegativeValue,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedErr, testCase.builder.Err())
+		})
+	}
+
+	builder := NewBuilder().MultiESDTNFTTransfer(destination, nil, &TransferEntry{TokenIdentifier: token, Value: big.NewInt(20)})
+	assert.Equal(t, ErrNilTransferEntry, builder.Err())
+	expected := NewBuilder().MultiESDTNFTTransfer(destination, &TransferEntry{TokenIdentifier: token, Value: big.NewInt(20)})
+	assert.Equal(t, expected.ToString(), builder.ToString(), "a nil transfer must not be counted")
+
+	builder = NewBuilder().ESDTTransfer(token, big.NewInt(-1))
+	builder.Clear().ESDTTransfer(token, big.NewInt(1))
+	assert.Nil(t, builder.Err())
+}
@@ -0,0 +1,15 @@
+package txDataBuilder
+
+import "errors"
+
+// ErrNilMetaData signals that a nil token metadata was provided
+var ErrNilMetaData = errors.New("nil token metadata")
+
+// ErrNilTransferEntry signals that a nil transfer entry was provided
+var ErrNilTransferEntry = errors.New("nil transfer entry")
+
+// ErrNilTokenEntry signals that a nil token entry was provided
+var ErrNilTokenEntry = errors.New("nil token entry")
+
+// ErrNegativeValue signals that a negative value was provided where an unsigned value is expected
+var ErrNegativeValue = errors.New("negative value")
@@ -0,0 +1,111 @@
+package vmcommon
+
+import "errors"
+
+// VMError is an error that carries the ReturnCode to be set in the VMOutput, a stable machine-readable code and
+// optional context about the call that produced it. A VMError is immutable, so the sentinel errors built with
+// NewVMError can be safely shared.
+type VMError struct {
+	err        error
+	returnCode ReturnCode
+	code       string
+	function   string
+	tokenID    []byte
+}
+
+// NewVMError creates a new VMError, classifying the provided error
+func NewVMError(err error, returnCode ReturnCode, code string) *VMError {
+	return &VMError{
+		err:        err,
+		returnCode: returnCode,
+		code:       code,
+	}
+}
+
+// Error returns the message of the wrapped error. The context is not part of the message, as the message ends up
+// in the VMOutput's ReturnMessage.
+func (e *VMError) Error() string {
+	if e.err == nil {
+		return "unknown error"
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *VMError) Unwrap() error {
+	return e.err
+}
+
+// ReturnCode returns the code to be set on the VMOutput when the execution fails with this error
+func (e *VMError) ReturnCode() ReturnCode {
+	return e.returnCode
+}
+
+// Code returns the stable, machine-readable identifier of the error
+func (e *VMError) Code() string {
+	return e.code
+}
+
+// Function returns the name of the function that produced the error, if known
+func (e *VMError) Function() string {
+	return e.function
+}
+
+// TokenID returns a copy of the token that the failed operation was handling, if any
+func (e *VMError) TokenID() []byte {
+	if len(e.tokenID) == 0 {
+		return nil
+	}
+
+	return append([]byte(nil), e.tokenID...)
+}
+
+// AddErrorContext returns a new VMError that wraps the provided error, keeps its classification and adds the function
+// name and token ID, if the provided error is, or wraps, a VMError. The error is returned unchanged otherwise.
+// The provided error is never modified and the returned one has the same message.
+func AddErrorContext(err error, function string, tokenID []byte) error {
+	vmErr := &VMError{}
+	if !errors.As(err, &vmErr) {
+		return err
+	}
+
+	contextErr := &VMError{
+		err:        err,
+		returnCode: vmErr.returnCode,
+		code:       vmErr.code,
+		function:   function,
+	}
+	if len(tokenID) > 0 {
+		contextErr.tokenID = append([]byte(nil), tokenID...)
+	}
+
+	return contextErr
+}
+
+// GetReturnCodeFromError returns the ReturnCode carried by the provided error. Errors that are not classified
+// are considered user errors. A nil error yields Ok.
+func GetReturnCodeFromError(err error) ReturnCode {
+	if err == nil {
+		return Ok
+	}
+
+	vmErr := &VMError{}
+	if errors.As(err, &vmErr) {
+		return vmErr.returnCode
+	}
+
+	return UserError
+}
+
+// NewVMOutputFromError creates a VMOutput holding the return code and message of the provided error
+func NewVMOutputFromError(err error) *VMOutput {
+	vmOutput := &VMOutput{
+		ReturnCode: GetReturnCodeFromError(err),
+	}
+	if err != nil {
+		vmOutput.ReturnMessage = err.Error()
+	}
+
+	return vmOutput
+}
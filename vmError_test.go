@@ -0,0 +1,87 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errClassifiedForTest = NewVMError(errors.New("classified"), OutOfFunds, "CLASSIFIED")
+
+func TestVMError_ErrorAndUnwrap(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "classified", errClassifiedForTest.Error())
+	assert.Equal(t, "unknown error", (&VMError{}).Error())
+
+	wrapped := fmt.Errorf("%w for something", errClassifiedForTest)
+	assert.True(t, errors.Is(wrapped, errClassifiedForTest))
+
+	vmErr := &VMError{}
+	require.True(t, errors.As(wrapped, &vmErr))
+	assert.Equal(t, OutOfFunds, vmErr.ReturnCode())
+	assert.Equal(t, "CLASSIFIED", vmErr.Code())
+	assert.Empty(t, vmErr.Function())
+	assert.Nil(t, vmErr.TokenID())
+}
+
+func TestAddErrorContext(t *testing.T) {
+	t.Parallel()
+
+	plainErr := errors.New("plain")
+	assert.Equal(t, plainErr, AddErrorContext(plainErr, "function", []byte("TKN-abcdef")))
+
+	wrapped := fmt.Errorf("%w, extra", errClassifiedForTest)
+	tokenID := []byte("TKN-abcdef")
+	err := AddErrorContext(wrapped, "ESDTTransfer", tokenID)
+	assert.Equal(t, "classified, extra", err.Error())
+	assert.True(t, errors.Is(err, errClassifiedForTest))
+
+	vmErr := &VMError{}
+	require.True(t, errors.As(err, &vmErr))
+	assert.Equal(t, OutOfFunds, vmErr.ReturnCode())
+	assert.Equal(t, "CLASSIFIED", vmErr.Code())
+	assert.Equal(t, "ESDTTransfer", vmErr.Function())
+	assert.Equal(t, []byte("TKN-abcdef"), vmErr.TokenID())
+
+	tokenID[0] = 'X'
+	vmErr.TokenID()[1] = 'X'
+	assert.Equal(t, []byte("TKN-abcdef"), vmErr.TokenID())
+
+	sentinel := &VMError{}
+	require.True(t, errors.As(wrapped, &sentinel))
+	assert.True(t, sentinel == errClassifiedForTest)
+	assert.Empty(t, errClassifiedForTest.Function())
+	assert.Nil(t, errClassifiedForTest.TokenID())
+}
+
+func TestGetReturnCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Ok, GetReturnCodeFromError(nil))
+	assert.Equal(t, UserError, GetReturnCodeFromError(errors.New("plain")))
+	assert.Equal(t, OutOfFunds, GetReturnCodeFromError(fmt.Errorf("%w", errClassifiedForTest)))
+}
+
+func TestNewVMOutputFromError(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := NewVMOutputFromError(errClassifiedForTest)
+	assert.Equal(t, OutOfFunds, vmOutput.ReturnCode)
+	assert.Equal(t, "classified", vmOutput.ReturnMessage)
+
+	vmOutput = NewVMOutputFromError(nil)
+	assert.Equal(t, Ok, vmOutput.ReturnCode)
+	assert.Empty(t, vmOutput.ReturnMessage)
+}
+
+func TestReturnCode_StringShouldCoverAllCodes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "upgrade failed", UpgradeFailed.String())
+	assert.Equal(t, "simulate failed", SimulateFailed.String())
+	assert.Equal(t, "unknown error, code: 13", ReturnCode(13).String())
+}